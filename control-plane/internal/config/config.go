@@ -27,6 +27,24 @@ type UIConfig struct {
 	SourcePath string `yaml:"source_path" mapstructure:"source_path"` // Path to UI source for building
 	DistPath   string `yaml:"dist_path" mapstructure:"dist_path"`     // Path to built UI assets for serving
 	DevPort    int    `yaml:"dev_port" mapstructure:"dev_port"`       // Port for UI dev server
+	// MaxEventSubscribers caps concurrent execution event streams (SSE and WebSocket).
+	// Zero keeps the default of events.DefaultMaxStreamSubscribers; negative disables the cap.
+	MaxEventSubscribers int `yaml:"max_event_subscribers" mapstructure:"max_event_subscribers"`
+	// EventDropPolicy picks which event a slow execution event subscriber loses when its
+	// buffer is full: "drop_newest" (default) or "drop_oldest".
+	EventDropPolicy string `yaml:"event_drop_policy" mapstructure:"event_drop_policy"`
+	// Redaction masks execution input/output values in UI responses. Rules can be
+	// changed at runtime through the execution-redaction settings endpoint.
+	Redaction RedactionConfig `yaml:"redaction" mapstructure:"redaction"`
+}
+
+// RedactionConfig lists the execution data to mask in UI responses.
+type RedactionConfig struct {
+	// Paths are dot-separated field paths into stored payloads, e.g. "input.api_key";
+	// "*" matches any key or array element.
+	Paths []string `yaml:"paths" mapstructure:"paths"`
+	// KeyPatterns are regular expressions matched against field names at any depth.
+	KeyPatterns []string `yaml:"key_patterns" mapstructure:"key_patterns"`
 }
 
 // AgentFieldConfig holds the core AgentField server configuration.
@@ -0,0 +1,18 @@
+package events
+
+import "time"
+
+// CustomEvent is a domain event emitted by an agent, such as "approval_requested". Its
+// type is chosen by the agent rather than the control plane.
+type CustomEvent struct {
+	Type        string                 `json:"type"`
+	AgentNodeID string                 `json:"agent_node_id"`
+	ExecutionID string                 `json:"execution_id,omitempty"`
+	WorkflowID  string                 `json:"workflow_id,omitempty"`
+	RunID       string                 `json:"run_id,omitempty"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+}
+
+// GlobalCustomEventBus is the global bus for agent-emitted custom events.
+var GlobalCustomEventBus = NewEventBus[CustomEvent]()
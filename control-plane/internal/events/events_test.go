@@ -1,6 +1,7 @@
 package events
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -105,6 +106,81 @@ func TestExecutionEventBus_MultipleSubscribers(t *testing.T) {
 	}
 }
 
+// TestExecutionEventBus_TrySubscribeLimit tests that stream subscribers are capped
+// while internal subscribers are not
+func TestExecutionEventBus_TrySubscribeLimit(t *testing.T) {
+	bus := NewExecutionEventBus()
+	bus.SetMaxSubscribers(2)
+
+	_, err := bus.TrySubscribe("stream-1")
+	require.NoError(t, err)
+	_, err = bus.TrySubscribe("stream-2")
+	require.NoError(t, err)
+	bus.Subscribe("internal-1")
+
+	_, err = bus.TrySubscribe("stream-3")
+	require.ErrorIs(t, err, ErrTooManySubscribers)
+	require.Equal(t, 3, bus.GetSubscriberCount())
+
+	bus.Unsubscribe("stream-1")
+	_, err = bus.TrySubscribe("stream-3")
+	require.NoError(t, err)
+
+	bus.SetMaxSubscribers(0)
+	_, err = bus.TrySubscribe("stream-4")
+	require.NoError(t, err)
+}
+
+// TestExecutionEventBus_SlowSubscriberDropPolicy tests that a subscriber that never drains
+// does not stall the bus and has its dropped events counted under either policy
+func TestExecutionEventBus_SlowSubscriberDropPolicy(t *testing.T) {
+	for _, policy := range []DropPolicy{DropNewest, DropOldest} {
+		t.Run(string(policy), func(t *testing.T) {
+			bus := NewExecutionEventBus()
+			bus.SetDropPolicy(policy)
+			slow := bus.Subscribe("slow")
+			fast := bus.Subscribe("fast")
+			defer bus.Unsubscribe("slow")
+			defer bus.Unsubscribe("fast")
+
+			const total = executionSubscriberBuffer + 10
+			for i := 0; i < total; i++ {
+				bus.Publish(ExecutionEvent{Type: ExecutionUpdated, ExecutionID: fmt.Sprintf("exec-%d", i)})
+				select {
+				case event := <-fast:
+					require.Equal(t, fmt.Sprintf("exec-%d", i), event.ExecutionID)
+				case <-time.After(time.Second):
+					t.Fatalf("fast subscriber missed event %d", i)
+				}
+			}
+
+			require.Equal(t, uint64(10), bus.DroppedEvents("slow"))
+			require.Zero(t, bus.DroppedEvents("fast"))
+
+			first := <-slow
+			if policy == DropOldest {
+				require.Equal(t, "exec-10", first.ExecutionID)
+			} else {
+				require.Equal(t, "exec-0", first.ExecutionID)
+			}
+		})
+	}
+}
+
+// TestParseDropPolicy tests drop policy configuration values
+func TestParseDropPolicy(t *testing.T) {
+	policy, err := ParseDropPolicy("")
+	require.NoError(t, err)
+	require.Equal(t, DropNewest, policy)
+
+	policy, err = ParseDropPolicy(" Drop_Oldest ")
+	require.NoError(t, err)
+	require.Equal(t, DropOldest, policy)
+
+	_, err = ParseDropPolicy("drop_random")
+	require.Error(t, err)
+}
+
 // TestExecutionEventBus_ConcurrentPublish tests concurrent publishing
 func TestExecutionEventBus_ConcurrentPublish(t *testing.T) {
 	bus := NewExecutionEventBus()
@@ -2,11 +2,18 @@ package events
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Agent-Field/agentfield/control-plane/internal/logger"
 	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 // ExecutionEventType represents the type of execution event
@@ -31,26 +38,131 @@ type ExecutionEvent struct {
 	Data        interface{}        `json:"data,omitempty"`
 }
 
+// DefaultMaxStreamSubscribers is the default limit on subscribers added through
+// TrySubscribe, i.e. client-facing SSE and WebSocket streams.
+const DefaultMaxStreamSubscribers = 1000
+
+// ErrTooManySubscribers is returned by TrySubscribe when the stream subscriber limit is reached.
+var ErrTooManySubscribers = errors.New("too many execution event subscribers")
+
+// executionSubscriberBuffer is the number of events queued per subscriber before the
+// drop policy applies.
+const executionSubscriberBuffer = 100
+
+// DropPolicy decides which event is discarded when a subscriber's buffer is full.
+type DropPolicy string
+
+const (
+	// DropNewest discards the event being published, keeping the subscriber's backlog.
+	DropNewest DropPolicy = "drop_newest"
+	// DropOldest discards the subscriber's oldest queued event to make room for the new one.
+	DropOldest DropPolicy = "drop_oldest"
+)
+
+// ParseDropPolicy converts a configured policy name, accepting "" as DropNewest.
+func ParseDropPolicy(name string) (DropPolicy, error) {
+	switch DropPolicy(strings.ToLower(strings.TrimSpace(name))) {
+	case "", DropNewest:
+		return DropNewest, nil
+	case DropOldest:
+		return DropOldest, nil
+	default:
+		return "", fmt.Errorf("unknown event drop policy %q", name)
+	}
+}
+
+var (
+	executionEventSubscribersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "agentfield_execution_event_subscribers",
+		Help: "Number of subscribers currently registered on execution event buses.",
+	})
+	executionEventsDroppedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "agentfield_execution_events_dropped_total",
+		Help: "Execution events discarded because a subscriber's buffer was full.",
+	})
+)
+
+// executionSubscriber is a registered subscriber channel and the number of events
+// dropped for it.
+type executionSubscriber struct {
+	ch      chan ExecutionEvent
+	dropped atomic.Uint64
+}
+
 // ExecutionEventBus manages execution event broadcasting
 type ExecutionEventBus struct {
-	subscribers map[string]chan ExecutionEvent
-	mutex       sync.RWMutex
+	subscribers map[string]*executionSubscriber
+	// streams holds the IDs added through TrySubscribe, which count toward maxStreams.
+	streams    map[string]struct{}
+	maxStreams int
+	dropPolicy DropPolicy
+	mutex      sync.RWMutex
 }
 
 // NewExecutionEventBus creates a new execution event bus
 func NewExecutionEventBus() *ExecutionEventBus {
 	return &ExecutionEventBus{
-		subscribers: make(map[string]chan ExecutionEvent),
+		subscribers: make(map[string]*executionSubscriber),
+		streams:     make(map[string]struct{}),
+		maxStreams:  DefaultMaxStreamSubscribers,
+		dropPolicy:  DropNewest,
+	}
+}
+
+// SetDropPolicy sets how Publish treats subscribers whose buffer is full.
+func (bus *ExecutionEventBus) SetDropPolicy(policy DropPolicy) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.dropPolicy = policy
+}
+
+// DroppedEvents returns how many events have been dropped for a subscriber, or zero
+// if it is not registered.
+func (bus *ExecutionEventBus) DroppedEvents(subscriberID string) uint64 {
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+	if sub, exists := bus.subscribers[subscriberID]; exists {
+		return sub.dropped.Load()
 	}
+	return 0
 }
 
-// Subscribe adds a new subscriber to the event bus
+// SetMaxSubscribers sets how many TrySubscribe subscribers may be registered at once.
+// A value of zero or less removes the limit.
+func (bus *ExecutionEventBus) SetMaxSubscribers(max int) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.maxStreams = max
+}
+
+// Subscribe adds a new subscriber to the event bus. It is not subject to the
+// subscriber limit and is meant for internal consumers.
 func (bus *ExecutionEventBus) Subscribe(subscriberID string) chan ExecutionEvent {
 	bus.mutex.Lock()
 	defer bus.mutex.Unlock()
+	return bus.addLocked(subscriberID)
+}
 
-	ch := make(chan ExecutionEvent, 100) // Buffer to prevent blocking
-	bus.subscribers[subscriberID] = ch
+// TrySubscribe adds a client stream subscriber, returning ErrTooManySubscribers when
+// the limit set by SetMaxSubscribers is reached.
+func (bus *ExecutionEventBus) TrySubscribe(subscriberID string) (chan ExecutionEvent, error) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	if _, exists := bus.streams[subscriberID]; !exists && bus.maxStreams > 0 && len(bus.streams) >= bus.maxStreams {
+		logger.Logger.Warn().Msgf("[ExecutionEventBus] Rejected subscriber %s: %d stream subscribers at limit", subscriberID, len(bus.streams))
+		return nil, ErrTooManySubscribers
+	}
+	bus.streams[subscriberID] = struct{}{}
+	return bus.addLocked(subscriberID), nil
+}
+
+func (bus *ExecutionEventBus) addLocked(subscriberID string) chan ExecutionEvent {
+	if _, exists := bus.subscribers[subscriberID]; !exists {
+		executionEventSubscribersGauge.Inc()
+	}
+	ch := make(chan ExecutionEvent, executionSubscriberBuffer) // Buffer to prevent blocking
+	bus.subscribers[subscriberID] = &executionSubscriber{ch: ch}
 
 	logger.Logger.Debug().Msgf("[ExecutionEventBus] Subscriber %s added, total subscribers: %d", subscriberID, len(bus.subscribers))
 	return ch
@@ -61,9 +173,11 @@ func (bus *ExecutionEventBus) Unsubscribe(subscriberID string) {
 	bus.mutex.Lock()
 	defer bus.mutex.Unlock()
 
-	if ch, exists := bus.subscribers[subscriberID]; exists {
-		close(ch)
+	if sub, exists := bus.subscribers[subscriberID]; exists {
+		close(sub.ch)
 		delete(bus.subscribers, subscriberID)
+		delete(bus.streams, subscriberID)
+		executionEventSubscribersGauge.Dec()
 		logger.Logger.Debug().Msgf("[ExecutionEventBus] Subscriber %s removed, total subscribers: %d", subscriberID, len(bus.subscribers))
 	}
 }
@@ -76,17 +190,39 @@ func (bus *ExecutionEventBus) Publish(event ExecutionEvent) {
 	logger.Logger.Debug().Msgf("[ExecutionEventBus] Publishing event: %s for execution %s to %d subscribers",
 		event.Type, event.ExecutionID, len(bus.subscribers))
 
-	for subscriberID, ch := range bus.subscribers {
-		select {
-		case ch <- event:
-			// Event sent successfully
-		default:
-			// Channel is full, skip this subscriber to prevent blocking
-			logger.Logger.Warn().Msgf("[ExecutionEventBus] Warning: Channel full for subscriber %s, skipping event", subscriberID)
+	for subscriberID, sub := range bus.subscribers {
+		if !sub.deliver(event, bus.dropPolicy) {
+			// Channel is full; never block the bus on one slow subscriber
+			sub.dropped.Add(1)
+			executionEventsDroppedCounter.Inc()
+			logger.Logger.Warn().Msgf("[ExecutionEventBus] Warning: Channel full for subscriber %s, dropped event (%s)", subscriberID, bus.dropPolicy)
 		}
 	}
 }
 
+// deliver sends event without blocking. It reports false when an event was dropped:
+// the new one under DropNewest, or the oldest queued one under DropOldest.
+func (sub *executionSubscriber) deliver(event ExecutionEvent, policy DropPolicy) bool {
+	select {
+	case sub.ch <- event:
+		return true
+	default:
+	}
+	if policy != DropOldest {
+		return false
+	}
+	select {
+	case <-sub.ch:
+	default:
+	}
+	select {
+	case sub.ch <- event:
+	default:
+		// A concurrent publisher refilled the slot; the new event is the one lost.
+	}
+	return false
+}
+
 // GetSubscriberCount returns the number of active subscribers
 func (bus *ExecutionEventBus) GetSubscriberCount() int {
 	bus.mutex.RLock()
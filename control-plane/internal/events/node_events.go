@@ -27,6 +27,7 @@ const (
 	NodeUnifiedStatusChanged NodeEventType = "node_unified_status_changed"
 	NodeStateTransition      NodeEventType = "node_state_transition"
 	NodeStatusRefreshed      NodeEventType = "node_status_refreshed"
+	NodeTransitionTimedOut   NodeEventType = "node_transition_timed_out"
 	BulkStatusUpdate         NodeEventType = "bulk_status_update"
 )
 
@@ -397,6 +398,28 @@ func PublishNodeStateTransition(nodeID string, fromState, toState, reason string
 	GlobalNodeEventBus.Publish(event)
 }
 
+// PublishNodeTransitionTimedOut publishes an event when a state transition was force-completed
+// after exceeding the maximum transition time
+func PublishNodeTransitionTimedOut(nodeID string, fromState, toState string, elapsed time.Duration) {
+	event := NodeEvent{
+		Type:      NodeTransitionTimedOut,
+		NodeID:    nodeID,
+		Status:    toState,
+		Timestamp: time.Now(),
+		Source:    "transition_timeout",
+		Reason:    fmt.Sprintf("transition %s -> %s exceeded timeout after %s", fromState, toState, elapsed),
+		Data: map[string]interface{}{
+			"from_state": fromState,
+			"to_state":   toState,
+			"elapsed_ms": elapsed.Milliseconds(),
+		},
+	}
+
+	logger.Logger.Debug().Msgf("🔍 NODE_EVENT_DEBUG: Publishing NodeTransitionTimedOut event - NodeID: %s, %s -> %s", nodeID, fromState, toState)
+
+	GlobalNodeEventBus.Publish(event)
+}
+
 // PublishNodeStatusRefreshed publishes a status refresh event
 func PublishNodeStatusRefreshed(nodeID string, status interface{}) {
 	event := NodeEvent{
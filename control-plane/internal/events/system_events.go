@@ -0,0 +1,23 @@
+package events
+
+import "time"
+
+// SystemEventType represents the type of control-plane level event.
+type SystemEventType string
+
+const (
+	// DeadLetterThresholdExceeded fires once when the observability dead letter queue grows
+	// past its configured alert threshold.
+	DeadLetterThresholdExceeded SystemEventType = "dead_letter_threshold_exceeded"
+)
+
+// SystemEvent represents an operational event raised by the control plane itself rather
+// than by a node, reasoner or execution.
+type SystemEvent struct {
+	Type      SystemEventType `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      interface{}     `json:"data,omitempty"`
+}
+
+// GlobalSystemEventBus is the global system event bus instance.
+var GlobalSystemEventBus = NewEventBus[SystemEvent]()
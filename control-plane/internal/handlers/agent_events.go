@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Agent-Field/agentfield/control-plane/internal/events"
+
+	"github.com/gin-gonic/gin"
+)
+
+// customEventTypePattern bounds agent-chosen event types to short identifiers.
+var customEventTypePattern = regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,128}$`)
+
+// AgentEventRequest is the body of an agent-emitted custom event.
+type AgentEventRequest struct {
+	EventType string                 `json:"event_type" binding:"required"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// AgentEventHandler handles POST /api/v1/events
+// Publishes a custom event emitted by an agent onto bus, from which the observability
+// forwarder delivers it. Execution context is taken from the X-Execution-ID,
+// X-Workflow-ID, X-Run-ID and X-Agent-Node-ID headers when present.
+func AgentEventHandler(bus *events.EventBus[events.CustomEvent]) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req AgentEventRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid payload: %v", err)})
+			return
+		}
+
+		eventType := strings.TrimSpace(req.EventType)
+		if !customEventTypePattern.MatchString(eventType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "event_type must be 1-128 characters of letters, digits, '_', '.', ':' or '-'"})
+			return
+		}
+
+		bus.Publish(events.CustomEvent{
+			Type:        eventType,
+			AgentNodeID: strings.TrimSpace(c.GetHeader("X-Agent-Node-ID")),
+			ExecutionID: strings.TrimSpace(c.GetHeader("X-Execution-ID")),
+			WorkflowID:  strings.TrimSpace(c.GetHeader("X-Workflow-ID")),
+			RunID:       strings.TrimSpace(c.GetHeader("X-Run-ID")),
+			Timestamp:   time.Now().UTC(),
+			Data:        req.Data,
+		})
+
+		c.JSON(http.StatusAccepted, gin.H{"accepted": true, "event_type": eventType})
+	}
+}
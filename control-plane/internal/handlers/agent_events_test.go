@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Agent-Field/agentfield/control-plane/internal/events"
+	"github.com/Agent-Field/agentfield/control-plane/internal/services"
+	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookConfigStore serves a fixed observability webhook config. The forwarder only
+// reads the config on this path; other store methods are left unimplemented.
+type webhookConfigStore struct {
+	services.ObservabilityWebhookStore
+	cfg *types.ObservabilityWebhookConfig
+}
+
+func (s *webhookConfigStore) GetObservabilityWebhook(ctx context.Context) (*types.ObservabilityWebhookConfig, error) {
+	return s.cfg, nil
+}
+
+func TestAgentEventHandler_ForwardsToObservabilityWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var (
+		mu       sync.Mutex
+		received []types.ObservabilityEvent
+	)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch types.ObservabilityEventBatch
+		if err := json.NewDecoder(r.Body).Decode(&batch); err == nil {
+			mu.Lock()
+			received = append(received, batch.Events...)
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer webhook.Close()
+
+	forwarder := services.NewObservabilityForwarder(&webhookConfigStore{
+		cfg: &types.ObservabilityWebhookConfig{ID: "global", URL: webhook.URL, Enabled: true},
+	}, services.ObservabilityForwarderConfig{
+		BatchSize:    1,
+		BatchTimeout: 50 * time.Millisecond,
+		WorkerCount:  1,
+	})
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+	require.Eventually(t, func() bool {
+		return events.GlobalCustomEventBus.SubscriberCount() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	router := gin.New()
+	router.POST("/api/v1/events", AgentEventHandler(events.GlobalCustomEventBus))
+
+	body, err := json.Marshal(AgentEventRequest{
+		EventType: "approval_requested",
+		Data:      map[string]interface{}{"amount": 1200.0, "approver": "finance"},
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/events", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Agent-Node-ID", "billing-agent")
+	req.Header.Set("X-Execution-ID", "exec-1")
+	req.Header.Set("X-Run-ID", "run-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var event types.ObservabilityEvent
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, e := range received {
+			if e.EventType == "approval_requested" {
+				event = e
+				return true
+			}
+		}
+		return false
+	}, 3*time.Second, 20*time.Millisecond)
+
+	require.Equal(t, "agent", event.EventSource)
+	data, ok := event.Data.(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "billing-agent", data["agent_node_id"])
+	require.Equal(t, "exec-1", data["execution_id"])
+	require.Equal(t, "run-1", data["run_id"])
+	require.Equal(t, map[string]interface{}{"amount": 1200.0, "approver": "finance"}, data["payload"])
+}
+
+func TestAgentEventHandler_RejectsInvalidEventType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	bus := events.NewEventBus[events.CustomEvent]()
+	ch := bus.Subscribe("test")
+	router := gin.New()
+	router.POST("/api/v1/events", AgentEventHandler(bus))
+
+	for _, body := range []string{`{}`, `{"event_type":"   "}`, `{"event_type":"has spaces"}`} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/events", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+	require.Empty(t, ch)
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/Agent-Field/agentfield/control-plane/internal/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// AsyncTargetPolicyConfigKey is the storage config key holding the
+	// AsyncTargetPolicy enforced by ExecuteAsyncHandler.
+	AsyncTargetPolicyConfigKey = "execution.async_target_policy"
+
+	// asyncTargetPolicyRefresh is how long a loaded policy is used before it is
+	// re-read from storage, so edits apply without a restart.
+	asyncTargetPolicyRefresh = 5 * time.Second
+)
+
+// AsyncTargetPolicy restricts which node_id.reasoner_name targets may be executed
+// asynchronously. Entries are exact targets or path.Match patterns such as
+// "node-a.*". A target matching Deny is rejected; when Allow is non-empty, only
+// targets matching it are accepted.
+type AsyncTargetPolicy struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// Permits reports whether target may be executed under the policy.
+func (p AsyncTargetPolicy) Permits(target string) bool {
+	if matchesAnyTarget(p.Deny, target) {
+		return false
+	}
+	return len(p.Allow) == 0 || matchesAnyTarget(p.Allow, target)
+}
+
+func matchesAnyTarget(patterns []string, target string) bool {
+	for _, pattern := range patterns {
+		if pattern == target {
+			return true
+		}
+		if ok, err := path.Match(pattern, target); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigStore reads and writes control plane settings; it is satisfied by
+// storage.StorageProvider.
+type ConfigStore interface {
+	SetConfig(ctx context.Context, key string, value interface{}) error
+	GetConfig(ctx context.Context, key string) (interface{}, error)
+}
+
+// asyncTargetPolicySource caches the stored AsyncTargetPolicy and reloads it once
+// the refresh interval has passed.
+type asyncTargetPolicySource struct {
+	store   ConfigStore
+	refresh time.Duration
+	now     func() time.Time
+
+	mu       sync.Mutex
+	policy   AsyncTargetPolicy
+	loadedAt time.Time
+	loaded   bool
+}
+
+func newAsyncTargetPolicySource(store ConfigStore) *asyncTargetPolicySource {
+	return &asyncTargetPolicySource{
+		store:   store,
+		refresh: asyncTargetPolicyRefresh,
+		now:     time.Now,
+	}
+}
+
+// current returns the policy, reloading it from storage when the cached copy is
+// stale. If the reload fails the previous policy stays in effect.
+func (s *asyncTargetPolicySource) current(ctx context.Context) AsyncTargetPolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.loaded && now.Sub(s.loadedAt) < s.refresh {
+		return s.policy
+	}
+
+	policy, err := loadAsyncTargetPolicy(ctx, s.store)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Msg("failed to reload async target policy; keeping previous policy")
+	} else {
+		s.policy = policy
+	}
+	s.loaded = true
+	s.loadedAt = now
+	return s.policy
+}
+
+func loadAsyncTargetPolicy(ctx context.Context, store ConfigStore) (AsyncTargetPolicy, error) {
+	var policy AsyncTargetPolicy
+	value, err := store.GetConfig(ctx, AsyncTargetPolicyConfigKey)
+	if err != nil || value == nil {
+		return policy, err
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return policy, fmt.Errorf("encode async target policy: %w", err)
+	}
+	if err := json.Unmarshal(encoded, &policy); err != nil {
+		return policy, fmt.Errorf("decode async target policy: %w", err)
+	}
+	return policy, nil
+}
+
+// allowAsyncTarget applies the stored allow/deny policy to the request's target,
+// writing a 403 and returning false when the target is not permitted.
+func (c *executionController) allowAsyncTarget(ctx *gin.Context) bool {
+	if c.asyncPolicy == nil {
+		return true
+	}
+	target, err := parseTarget(ctx.Param("target"))
+	if err != nil {
+		// Let prepareExecution report the malformed target.
+		return true
+	}
+
+	key := target.NodeID + "." + target.TargetName
+	if c.asyncPolicy.current(ctx.Request.Context()).Permits(key) {
+		return true
+	}
+	ctx.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("async execution of %s is not permitted", key)})
+	return false
+}
+
+// GetAsyncTargetPolicyHandler returns the stored async target policy.
+func GetAsyncTargetPolicyHandler(store ConfigStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		policy, err := loadAsyncTargetPolicy(ctx.Request.Context(), store)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusOK, policy)
+	}
+}
+
+// SetAsyncTargetPolicyHandler replaces the stored async target policy. Running
+// async handlers pick it up on their next refresh.
+func SetAsyncTargetPolicyHandler(store ConfigStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var policy AsyncTargetPolicy
+		if err := ctx.ShouldBindJSON(&policy); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid request body: %v", err)})
+			return
+		}
+		for _, pattern := range append(append([]string{}, policy.Allow...), policy.Deny...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid target pattern %q", pattern)})
+				return
+			}
+		}
+		if err := store.SetConfig(ctx.Request.Context(), AsyncTargetPolicyConfigKey, policy); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusOK, policy)
+	}
+}
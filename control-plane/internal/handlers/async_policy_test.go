@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Agent-Field/agentfield/control-plane/internal/services"
+	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type configExecutionStorage struct {
+	*testExecutionStorage
+	mu     sync.Mutex
+	config map[string]interface{}
+}
+
+func (s *configExecutionStorage) SetConfig(ctx context.Context, key string, value interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config[key] = value
+	return nil
+}
+
+func (s *configExecutionStorage) GetConfig(ctx context.Context, key string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.config[key], nil
+}
+
+func TestExecuteAsyncHandler_EnforcesTargetPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer agentServer.Close()
+
+	agent := &types.AgentNode{
+		ID:        "node-1",
+		BaseURL:   agentServer.URL,
+		Reasoners: []types.ReasonerDefinition{{ID: "summarize"}, {ID: "delete_all"}},
+	}
+	store := &configExecutionStorage{
+		testExecutionStorage: newTestExecutionStorage(agent),
+		config: map[string]interface{}{
+			AsyncTargetPolicyConfigKey: map[string]interface{}{
+				"allow": []interface{}{"node-1.*"},
+				"deny":  []interface{}{"node-1.delete_all"},
+			},
+		},
+	}
+
+	router := gin.New()
+	router.POST("/api/v1/execute/async/:target", ExecuteAsyncHandler(store, services.NewFilePayloadStore(t.TempDir()), nil, 90*time.Second))
+
+	execute := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/execute/async/"+target, strings.NewReader(`{"input":{"text":"hello"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	denied := execute("node-1.delete_all")
+	require.Equal(t, http.StatusForbidden, denied.Code)
+	require.Contains(t, denied.Body.String(), "node-1.delete_all is not permitted")
+
+	records, err := store.QueryExecutionRecords(context.Background(), types.ExecutionFilter{})
+	require.NoError(t, err)
+	require.Empty(t, records, "denied target must be rejected before an execution is enqueued")
+
+	require.Equal(t, http.StatusAccepted, execute("node-1.summarize").Code)
+	require.Equal(t, http.StatusForbidden, execute("node-2.summarize").Code, "targets outside the allowlist are rejected")
+
+	records, err = store.QueryExecutionRecords(context.Background(), types.ExecutionFilter{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "summarize", records[0].ReasonerID)
+
+	require.Eventually(t, func() bool {
+		record, err := store.GetExecutionRecord(context.Background(), records[0].ExecutionID)
+		return err == nil && record != nil && record.Status == types.ExecutionStatusSucceeded
+	}, 2*time.Second, 50*time.Millisecond)
+}
+
+func TestAsyncTargetPolicySource_ReloadsFromStorage(t *testing.T) {
+	store := &configExecutionStorage{
+		testExecutionStorage: newTestExecutionStorage(nil),
+		config:               map[string]interface{}{},
+	}
+	clock := time.Unix(1700000000, 0)
+	source := newAsyncTargetPolicySource(store)
+	source.now = func() time.Time { return clock }
+
+	ctx := context.Background()
+	require.True(t, source.current(ctx).Permits("node-1.delete_all"), "no stored policy allows every target")
+
+	require.NoError(t, store.SetConfig(ctx, AsyncTargetPolicyConfigKey, AsyncTargetPolicy{Deny: []string{"*.delete_all"}}))
+	require.True(t, source.current(ctx).Permits("node-1.delete_all"), "cached policy is used until the refresh interval passes")
+
+	clock = clock.Add(asyncTargetPolicyRefresh)
+	require.False(t, source.current(ctx).Permits("node-1.delete_all"))
+	require.True(t, source.current(ctx).Permits("node-1.summarize"))
+}
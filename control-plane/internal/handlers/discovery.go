@@ -85,13 +85,14 @@ type AgentCapability struct {
 
 // ReasonerCapability captures metadata for a reasoner.
 type ReasonerCapability struct {
-	ID               string                   `json:"id"`
-	Description      *string                  `json:"description,omitempty"`
-	Tags             []string                 `json:"tags,omitempty"`
-	InputSchema      map[string]interface{}   `json:"input_schema,omitempty"`
-	OutputSchema     map[string]interface{}   `json:"output_schema,omitempty"`
-	Examples         []map[string]interface{} `json:"examples,omitempty"`
-	InvocationTarget string                   `json:"invocation_target"`
+	ID               string                     `json:"id"`
+	Description      *string                    `json:"description,omitempty"`
+	Tags             []string                   `json:"tags,omitempty"`
+	InputSchema      map[string]interface{}     `json:"input_schema,omitempty"`
+	OutputSchema     map[string]interface{}     `json:"output_schema,omitempty"`
+	Examples         []map[string]interface{}   `json:"examples,omitempty"`
+	InvocationTarget string                     `json:"invocation_target"`
+	Deprecation      *types.ReasonerDeprecation `json:"deprecation,omitempty"`
 }
 
 // SkillCapability captures metadata for a skill.
@@ -506,6 +507,7 @@ func buildDiscoveryResponse(agents []*types.AgentNode, filters DiscoveryFilters)
 				ID:               reasoner.ID,
 				Tags:             reasoner.Tags,
 				InvocationTarget: fmt.Sprintf("%s:%s", agent.ID, reasoner.ID),
+				Deprecation:      reasoner.Deprecation,
 			}
 
 			if filters.IncludeInputSchema {
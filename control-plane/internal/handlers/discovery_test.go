@@ -96,6 +96,34 @@ func TestDiscoveryCapabilities_WithFiltersAndSchemas(t *testing.T) {
 	assert.Empty(t, resp.Capabilities[0].Skills)
 }
 
+func TestDiscoveryCapabilities_ReasonerDeprecation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	InvalidateDiscoveryCache()
+
+	// Agents register reasoners with a deprecation block; it must survive decoding.
+	var reasoner types.ReasonerDefinition
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"summarize","deprecation":{"since":"1.2.0","message":"use summarize_v2"}}`), &reasoner))
+	require.Equal(t, &types.ReasonerDeprecation{Since: "1.2.0", Message: "use summarize_v2"}, reasoner.Deprecation)
+
+	agents := buildDiscoveryAgents()
+	agents[0].Reasoners = []types.ReasonerDefinition{reasoner}
+	lister := &stubAgentLister{agents: agents}
+	router := gin.New()
+	router.GET("/api/v1/discovery/capabilities", DiscoveryCapabilitiesHandler(lister))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/discovery/capabilities?node_id=agent-alpha", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp DiscoveryResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Capabilities, 1)
+	require.Len(t, resp.Capabilities[0].Reasoners, 1)
+	assert.Equal(t, reasoner.Deprecation, resp.Capabilities[0].Reasoners[0].Deprecation)
+}
+
 func TestDiscoveryCapabilities_Formats(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	InvalidateDiscoveryCache()
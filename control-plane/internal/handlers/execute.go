@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -19,6 +20,7 @@ import (
 	"github.com/Agent-Field/agentfield/control-plane/internal/events"
 	"github.com/Agent-Field/agentfield/control-plane/internal/logger"
 	"github.com/Agent-Field/agentfield/control-plane/internal/services"
+	"github.com/Agent-Field/agentfield/control-plane/internal/storage"
 	"github.com/Agent-Field/agentfield/control-plane/internal/utils"
 	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
 
@@ -44,6 +46,13 @@ type ExecuteRequest struct {
 	Input   map[string]interface{} `json:"input" binding:"required"`
 	Context map[string]interface{} `json:"context,omitempty"`
 	Webhook *WebhookRequest        `json:"webhook,omitempty"`
+	// Tags label the execution (e.g. {"env": "staging"}) for later filtering.
+	Tags map[string]string `json:"tags,omitempty"`
+	// ExecutionID replaces the generated execution ID, letting clients correlate or
+	// retry a submission under a known ID. It must not already exist.
+	ExecutionID string `json:"execution_id,omitempty"`
+	// ReplayOf links the execution to the earlier one whose input it replays.
+	ReplayOf string `json:"replay_of,omitempty"`
 }
 
 // WebhookRequest represents webhook registration parameters supplied by the client.
@@ -77,6 +86,7 @@ type AsyncExecuteResponse struct {
 	EnqueuedAt        string  `json:"enqueued_at,omitempty"`
 	WebhookRegistered bool    `json:"webhook_registered"`
 	WebhookError      *string `json:"webhook_error,omitempty"`
+	ReplayOf          *string `json:"replay_of,omitempty"`
 }
 
 // ExecutionStatusResponse mirrors the data required by the UI to render execution state.
@@ -86,6 +96,8 @@ type ExecutionStatusResponse struct {
 	Status            string                         `json:"status"`
 	Result            interface{}                    `json:"result,omitempty"`
 	Error             *string                        `json:"error,omitempty"`
+	ErrorCategory     *string                        `json:"error_category,omitempty"`
+	Tags              map[string]string              `json:"tags,omitempty"`
 	StartedAt         string                         `json:"started_at"`
 	CompletedAt       *string                        `json:"completed_at,omitempty"`
 	DurationMS        *int64                         `json:"duration_ms,omitempty"`
@@ -102,12 +114,13 @@ type BatchStatusRequest struct {
 type BatchStatusResponse map[string]ExecutionStatusResponse
 
 type executionStatusUpdateRequest struct {
-	Status      string                 `json:"status" binding:"required"`
-	Result      map[string]interface{} `json:"result,omitempty"`
-	Error       string                 `json:"error,omitempty"`
-	DurationMS  *int64                 `json:"duration_ms,omitempty"`
-	CompletedAt *time.Time             `json:"completed_at,omitempty"`
-	Progress    *int                   `json:"progress,omitempty"`
+	Status        string                 `json:"status" binding:"required"`
+	Result        map[string]interface{} `json:"result,omitempty"`
+	Error         string                 `json:"error,omitempty"`
+	ErrorCategory string                 `json:"error_category,omitempty"`
+	DurationMS    *int64                 `json:"duration_ms,omitempty"`
+	CompletedAt   *time.Time             `json:"completed_at,omitempty"`
+	Progress      *int                   `json:"progress,omitempty"`
 }
 
 type executionController struct {
@@ -117,6 +130,15 @@ type executionController struct {
 	webhooks   services.WebhookDispatcher
 	eventBus   *events.ExecutionEventBus
 	timeout    time.Duration
+	limiter    *executionRateLimiter
+	asyncPool  *asyncWorkerPool // nil uses the shared pool
+
+	// asyncPolicy restricts which targets handleAsync accepts; nil allows all.
+	asyncPolicy *asyncTargetPolicySource
+
+	// maxInlinePayload is the largest input/result stored inline on the execution
+	// record; larger payloads live only in the payload store. Zero disables offloading.
+	maxInlinePayload int
 }
 
 type asyncExecutionJob struct {
@@ -149,29 +171,48 @@ const (
 	maxWebhookHeaders      = 20
 	maxWebhookHeaderLength = 512
 	maxWebhookSecretLength = 4096
+
+	maxExecutionTags        = 20
+	maxExecutionTagKeyLen   = 64
+	maxExecutionTagValueLen = 256
+
+	maxExecutionIDLength = 128
+
+	// asyncQueueRetryAfterSeconds is the Retry-After hint sent when the async queue is full.
+	asyncQueueRetryAfterSeconds = 1
+
+	defaultMaxInlinePayloadBytes = 1024 * 1024 // 1 MiB
 )
 
 // ExecuteHandler handles synchronous execution requests.
 func ExecuteHandler(store ExecutionStore, payloads services.PayloadStore, webhooks services.WebhookDispatcher, timeout time.Duration) gin.HandlerFunc {
 	controller := newExecutionController(store, payloads, webhooks, timeout)
+	controller.limiter = getExecutionRateLimiter()
 	return controller.handleSync
 }
 
-// ExecuteAsyncHandler handles asynchronous execution requests.
+// ExecuteAsyncHandler handles asynchronous execution requests. When store also
+// implements ConfigStore, targets are checked against the stored AsyncTargetPolicy.
 func ExecuteAsyncHandler(store ExecutionStore, payloads services.PayloadStore, webhooks services.WebhookDispatcher, timeout time.Duration) gin.HandlerFunc {
 	controller := newExecutionController(store, payloads, webhooks, timeout)
+	controller.limiter = getExecutionRateLimiter()
+	if configs, ok := store.(ConfigStore); ok {
+		controller.asyncPolicy = newAsyncTargetPolicySource(configs)
+	}
 	return controller.handleAsync
 }
 
-// GetExecutionStatusHandler resolves a single execution record.
-func GetExecutionStatusHandler(store ExecutionStore) gin.HandlerFunc {
-	controller := newExecutionController(store, nil, nil, 0)
+// GetExecutionStatusHandler resolves a single execution record. payloads is used to
+// load results offloaded from the record and may be nil.
+func GetExecutionStatusHandler(store ExecutionStore, payloads services.PayloadStore) gin.HandlerFunc {
+	controller := newExecutionController(store, payloads, nil, 0)
 	return controller.handleStatus
 }
 
-// BatchExecutionStatusHandler resolves multiple execution records.
-func BatchExecutionStatusHandler(store ExecutionStore) gin.HandlerFunc {
-	controller := newExecutionController(store, nil, nil, 0)
+// BatchExecutionStatusHandler resolves multiple execution records. payloads is used to
+// load results offloaded from the records and may be nil.
+func BatchExecutionStatusHandler(store ExecutionStore, payloads services.PayloadStore) gin.HandlerFunc {
+	controller := newExecutionController(store, payloads, nil, 0)
 	return controller.handleBatchStatus
 }
 
@@ -191,14 +232,18 @@ func newExecutionController(store ExecutionStore, payloads services.PayloadStore
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
-		payloads: payloads,
-		webhooks: webhooks,
-		eventBus: store.GetExecutionEventBus(),
-		timeout:  timeout,
+		payloads:         payloads,
+		webhooks:         webhooks,
+		eventBus:         store.GetExecutionEventBus(),
+		timeout:          timeout,
+		maxInlinePayload: resolveIntFromEnv("AGENTFIELD_EXEC_MAX_INLINE_PAYLOAD_BYTES", defaultMaxInlinePayloadBytes),
 	}
 }
 
 func (c *executionController) handleSync(ctx *gin.Context) {
+	if !c.allowExecution(ctx) {
+		return
+	}
 	reqCtx := ctx.Request.Context()
 	plan, err := c.prepareExecution(reqCtx, ctx)
 	if err != nil {
@@ -229,6 +274,7 @@ func (c *executionController) handleSync(ctx *gin.Context) {
 		}
 
 		// Build response from completed execution
+		exec = c.withHydratedResult(reqCtx, exec)
 		var result interface{}
 		if exec.ResultPayload != nil {
 			result = decodeJSON(exec.ResultPayload)
@@ -323,6 +369,9 @@ func (c *executionController) handleSync(ctx *gin.Context) {
 }
 
 func (c *executionController) handleAsync(ctx *gin.Context) {
+	if !c.allowAsyncTarget(ctx) || !c.allowExecution(ctx) {
+		return
+	}
 	reqCtx := ctx.Request.Context()
 	plan, err := c.prepareExecution(reqCtx, ctx)
 	if err != nil {
@@ -330,15 +379,21 @@ func (c *executionController) handleAsync(ctx *gin.Context) {
 		return
 	}
 
-	pool := getAsyncWorkerPool()
+	pool := c.asyncPool
+	if pool == nil {
+		pool = getAsyncWorkerPool()
+	}
 	job := asyncExecutionJob{
 		controller: c,
 		plan:       *plan,
 	}
 
-	if ok := pool.submit(job); !ok {
-		queueErr := errors.New("async execution queue is full; retry later")
-		if updateErr := c.failExecution(reqCtx, plan, queueErr, 0, nil); updateErr != nil {
+	submitted := pool.submit(job)
+	ctx.Header("X-Queue-Depth", strconv.Itoa(len(pool.queue)))
+	ctx.Header("X-Queue-Capacity", strconv.Itoa(cap(pool.queue)))
+
+	if !submitted {
+		if updateErr := c.failExecution(reqCtx, plan, errAsyncQueueFull, 0, nil); updateErr != nil {
 			logger.Logger.Error().
 				Err(updateErr).
 				Str("execution_id", plan.exec.ExecutionID).
@@ -347,7 +402,8 @@ func (c *executionController) handleAsync(ctx *gin.Context) {
 		logger.Logger.Warn().
 			Str("execution_id", plan.exec.ExecutionID).
 			Msg("async execution rejected due to queue saturation")
-		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": queueErr.Error()})
+		ctx.Header("Retry-After", strconv.Itoa(asyncQueueRetryAfterSeconds))
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": errAsyncQueueFull.Error()})
 		return
 	}
 
@@ -363,6 +419,7 @@ func (c *executionController) handleAsync(ctx *gin.Context) {
 		CreatedAt:         createdAt,
 		EnqueuedAt:        createdAt,
 		WebhookRegistered: plan.webhookRegistered,
+		ReplayOf:          plan.exec.ReplayOf,
 	}
 	if plan.webhookError != nil {
 		response.WebhookError = plan.webhookError
@@ -391,7 +448,7 @@ func (c *executionController) handleStatus(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, renderStatus(exec))
+	ctx.JSON(http.StatusOK, renderStatus(c.withHydratedResult(reqCtx, exec)))
 }
 
 func (c *executionController) handleBatchStatus(ctx *gin.Context) {
@@ -420,7 +477,7 @@ func (c *executionController) handleBatchStatus(ctx *gin.Context) {
 			}
 			continue
 		}
-		response[id] = renderStatus(exec)
+		response[id] = renderStatus(c.withHydratedResult(reqCtx, exec))
 	}
 
 	ctx.JSON(http.StatusOK, response)
@@ -445,6 +502,10 @@ func (c *executionController) handleStatusUpdate(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported status '%s'", req.Status)})
 		return
 	}
+	if req.ErrorCategory != "" && !types.IsExecutionErrorCategory(req.ErrorCategory) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported error_category '%s'", req.ErrorCategory)})
+		return
+	}
 
 	var (
 		resultBytes []byte
@@ -470,8 +531,9 @@ func (c *executionController) handleStatusUpdate(ctx *gin.Context) {
 
 		current.Status = normalizedStatus
 		if len(resultBytes) > 0 {
-			current.ResultPayload = json.RawMessage(resultBytes)
+			current.ResultPayload = c.inlinePayload(resultBytes, resultURI)
 			current.ResultURI = resultURI
+			current.ResultSize = pointerInt64(int64(len(resultBytes)))
 		}
 
 		if req.Error != "" {
@@ -480,8 +542,12 @@ func (c *executionController) handleStatusUpdate(ctx *gin.Context) {
 			errorMsg = &errCopy
 		} else if normalizedStatus == string(types.ExecutionStatusSucceeded) {
 			current.ErrorMessage = nil
+			current.ErrorCategory = nil
 			errorMsg = nil
 		}
+		if category := statusUpdateErrorCategory(normalizedStatus, req.ErrorCategory); category != "" {
+			current.ErrorCategory = &category
+		}
 
 		if req.DurationMS != nil {
 			current.DurationMS = req.DurationMS
@@ -528,7 +594,11 @@ func (c *executionController) handleStatusUpdate(ctx *gin.Context) {
 	}
 
 	if isTerminal {
-		c.updateWorkflowExecutionFinalState(reqCtx, executionID, types.ExecutionStatus(normalizedStatus), updated.ResultPayload, elapsed, errorMsg)
+		finalResult := updated.ResultPayload
+		if len(resultBytes) > 0 {
+			finalResult = resultBytes
+		}
+		c.updateWorkflowExecutionFinalState(reqCtx, executionID, types.ExecutionStatus(normalizedStatus), finalResult, elapsed, errorMsg)
 		if updated.WebhookRegistered {
 			c.triggerWebhook(executionID)
 		}
@@ -540,7 +610,7 @@ func (c *executionController) handleStatusUpdate(ctx *gin.Context) {
 		"progress": req.Progress,
 	})
 
-	ctx.JSON(http.StatusOK, renderStatus(updated))
+	ctx.JSON(http.StatusOK, renderStatus(c.withHydratedResult(reqCtx, updated)))
 }
 
 func (c *executionController) publishExecutionEvent(exec *types.Execution, status string, data map[string]interface{}) {
@@ -661,6 +731,23 @@ func (c *executionController) prepareExecution(ctx context.Context, ginCtx *gin.
 	if len(req.Input) == 0 {
 		return nil, errors.New("input is required")
 	}
+	tags, err := normalizeExecutionTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	executionID, err := normalizeClientExecutionID(req.ExecutionID)
+	if err != nil {
+		return nil, err
+	}
+	if executionID != "" {
+		existing, err := c.store.GetExecutionRecord(ctx, executionID)
+		if err != nil {
+			return nil, fmt.Errorf("check execution_id: %w", err)
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("%w: %s", errExecutionIDConflict, executionID)
+		}
+	}
 
 	var (
 		sanitizedWebhook *normalizedWebhookConfig
@@ -708,7 +795,9 @@ func (c *executionController) prepareExecution(ctx context.Context, ginCtx *gin.
 		runID = utils.GenerateRunID()
 	}
 
-	executionID := utils.GenerateExecutionID()
+	if executionID == "" {
+		executionID = utils.GenerateExecutionID()
+	}
 	now := time.Now().UTC()
 
 	clientPayload := map[string]interface{}{
@@ -732,10 +821,14 @@ func (c *executionController) prepareExecution(ctx context.Context, ginCtx *gin.
 		NodeID:            target.NodeID,
 		Status:            types.ExecutionStatusRunning,
 		InputPayload:      json.RawMessage(storedPayload),
+		Tags:              tags,
 		StartedAt:         now,
 		CreatedAt:         now,
 		UpdatedAt:         now,
 	}
+	if replayOf := strings.TrimSpace(req.ReplayOf); replayOf != "" {
+		exec.ReplayOf = &replayOf
+	}
 
 	agentPayload := make(map[string]interface{}, len(req.Input))
 	for key, value := range req.Input {
@@ -754,6 +847,8 @@ func (c *executionController) prepareExecution(ctx context.Context, ginCtx *gin.
 
 	inputURI := c.savePayload(ctx, storedPayload)
 	exec.InputURI = inputURI
+	exec.InputPayload = c.inlinePayload(storedPayload, inputURI)
+	exec.InputSize = pointerInt64(int64(len(storedPayload)))
 
 	if headers.sessionID != nil {
 		exec.SessionID = headers.sessionID
@@ -763,6 +858,11 @@ func (c *executionController) prepareExecution(ctx context.Context, ginCtx *gin.
 	}
 
 	if err := c.store.CreateExecutionRecord(ctx, exec); err != nil {
+		c.removePayload(ctx, inputURI)
+		if errors.Is(err, storage.ErrExecutionExists) {
+			// A concurrent request claimed the client-supplied ID after our check.
+			return nil, fmt.Errorf("%w: %s", errExecutionIDConflict, executionID)
+		}
 		return nil, fmt.Errorf("create execution record: %w", err)
 	}
 
@@ -858,12 +958,68 @@ func (c *executionController) callAgent(ctx context.Context, plan *preparedExecu
 	}
 
 	if resp.StatusCode >= http.StatusBadRequest {
-		return body, time.Since(start), false, fmt.Errorf("agent error (%d): %s", resp.StatusCode, truncateForLog(body))
+		return body, time.Since(start), false, &agentStatusError{statusCode: resp.StatusCode, body: body}
 	}
 
 	return body, time.Since(start), false, nil
 }
 
+// errAsyncQueueFull is recorded on executions rejected because the async worker queue is saturated.
+var errAsyncQueueFull = errors.New("async execution queue is full; retry later")
+
+// agentStatusError reports that the agent answered a call with an HTTP error status.
+type agentStatusError struct {
+	statusCode int
+	body       []byte
+}
+
+func (e *agentStatusError) Error() string {
+	return fmt.Sprintf("agent error (%d): %s", e.statusCode, truncateForLog(e.body))
+}
+
+// categorizeExecutionError maps the error of a failed agent call onto an execution error category.
+func categorizeExecutionError(err error) types.ExecutionErrorCategory {
+	var statusErr *agentStatusError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, errAsyncQueueFull):
+		return types.ExecutionErrorCategoryQueueFull
+	case errors.As(err, &statusErr):
+		switch {
+		case statusErr.statusCode >= http.StatusInternalServerError:
+			return types.ExecutionErrorCategoryAgent5xx
+		case statusErr.statusCode == http.StatusBadRequest, statusErr.statusCode == http.StatusUnprocessableEntity:
+			return types.ExecutionErrorCategoryValidation
+		default:
+			return types.ExecutionErrorCategoryAgent4xx
+		}
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return types.ExecutionErrorCategoryTimeout
+	case errors.As(err, &netErr):
+		return types.ExecutionErrorCategoryNetwork
+	}
+	return types.ExecutionErrorCategoryInternal
+}
+
+// statusUpdateErrorCategory categorizes a failure reported by the agent through a
+// status update. An explicit category from the agent wins; otherwise the failure is
+// treated as internal to the reasoner, since no HTTP error was observed.
+func statusUpdateErrorCategory(status string, reported string) types.ExecutionErrorCategory {
+	switch types.ExecutionStatus(status) {
+	case types.ExecutionStatusFailed:
+		if reported != "" {
+			return reported
+		}
+		return types.ExecutionErrorCategoryInternal
+	case types.ExecutionStatusTimeout:
+		if reported != "" {
+			return reported
+		}
+		return types.ExecutionErrorCategoryTimeout
+	}
+	return ""
+}
+
 func (c *executionController) completeExecution(ctx context.Context, plan *preparedExecution, result []byte, elapsed time.Duration) error {
 	resultURI := c.savePayload(ctx, result)
 
@@ -875,8 +1031,10 @@ func (c *executionController) completeExecution(ctx context.Context, plan *prepa
 			}
 			now := time.Now().UTC()
 			current.Status = types.ExecutionStatusSucceeded
-			current.ResultPayload = json.RawMessage(result)
+			current.ResultPayload = c.inlinePayload(result, resultURI)
+			current.ResultSize = pointerInt64(int64(len(result)))
 			current.ErrorMessage = nil
+			current.ErrorCategory = nil
 			current.CompletedAt = pointerTime(now)
 			duration := elapsed.Milliseconds()
 			current.DurationMS = &duration
@@ -915,6 +1073,7 @@ func (c *executionController) completeExecution(ctx context.Context, plan *prepa
 
 func (c *executionController) failExecution(ctx context.Context, plan *preparedExecution, callErr error, elapsed time.Duration, result []byte) error {
 	errMsg := callErr.Error()
+	category := categorizeExecutionError(callErr)
 	resultURI := c.savePayload(ctx, result)
 	var lastErr error
 	for attempt := 0; attempt < 5; attempt++ {
@@ -925,12 +1084,14 @@ func (c *executionController) failExecution(ctx context.Context, plan *preparedE
 			now := time.Now().UTC()
 			current.Status = types.ExecutionStatusFailed
 			current.ErrorMessage = &errMsg
+			current.ErrorCategory = &category
 			current.CompletedAt = pointerTime(now)
 			duration := elapsed.Milliseconds()
 			current.DurationMS = &duration
 			current.UpdatedAt = now
 			if len(result) > 0 {
-				current.ResultPayload = json.RawMessage(result)
+				current.ResultPayload = c.inlinePayload(result, resultURI)
+				current.ResultSize = pointerInt64(int64(len(result)))
 			}
 			current.ResultURI = resultURI
 			return current, nil
@@ -948,7 +1109,8 @@ func (c *executionController) failExecution(ctx context.Context, plan *preparedE
 				c.triggerWebhook(plan.exec.ExecutionID)
 			}
 			eventData := map[string]interface{}{
-				"error": errMsg,
+				"error":          errMsg,
+				"error_category": category,
 			}
 			if payload := decodeJSON(result); payload != nil {
 				eventData["result"] = payload
@@ -1114,6 +1276,58 @@ type normalizedWebhookConfig struct {
 	Headers map[string]string
 }
 
+// normalizeExecutionTags trims tag keys and values and enforces the tag limits. Tags
+// with an empty key are rejected; no tags yields nil.
+func normalizeExecutionTags(tags map[string]string) (map[string]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	if len(tags) > maxExecutionTags {
+		return nil, fmt.Errorf("tags supports at most %d entries", maxExecutionTags)
+	}
+	normalized := make(map[string]string, len(tags))
+	for key, value := range tags {
+		trimmedKey := strings.TrimSpace(key)
+		trimmedValue := strings.TrimSpace(value)
+		if trimmedKey == "" {
+			return nil, fmt.Errorf("tag names must not be empty")
+		}
+		if len(trimmedKey) > maxExecutionTagKeyLen {
+			return nil, fmt.Errorf("tag name '%s' is too long", trimmedKey)
+		}
+		if len(trimmedValue) > maxExecutionTagValueLen {
+			return nil, fmt.Errorf("value of tag '%s' is too long", trimmedKey)
+		}
+		normalized[trimmedKey] = trimmedValue
+	}
+	return normalized, nil
+}
+
+// errExecutionIDConflict rejects a client-supplied execution_id that is already in use.
+var errExecutionIDConflict = errors.New("execution_id already exists")
+
+// normalizeClientExecutionID trims a client-supplied execution ID and checks that it is
+// safe to use in URLs: at most maxExecutionIDLength letters, digits, '-', '_', '.' or ':'.
+// An empty ID yields "" so the controller generates one.
+func normalizeClientExecutionID(id string) (string, error) {
+	trimmed := strings.TrimSpace(id)
+	if trimmed == "" {
+		return "", nil
+	}
+	if len(trimmed) > maxExecutionIDLength {
+		return "", fmt.Errorf("execution_id must be at most %d characters", maxExecutionIDLength)
+	}
+	for _, r := range trimmed {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == ':':
+		default:
+			return "", fmt.Errorf("execution_id contains invalid character %q", r)
+		}
+	}
+	return trimmed, nil
+}
+
 func normalizeWebhookRequest(req *WebhookRequest) (*normalizedWebhookConfig, error) {
 	if req == nil {
 		return nil, nil
@@ -1202,6 +1416,8 @@ func renderStatus(exec *types.Execution) ExecutionStatusResponse {
 		Status:            exec.Status,
 		Result:            decodeJSON(exec.ResultPayload),
 		Error:             exec.ErrorMessage,
+		ErrorCategory:     exec.ErrorCategory,
+		Tags:              exec.Tags,
 		StartedAt:         exec.StartedAt.UTC().Format(time.RFC3339),
 		CompletedAt:       completedAt,
 		DurationMS:        exec.DurationMS,
@@ -1362,6 +1578,10 @@ func writeExecutionError(ctx *gin.Context, err error) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "unknown error"})
 		return
 	}
+	if errors.Is(err, errExecutionIDConflict) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 }
 
@@ -1398,6 +1618,48 @@ func (c *executionController) savePayload(ctx context.Context, data []byte) *str
 	return &uri
 }
 
+// removePayload deletes a payload saved for a request that was then rejected.
+func (c *executionController) removePayload(ctx context.Context, uri *string) {
+	if c.payloads == nil || uri == nil {
+		return
+	}
+	if err := c.payloads.Remove(ctx, *uri); err != nil {
+		logger.Logger.Warn().Err(err).Str("uri", *uri).Msg("failed to remove orphaned payload")
+	}
+}
+
+// inlinePayload returns the bytes to store inline on the execution record. Payloads
+// above maxInlinePayload that were persisted to the payload store are offloaded and
+// only referenced by uri.
+func (c *executionController) inlinePayload(data []byte, uri *string) json.RawMessage {
+	if c.maxInlinePayload > 0 && len(data) > c.maxInlinePayload && uri != nil {
+		return nil
+	}
+	return json.RawMessage(data)
+}
+
+// withHydratedResult returns exec with an offloaded result loaded back from the
+// payload store so API responses include it. The stored record is not modified.
+func (c *executionController) withHydratedResult(ctx context.Context, exec *types.Execution) *types.Execution {
+	if exec == nil || len(exec.ResultPayload) > 0 || exec.ResultURI == nil || c.payloads == nil {
+		return exec
+	}
+	reader, err := c.payloads.Open(ctx, *exec.ResultURI)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Str("execution_id", exec.ExecutionID).Msg("failed to open offloaded result payload")
+		return exec
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Str("execution_id", exec.ExecutionID).Msg("failed to read offloaded result payload")
+		return exec
+	}
+	hydrated := *exec
+	hydrated.ResultPayload = json.RawMessage(data)
+	return &hydrated
+}
+
 func (j asyncExecutionJob) process() {
 	bgCtx := context.Background()
 	resultBody, elapsed, asyncAccepted, callErr := j.controller.callAgent(bgCtx, &j.plan)
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -110,6 +111,47 @@ func TestExecuteAsyncHandler_QueueSaturation(t *testing.T) {
 	}
 }
 
+func TestExecuteAsyncHandler_QueueHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent := &types.AgentNode{
+		ID:        "node-1",
+		BaseURL:   "http://agent.example",
+		Reasoners: []types.ReasonerDefinition{{ID: "reasoner-a"}},
+	}
+	store := newTestExecutionStorage(agent)
+	payloads := services.NewFilePayloadStore(t.TempDir())
+
+	// No workers, so submitted jobs stay queued and depth is deterministic.
+	controller := newExecutionController(store, payloads, nil, 90*time.Second)
+	controller.asyncPool = newAsyncWorkerPool(0, 2)
+
+	router := gin.New()
+	router.POST("/api/v1/execute/async/:target", controller.handleAsync)
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/execute/async/node-1.reasoner-a", strings.NewReader(`{"input":{"foo":"bar"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	for depth := 1; depth <= 2; depth++ {
+		resp := send()
+		require.Equal(t, http.StatusAccepted, resp.Code)
+		require.Equal(t, strconv.Itoa(depth), resp.Header().Get("X-Queue-Depth"))
+		require.Equal(t, "2", resp.Header().Get("X-Queue-Capacity"))
+		require.Empty(t, resp.Header().Get("Retry-After"))
+	}
+
+	resp := send()
+	require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	require.Equal(t, "2", resp.Header().Get("X-Queue-Depth"))
+	require.Equal(t, "2", resp.Header().Get("X-Queue-Capacity"))
+	require.Equal(t, "1", resp.Header().Get("Retry-After"))
+}
+
 func TestExecuteAsyncHandler_WithWebhook(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
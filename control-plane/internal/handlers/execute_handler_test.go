@@ -3,10 +3,15 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -122,6 +127,82 @@ func TestExecuteHandler_AgentError(t *testing.T) {
 	require.Contains(t, *records[0].ErrorMessage, "agent error (500)")
 }
 
+func TestExecuteHandler_CategorizesAgentFailures(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closedServer.URL
+	closedServer.Close()
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		baseURL  string
+		expected string
+	}{
+		{
+			name: "agent 5xx",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			expected: types.ExecutionErrorCategoryAgent5xx,
+		},
+		{
+			name: "timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(500 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			},
+			expected: types.ExecutionErrorCategoryTimeout,
+		},
+		{
+			name: "validation",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"error":"missing field"}`))
+			},
+			expected: types.ExecutionErrorCategoryValidation,
+		},
+		{
+			name:     "network",
+			baseURL:  closedURL,
+			expected: types.ExecutionErrorCategoryNetwork,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL := tt.baseURL
+			if tt.handler != nil {
+				agentServer := httptest.NewServer(tt.handler)
+				defer agentServer.Close()
+				baseURL = agentServer.URL
+			}
+
+			agent := &types.AgentNode{
+				ID:        "node-1",
+				BaseURL:   baseURL,
+				Reasoners: []types.ReasonerDefinition{{ID: "reasoner-a"}},
+			}
+			store := newTestExecutionStorage(agent)
+
+			router := gin.New()
+			router.POST("/api/v1/execute/:target", ExecuteHandler(store, nil, nil, 100*time.Millisecond))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/execute/node-1.reasoner-a", strings.NewReader(`{"input":{"foo":"bar"}}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			records, err := store.QueryExecutionRecords(context.Background(), types.ExecutionFilter{})
+			require.NoError(t, err)
+			require.Len(t, records, 1)
+			require.Equal(t, types.ExecutionStatusFailed, records[0].Status)
+			require.NotNil(t, records[0].ErrorCategory)
+			require.Equal(t, tt.expected, *records[0].ErrorCategory)
+		})
+	}
+}
+
 func TestExecuteHandler_TargetNotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -222,6 +303,198 @@ func TestExecuteAsyncHandler_InvalidJSON(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, resp.Code)
 }
 
+func TestExecuteHandler_RecordsTags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer agentServer.Close()
+
+	agent := &types.AgentNode{
+		ID:        "node-1",
+		BaseURL:   agentServer.URL,
+		Reasoners: []types.ReasonerDefinition{{ID: "reasoner-a"}},
+	}
+
+	store := newTestExecutionStorage(agent)
+	payloads := services.NewFilePayloadStore(t.TempDir())
+
+	router := gin.New()
+	router.POST("/api/v1/execute/:target", ExecuteHandler(store, payloads, nil, 90*time.Second))
+	router.GET("/api/v1/executions/:execution_id", GetExecutionStatusHandler(store, nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/execute/node-1.reasoner-a",
+		strings.NewReader(`{"input":{"foo":"bar"},"tags":{" env ":"staging","team":"search"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var envelope ExecuteResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &envelope))
+
+	want := map[string]string{"env": "staging", "team": "search"}
+	record, err := store.GetExecutionRecord(context.Background(), envelope.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, want, record.Tags)
+
+	statusReq := httptest.NewRequest(http.MethodGet, "/api/v1/executions/"+envelope.ExecutionID, nil)
+	statusResp := httptest.NewRecorder()
+	router.ServeHTTP(statusResp, statusReq)
+	require.Equal(t, http.StatusOK, statusResp.Code)
+
+	var status ExecutionStatusResponse
+	require.NoError(t, json.Unmarshal(statusResp.Body.Bytes(), &status))
+	require.Equal(t, want, status.Tags)
+
+	badReq := httptest.NewRequest(http.MethodPost, "/api/v1/execute/node-1.reasoner-a",
+		strings.NewReader(`{"input":{"foo":"bar"},"tags":{" ":"x"}}`))
+	badReq.Header.Set("Content-Type", "application/json")
+	badResp := httptest.NewRecorder()
+	router.ServeHTTP(badResp, badReq)
+	require.Equal(t, http.StatusBadRequest, badResp.Code)
+}
+
+func TestExecuteHandler_ClientExecutionID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var agentExecutionID atomic.Value
+	agentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agentExecutionID.Store(r.Header.Get("X-Execution-ID"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer agentServer.Close()
+
+	agent := &types.AgentNode{
+		ID:        "node-1",
+		BaseURL:   agentServer.URL,
+		Reasoners: []types.ReasonerDefinition{{ID: "reasoner-a"}},
+	}
+
+	store := newTestExecutionStorage(agent)
+	payloads := services.NewFilePayloadStore(t.TempDir())
+
+	router := gin.New()
+	router.POST("/api/v1/execute/:target", ExecuteHandler(store, payloads, nil, 90*time.Second))
+
+	submit := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/execute/node-1.reasoner-a", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := submit(`{"input":{"foo":"bar"},"execution_id":"test-exec-42"}`)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var envelope ExecuteResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &envelope))
+	require.Equal(t, "test-exec-42", envelope.ExecutionID)
+	require.Equal(t, "test-exec-42", agentExecutionID.Load())
+
+	record, err := store.GetExecutionRecord(context.Background(), "test-exec-42")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+
+	dup := submit(`{"input":{"foo":"baz"},"execution_id":"test-exec-42"}`)
+	require.Equal(t, http.StatusConflict, dup.Code)
+	require.Contains(t, dup.Body.String(), "execution_id already exists")
+
+	invalid := submit(`{"input":{"foo":"bar"},"execution_id":"../etc"}`)
+	require.Equal(t, http.StatusBadRequest, invalid.Code)
+}
+
+// racingExecutionStorage holds the first lookups of an execution ID until they have all
+// arrived, so concurrent requests all pass the uniqueness check before either inserts.
+type racingExecutionStorage struct {
+	*testExecutionStorage
+	executionID string
+	barrier     sync.WaitGroup
+	waiting     atomic.Int32
+}
+
+func (s *racingExecutionStorage) GetExecutionRecord(ctx context.Context, executionID string) (*types.Execution, error) {
+	if executionID == s.executionID && s.waiting.Add(-1) >= 0 {
+		s.barrier.Done()
+		s.barrier.Wait()
+	}
+	return s.testExecutionStorage.GetExecutionRecord(ctx, executionID)
+}
+
+func TestExecuteHandler_ConcurrentDuplicateExecutionID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer agentServer.Close()
+
+	agent := &types.AgentNode{
+		ID:        "node-1",
+		BaseURL:   agentServer.URL,
+		Reasoners: []types.ReasonerDefinition{{ID: "reasoner-a"}},
+	}
+
+	const concurrent = 2
+	store := &racingExecutionStorage{testExecutionStorage: newTestExecutionStorage(agent), executionID: "dup-exec"}
+	store.barrier.Add(concurrent)
+	store.waiting.Store(concurrent)
+	payloadDir := t.TempDir()
+	payloads := services.NewFilePayloadStore(payloadDir)
+
+	router := gin.New()
+	router.POST("/api/v1/execute/:target", ExecuteHandler(store, payloads, nil, 90*time.Second))
+
+	codes := make(chan int, concurrent)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrent; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body := fmt.Sprintf(`{"input":{"request":%d},"execution_id":"dup-exec"}`, i)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/execute/node-1.reasoner-a", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+			codes <- resp.Code
+		}(i)
+	}
+	wg.Wait()
+	close(codes)
+
+	var got []int
+	for code := range codes {
+		got = append(got, code)
+	}
+	require.ElementsMatch(t, []int{http.StatusOK, http.StatusConflict}, got)
+
+	// Only the winner's input payload is kept.
+	record, err := store.GetExecutionRecord(context.Background(), "dup-exec")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	inputs := 0
+	err = filepath.WalkDir(payloadDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(string(data), `"request"`) {
+			inputs++
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, inputs)
+}
+
 func TestGetExecutionStatusHandler_ReturnsResult(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -244,7 +517,7 @@ func TestGetExecutionStatusHandler_ReturnsResult(t *testing.T) {
 	require.NoError(t, store.CreateExecutionRecord(context.Background(), execution))
 
 	router := gin.New()
-	router.GET("/api/v1/executions/:execution_id", GetExecutionStatusHandler(store))
+	router.GET("/api/v1/executions/:execution_id", GetExecutionStatusHandler(store, nil))
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/exec-1", nil)
 	resp := httptest.NewRecorder()
@@ -278,7 +551,7 @@ func TestBatchExecutionStatusHandler_MixedResults(t *testing.T) {
 	}))
 
 	router := gin.New()
-	router.POST("/api/v1/executions/batch-status", BatchExecutionStatusHandler(store))
+	router.POST("/api/v1/executions/batch-status", BatchExecutionStatusHandler(store, nil))
 
 	body := `{"execution_ids":["exec-ok","exec-missing"]}`
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/executions/batch-status", strings.NewReader(body))
@@ -295,6 +568,63 @@ func TestBatchExecutionStatusHandler_MixedResults(t *testing.T) {
 	require.Equal(t, "not_found", payload["exec-missing"].Status)
 }
 
+func TestExecuteHandler_OffloadsLargePayloads(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	largeResult := `{"blob":"` + strings.Repeat("x", 4096) + `"}`
+	agentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(largeResult))
+	}))
+	defer agentServer.Close()
+
+	agent := &types.AgentNode{
+		ID:        "node-1",
+		BaseURL:   agentServer.URL,
+		Reasoners: []types.ReasonerDefinition{{ID: "reasoner-a"}},
+	}
+	store := newTestExecutionStorage(agent)
+	payloads := services.NewFilePayloadStore(t.TempDir())
+
+	controller := newExecutionController(store, payloads, nil, 90*time.Second)
+	controller.maxInlinePayload = 1024
+
+	router := gin.New()
+	router.POST("/api/v1/execute/:target", controller.handleSync)
+	router.GET("/api/v1/executions/:execution_id", GetExecutionStatusHandler(store, payloads))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/execute/node-1.reasoner-a", strings.NewReader(`{"input":{"foo":"bar"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var envelope ExecuteResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &envelope))
+
+	record, err := store.GetExecutionRecord(context.Background(), envelope.ExecutionID)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	require.Empty(t, record.ResultPayload, "oversized result should not be stored inline")
+	require.NotNil(t, record.ResultURI)
+	require.NotEmpty(t, record.InputPayload, "small input stays inline")
+	require.NotNil(t, record.ResultSize, "offloaded result keeps its size")
+	require.Equal(t, int64(len(largeResult)), *record.ResultSize)
+	require.NotNil(t, record.InputSize)
+	require.Equal(t, int64(len(record.InputPayload)), *record.InputSize)
+
+	statusReq := httptest.NewRequest(http.MethodGet, "/api/v1/executions/"+envelope.ExecutionID, nil)
+	statusResp := httptest.NewRecorder()
+	router.ServeHTTP(statusResp, statusReq)
+	require.Equal(t, http.StatusOK, statusResp.Code)
+
+	var status ExecutionStatusResponse
+	require.NoError(t, json.Unmarshal(statusResp.Body.Bytes(), &status))
+	resultMap, ok := status.Result.(map[string]interface{})
+	require.True(t, ok)
+	require.Len(t, resultMap["blob"], 4096)
+}
+
 func ptrString(value string) *string {
 	return &value
 }
@@ -126,6 +126,90 @@ func TestUpdateExecutionStatusHandler_Failed(t *testing.T) {
 	require.Equal(t, types.ExecutionStatusFailed, updated.Status)
 	require.NotNil(t, updated.ErrorMessage)
 	require.Contains(t, *updated.ErrorMessage, "something went wrong")
+	require.NotNil(t, updated.ErrorCategory)
+	require.Equal(t, types.ExecutionErrorCategoryInternal, *updated.ErrorCategory)
+}
+
+func TestUpdateExecutionStatusHandler_ReportedErrorCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent := &types.AgentNode{
+		ID:        "node-1",
+		BaseURL:   "http://agent.example",
+		Reasoners: []types.ReasonerDefinition{{ID: "reasoner-a"}},
+	}
+
+	store := newTestExecutionStorage(agent)
+	payloads := services.NewFilePayloadStore(t.TempDir())
+
+	execution := &types.Execution{
+		ExecutionID: "exec-1",
+		RunID:       "run-1",
+		Status:      types.ExecutionStatusRunning,
+		StartedAt:   time.Now().UTC(),
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	}
+	require.NoError(t, store.CreateExecutionRecord(context.Background(), execution))
+
+	router := gin.New()
+	router.PUT("/api/v1/executions/:execution_id/status", UpdateExecutionStatusHandler(store, payloads, nil, 90*time.Second))
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/executions/exec-1/status", strings.NewReader(`{"status": "failed", "error_category": "bogus"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/executions/exec-1/status", strings.NewReader(`{"status": "failed", "error": "bad input", "error_category": "validation"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	updated, err := store.GetExecutionRecord(context.Background(), "exec-1")
+	require.NoError(t, err)
+	require.NotNil(t, updated.ErrorCategory)
+	require.Equal(t, types.ExecutionErrorCategoryValidation, *updated.ErrorCategory)
+}
+
+func TestUpdateExecutionStatusHandler_TimeoutSetsErrorCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agent := &types.AgentNode{
+		ID:        "node-1",
+		BaseURL:   "http://agent.example",
+		Reasoners: []types.ReasonerDefinition{{ID: "reasoner-a"}},
+	}
+
+	store := newTestExecutionStorage(agent)
+	payloads := services.NewFilePayloadStore(t.TempDir())
+
+	execution := &types.Execution{
+		ExecutionID: "exec-1",
+		RunID:       "run-1",
+		Status:      types.ExecutionStatusRunning,
+		StartedAt:   time.Now().UTC(),
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	}
+	require.NoError(t, store.CreateExecutionRecord(context.Background(), execution))
+
+	router := gin.New()
+	router.PUT("/api/v1/executions/:execution_id/status", UpdateExecutionStatusHandler(store, payloads, nil, 90*time.Second))
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/executions/exec-1/status", strings.NewReader(`{"status": "timeout"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var payload ExecutionStatusResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &payload))
+	require.NotNil(t, payload.ErrorCategory)
+	require.Equal(t, types.ExecutionErrorCategoryTimeout, *payload.ErrorCategory)
 }
 
 func TestUpdateExecutionStatusHandler_WithWebhook(t *testing.T) {
@@ -363,7 +363,7 @@ func TestBatchExecutionStatusHandler(t *testing.T) {
 
 			// Create gin context
 			router := gin.New()
-			router.POST("/api/v1/executions/batch-status", BatchExecutionStatusHandler(mockStorage))
+			router.POST("/api/v1/executions/batch-status", BatchExecutionStatusHandler(mockStorage, nil))
 
 			// Perform request
 			router.ServeHTTP(w, req)
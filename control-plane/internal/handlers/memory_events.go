@@ -159,9 +159,10 @@ func (h *MemoryEventsHandler) SSEHandler(c *gin.Context) {
 				continue // Skip events that can't be marshaled
 			}
 
-			// Send event to client
-			c.SSEvent("message", string(eventJSON))
-			c.Writer.Flush()
+			// Send event to client; stop as soon as the connection stops accepting writes
+			if !WriteSSEvent(c, "message", string(eventJSON)) {
+				return
+			}
 		}
 	}
 }
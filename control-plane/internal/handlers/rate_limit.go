@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultExecRateLimit = 50  // requests per second per target
+	defaultExecRateBurst = 100 // requests a target may burst above the steady rate
+
+	// maxTrackedRateLimitKeys caps the number of per-target buckets; idle (full)
+	// buckets are pruned once the cap is reached.
+	maxTrackedRateLimitKeys = 10000
+)
+
+var (
+	execRateLimiterOnce sync.Once
+	execRateLimiter     *executionRateLimiter
+)
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// executionRateLimiter is a token-bucket limiter keyed by execution target
+// (node_id.reasoner_name), shared by the sync and async execute handlers.
+type executionRateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // tokens added per second
+	burst   float64 // bucket capacity
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+func newExecutionRateLimiter(rate float64, burst int) *executionRateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &executionRateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// getExecutionRateLimiter returns the process-wide limiter configured from
+// AGENTFIELD_EXEC_RATE_LIMIT and AGENTFIELD_EXEC_RATE_BURST, defaulting to
+// defaultExecRateLimit and defaultExecRateBurst. Setting AGENTFIELD_EXEC_RATE_LIMIT=0
+// disables limiting and yields nil.
+func getExecutionRateLimiter() *executionRateLimiter {
+	execRateLimiterOnce.Do(func() {
+		execRateLimiter = executionRateLimiterFromEnv()
+	})
+	return execRateLimiter
+}
+
+func executionRateLimiterFromEnv() *executionRateLimiter {
+	rate := resolveIntFromEnv("AGENTFIELD_EXEC_RATE_LIMIT", defaultExecRateLimit)
+	if rate <= 0 {
+		return nil
+	}
+	burst := resolveIntFromEnv("AGENTFIELD_EXEC_RATE_BURST", defaultExecRateBurst)
+	if burst <= 0 {
+		burst = defaultExecRateBurst
+	}
+	return newExecutionRateLimiter(float64(rate), burst)
+}
+
+// allow consumes a token for key. When none is available it reports how long
+// the caller should wait before the next token is added.
+func (l *executionRateLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	bucket, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxTrackedRateLimitKeys {
+			l.pruneLocked(now)
+		}
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+func (l *executionRateLimiter) pruneLocked(now time.Time) {
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// allowExecution applies the rate limit for the request's target, writing a 429
+// with Retry-After and returning false when the target is over its limit.
+func (c *executionController) allowExecution(ctx *gin.Context) bool {
+	if c.limiter == nil {
+		return true
+	}
+	target, err := parseTarget(ctx.Param("target"))
+	if err != nil {
+		// Let prepareExecution report the malformed target.
+		return true
+	}
+
+	key := target.NodeID + "." + target.TargetName
+	allowed, wait := c.limiter.allow(key)
+	if allowed {
+		return true
+	}
+
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+	ctx.JSON(http.StatusTooManyRequests, gin.H{"error": fmt.Sprintf("rate limit exceeded for %s; retry later", key)})
+	return false
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Agent-Field/agentfield/control-plane/internal/services"
+	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionRateLimiter_RefillsAtRate(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newExecutionRateLimiter(2, 2)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		allowed, _ := limiter.allow("node-1.reasoner-a")
+		require.True(t, allowed)
+	}
+	allowed, wait := limiter.allow("node-1.reasoner-a")
+	require.False(t, allowed)
+	require.Equal(t, 500*time.Millisecond, wait)
+
+	// Other targets have their own bucket.
+	allowed, _ = limiter.allow("node-1.reasoner-b")
+	require.True(t, allowed)
+
+	now = now.Add(500 * time.Millisecond)
+	allowed, _ = limiter.allow("node-1.reasoner-a")
+	require.True(t, allowed)
+	allowed, _ = limiter.allow("node-1.reasoner-a")
+	require.False(t, allowed)
+}
+
+func TestExecutionRateLimiterFromEnv_DefaultRejectsBurst(t *testing.T) {
+	t.Setenv("AGENTFIELD_EXEC_RATE_LIMIT", "")
+	t.Setenv("AGENTFIELD_EXEC_RATE_BURST", "")
+	limiter := executionRateLimiterFromEnv()
+	require.NotNil(t, limiter, "limiting is on by default")
+
+	now := time.Unix(0, 0)
+	limiter.now = func() time.Time { return now }
+	for i := 0; i < defaultExecRateBurst; i++ {
+		allowed, _ := limiter.allow("node-1.reasoner-a")
+		require.True(t, allowed, "request %d is within the default burst", i)
+	}
+	allowed, wait := limiter.allow("node-1.reasoner-a")
+	require.False(t, allowed)
+	require.Equal(t, time.Second/defaultExecRateLimit, wait)
+
+	t.Setenv("AGENTFIELD_EXEC_RATE_LIMIT", "0")
+	require.Nil(t, executionRateLimiterFromEnv())
+
+	t.Setenv("AGENTFIELD_EXEC_RATE_LIMIT", "5")
+	limiter = executionRateLimiterFromEnv()
+	require.NotNil(t, limiter)
+	require.Equal(t, float64(5), limiter.rate)
+	require.Equal(t, float64(defaultExecRateBurst), limiter.burst)
+}
+
+func TestExecuteHandler_RateLimited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	agentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer agentServer.Close()
+
+	agent := &types.AgentNode{
+		ID:        "node-1",
+		BaseURL:   agentServer.URL,
+		Reasoners: []types.ReasonerDefinition{{ID: "reasoner-a"}},
+	}
+	store := newTestExecutionStorage(agent)
+	payloads := services.NewFilePayloadStore(t.TempDir())
+
+	now := time.Unix(0, 0)
+	controller := newExecutionController(store, payloads, nil, 90*time.Second)
+	controller.limiter = newExecutionRateLimiter(1, 3)
+	controller.limiter.now = func() time.Time { return now }
+
+	router := gin.New()
+	router.POST("/api/v1/execute/:target", controller.handleSync)
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/execute/node-1.reasoner-a", strings.NewReader(`{"input":{"foo":"bar"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	var accepted, limited int
+	for i := 0; i < 5; i++ {
+		resp := send()
+		switch resp.Code {
+		case http.StatusOK:
+			accepted++
+		case http.StatusTooManyRequests:
+			limited++
+			require.Equal(t, "1", resp.Header().Get("Retry-After"))
+			require.Contains(t, resp.Body.String(), "rate limit exceeded")
+		default:
+			t.Fatalf("unexpected status %d: %s", resp.Code, resp.Body.String())
+		}
+	}
+	require.Equal(t, 3, accepted)
+	require.Equal(t, 2, limited)
+
+	now = now.Add(time.Second)
+	require.Equal(t, http.StatusOK, send().Code)
+	require.Equal(t, http.StatusTooManyRequests, send().Code)
+}
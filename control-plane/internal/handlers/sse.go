@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlushSSE pushes buffered stream output to the client and reports whether it reached
+// the connection. gin's Flush discards the error, so FlushSSE looks through the wrapped
+// writers for net/http's FlushError. SSE handlers should stop streaming, and release
+// their event bus subscription, as soon as it fails.
+func FlushSSE(w gin.ResponseWriter) error {
+	w.WriteHeaderNow()
+
+	var rw http.ResponseWriter = w
+	for {
+		switch t := rw.(type) {
+		case interface{ FlushError() error }:
+			return t.FlushError()
+		case interface{ Unwrap() http.ResponseWriter }:
+			rw = t.Unwrap()
+		case http.Flusher:
+			t.Flush()
+			return nil
+		default:
+			return nil
+		}
+	}
+}
+
+// WriteSSEvent writes a named SSE event and flushes it, returning false when the client
+// can no longer be written to.
+func WriteSSEvent(c *gin.Context, name string, data interface{}) bool {
+	c.SSEvent(name, data)
+	if c.IsAborted() {
+		// gin records the render error and aborts the context.
+		return false
+	}
+	return FlushSSE(c.Writer) == nil
+}
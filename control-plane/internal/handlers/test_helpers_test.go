@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/Agent-Field/agentfield/control-plane/internal/events"
+	"github.com/Agent-Field/agentfield/control-plane/internal/storage"
 	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
 )
 
@@ -214,6 +215,9 @@ func (s *testExecutionStorage) CreateExecutionRecord(ctx context.Context, execut
 	if execution == nil {
 		return fmt.Errorf("execution cannot be nil")
 	}
+	if _, exists := s.executionRecords[execution.ExecutionID]; exists {
+		return fmt.Errorf("insert execution %s: %w", execution.ExecutionID, storage.ErrExecutionExists)
+	}
 	copy := *execution
 	s.executionRecords[execution.ExecutionID] = &copy
 	select {
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Agent-Field/agentfield/control-plane/internal/core/domain"
 	"github.com/Agent-Field/agentfield/control-plane/internal/core/interfaces"
@@ -193,6 +194,166 @@ func TestRefreshNodeStatusHandler_Structure(t *testing.T) {
 	assert.True(t, resp.Code >= http.StatusBadRequest) // Any response is valid
 }
 
+// TestGetAllAgentStatusHandler returns snapshot status for every registered agent
+func TestGetAllAgentStatusHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	cfg := storage.StorageConfig{
+		Mode: "local",
+		Local: storage.LocalStorageConfig{
+			DatabasePath: tempDir + "/test.db",
+			KVStorePath:  tempDir + "/test.bolt",
+		},
+	}
+
+	realStorage := storage.NewLocalStorage(storage.LocalStorageConfig{})
+	err := realStorage.Initialize(ctx, cfg)
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "fts5") {
+		t.Skip("sqlite3 compiled without FTS5")
+	}
+	require.NoError(t, err)
+	defer realStorage.Close(ctx)
+
+	lastHeartbeat := time.Now().Add(-30 * time.Second).UTC().Truncate(time.Second)
+	agents := map[string]types.HealthStatus{
+		"node-active":   types.HealthStatusActive,
+		"node-inactive": types.HealthStatusInactive,
+	}
+	for nodeID, health := range agents {
+		lifecycle := types.AgentStatusReady
+		if health == types.HealthStatusInactive {
+			lifecycle = types.AgentStatusOffline
+		}
+		require.NoError(t, realStorage.RegisterAgent(ctx, &types.AgentNode{
+			ID:              nodeID,
+			TeamID:          "team",
+			BaseURL:         "http://localhost",
+			Version:         "1.0.0",
+			HealthStatus:    health,
+			LifecycleStatus: lifecycle,
+			LastHeartbeat:   lastHeartbeat,
+			Reasoners:       []types.ReasonerDefinition{},
+			Skills:          []types.SkillDefinition{},
+		}))
+	}
+
+	// The agent client must not be called: snapshots never run live health checks
+	mockAgentClient := &MockAgentClientForUI{}
+	mockAgentService := &MockAgentServiceForUI{}
+	statusManager := services.NewStatusManager(realStorage, services.StatusManagerConfig{}, nil, mockAgentClient)
+	uiService := services.NewUIService(realStorage, mockAgentClient, mockAgentService, statusManager)
+
+	handler := NewNodesHandler(uiService)
+	router := gin.New()
+	router.GET("/api/ui/v1/agents/status", handler.GetAllAgentStatusHandler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/ui/v1/agents/status", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var result struct {
+		Statuses map[string]types.AgentStatus `json:"statuses"`
+		Count    int                          `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, 2, result.Count)
+	require.Len(t, result.Statuses, 2)
+
+	active := result.Statuses["node-active"]
+	assert.Equal(t, types.AgentStateActive, active.State)
+	assert.Equal(t, types.HealthStatusActive, active.HealthStatus)
+	assert.Equal(t, types.StatusSourceReconcile, active.Source)
+	assert.True(t, active.LastSeen.Equal(lastHeartbeat))
+
+	inactive := result.Statuses["node-inactive"]
+	assert.Equal(t, types.AgentStateInactive, inactive.State)
+	assert.Equal(t, types.AgentStatusOffline, inactive.LifecycleStatus)
+	assert.True(t, inactive.LastSeen.Equal(lastHeartbeat))
+
+	mockAgentClient.AssertNotCalled(t, "GetAgentStatus", mock.Anything, mock.Anything)
+}
+
+// TestRefreshAgentHandler forces a live check for one agent
+func TestRefreshAgentHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	cfg := storage.StorageConfig{
+		Mode: "local",
+		Local: storage.LocalStorageConfig{
+			DatabasePath: tempDir + "/test.db",
+			KVStorePath:  tempDir + "/test.bolt",
+		},
+	}
+
+	realStorage := storage.NewLocalStorage(storage.LocalStorageConfig{})
+	err := realStorage.Initialize(ctx, cfg)
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "fts5") {
+		t.Skip("sqlite3 compiled without FTS5")
+	}
+	require.NoError(t, err)
+	defer realStorage.Close(ctx)
+
+	// Stored as offline; the live check reports it running again
+	require.NoError(t, realStorage.RegisterAgent(ctx, &types.AgentNode{
+		ID:              "node-1",
+		TeamID:          "team",
+		BaseURL:         "http://localhost",
+		Version:         "1.0.0",
+		HealthStatus:    types.HealthStatusInactive,
+		LifecycleStatus: types.AgentStatusOffline,
+		LastHeartbeat:   time.Now().Add(-time.Hour),
+		Reasoners:       []types.ReasonerDefinition{},
+		Skills:          []types.SkillDefinition{},
+	}))
+
+	mockAgentClient := &MockAgentClientForUI{}
+	mockAgentClient.On("GetAgentStatus", mock.Anything, "node-1").
+		Return(&interfaces.AgentStatusResponse{Status: "running", NodeID: "node-1"}, nil).Once()
+	mockAgentService := &MockAgentServiceForUI{}
+	statusManager := services.NewStatusManager(realStorage, services.StatusManagerConfig{}, nil, mockAgentClient)
+	uiService := services.NewUIService(realStorage, mockAgentClient, mockAgentService, statusManager)
+
+	// Prime the cache with the stale stored status
+	stale, err := uiService.GetNodeStatusSnapshot(ctx, "node-1")
+	require.NoError(t, err)
+	require.Equal(t, types.AgentStateInactive, stale.State)
+
+	handler := NewNodesHandler(uiService)
+	router := gin.New()
+	router.POST("/api/ui/v1/agents/:agentId/refresh", handler.RefreshAgentHandler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/ui/v1/agents/node-1/refresh", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var status types.AgentStatus
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &status))
+	assert.Equal(t, types.AgentStateActive, status.State)
+	assert.Equal(t, types.StatusSourceHealthCheck, status.Source)
+	assert.NotNil(t, status.LastVerified)
+	mockAgentClient.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/ui/v1/agents/missing/refresh", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	mockAgentClient.AssertNotCalled(t, "GetAgentStatus", mock.Anything, "missing")
+
+	// Lookup failures other than a missing agent are server errors
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	req = httptest.NewRequest(http.MethodPost, "/api/ui/v1/agents/node-1/refresh", nil).WithContext(cancelled)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+}
+
 // TestBulkNodeStatusHandler_Validation tests bulk node status handler request validation
 func TestBulkNodeStatusHandler_Validation(t *testing.T) {
 	gin.SetMode(gin.TestMode)
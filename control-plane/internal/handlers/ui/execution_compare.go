@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxExecutionDiffEntries caps how many differences are reported per payload so that
+	// comparing very large inputs or outputs stays bounded.
+	maxExecutionDiffEntries = 500
+	// maxExecutionDiffValueBytes caps the encoded size of a single value echoed in a diff entry.
+	maxExecutionDiffValueBytes = 1024
+)
+
+// ExecutionDiffEntry describes a single difference at a JSON path.
+type ExecutionDiffEntry struct {
+	Path   string      `json:"path"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// ExecutionDataDiff lists the paths added, removed, or changed between two payloads.
+type ExecutionDataDiff struct {
+	Added     []ExecutionDiffEntry `json:"added"`
+	Removed   []ExecutionDiffEntry `json:"removed"`
+	Changed   []ExecutionDiffEntry `json:"changed"`
+	Truncated bool                 `json:"truncated"`
+}
+
+// ExecutionComparisonResponse is the structured delta between two executions.
+type ExecutionComparisonResponse struct {
+	A      ExecutionSummary  `json:"a"`
+	B      ExecutionSummary  `json:"b"`
+	Input  ExecutionDataDiff `json:"input"`
+	Output ExecutionDataDiff `json:"output"`
+}
+
+// CompareExecutionsHandler diffs the inputs and outputs of two executions.
+// GET /api/ui/v1/executions/compare?a=<id>&b=<id>
+func (h *ExecutionHandler) CompareExecutionsHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	idA := strings.TrimSpace(c.Query("a"))
+	idB := strings.TrimSpace(c.Query("b"))
+	if idA == "" || idB == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "both a and b execution ids are required"})
+		return
+	}
+
+	execA, err := h.store.GetExecutionRecord(ctx, idA)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("failed to load execution %s: %v", idA, err)})
+		return
+	}
+	if execA == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: fmt.Sprintf("execution %s not found", idA)})
+		return
+	}
+
+	execB, err := h.store.GetExecutionRecord(ctx, idB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("failed to load execution %s: %v", idB, err)})
+		return
+	}
+	if execB == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: fmt.Sprintf("execution %s not found", idB)})
+		return
+	}
+
+	inputA, _ := h.resolveExecutionData(ctx, execA.InputPayload, execA.InputURI)
+	inputB, _ := h.resolveExecutionData(ctx, execB.InputPayload, execB.InputURI)
+	outputA, _ := h.resolveExecutionData(ctx, execA.ResultPayload, execA.ResultURI)
+	outputB, _ := h.resolveExecutionData(ctx, execB.ResultPayload, execB.ResultURI)
+
+	c.JSON(http.StatusOK, ExecutionComparisonResponse{
+		A:      h.toExecutionSummary(execA),
+		B:      h.toExecutionSummary(execB),
+		Input:  diffExecutionData(inputA, inputB),
+		Output: diffExecutionData(outputA, outputB),
+	})
+}
+
+// diffExecutionData walks two decoded JSON values and records every path that differs.
+// Objects are compared key by key and arrays index by index; anything else is compared
+// as a whole value. Paths use a "$.field[0]" notation rooted at "$".
+func diffExecutionData(before, after interface{}) ExecutionDataDiff {
+	diff := ExecutionDataDiff{
+		Added:   []ExecutionDiffEntry{},
+		Removed: []ExecutionDiffEntry{},
+		Changed: []ExecutionDiffEntry{},
+	}
+	walkExecutionDiff("$", before, after, &diff)
+	return diff
+}
+
+func walkExecutionDiff(path string, before, after interface{}, diff *ExecutionDataDiff) {
+	if diff.Truncated {
+		return
+	}
+
+	switch b := before.(type) {
+	case map[string]interface{}:
+		if a, ok := after.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(b)+len(a))
+			for key := range b {
+				keys = append(keys, key)
+			}
+			for key := range a {
+				if _, exists := b[key]; !exists {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				childPath := path + "." + key
+				beforeValue, inBefore := b[key]
+				afterValue, inAfter := a[key]
+				switch {
+				case !inAfter:
+					recordExecutionDiff(&diff.Removed, diff, ExecutionDiffEntry{Path: childPath, Before: capDiffValue(beforeValue)})
+				case !inBefore:
+					recordExecutionDiff(&diff.Added, diff, ExecutionDiffEntry{Path: childPath, After: capDiffValue(afterValue)})
+				default:
+					walkExecutionDiff(childPath, beforeValue, afterValue, diff)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if a, ok := after.([]interface{}); ok {
+			for i := 0; i < len(b) || i < len(a); i++ {
+				childPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(a):
+					recordExecutionDiff(&diff.Removed, diff, ExecutionDiffEntry{Path: childPath, Before: capDiffValue(b[i])})
+				case i >= len(b):
+					recordExecutionDiff(&diff.Added, diff, ExecutionDiffEntry{Path: childPath, After: capDiffValue(a[i])})
+				default:
+					walkExecutionDiff(childPath, b[i], a[i], diff)
+				}
+			}
+			return
+		}
+	}
+
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+	switch {
+	case before == nil:
+		recordExecutionDiff(&diff.Added, diff, ExecutionDiffEntry{Path: path, After: capDiffValue(after)})
+	case after == nil:
+		recordExecutionDiff(&diff.Removed, diff, ExecutionDiffEntry{Path: path, Before: capDiffValue(before)})
+	default:
+		recordExecutionDiff(&diff.Changed, diff, ExecutionDiffEntry{Path: path, Before: capDiffValue(before), After: capDiffValue(after)})
+	}
+}
+
+func recordExecutionDiff(bucket *[]ExecutionDiffEntry, diff *ExecutionDataDiff, entry ExecutionDiffEntry) {
+	if len(diff.Added)+len(diff.Removed)+len(diff.Changed) >= maxExecutionDiffEntries {
+		diff.Truncated = true
+		return
+	}
+	*bucket = append(*bucket, entry)
+}
+
+// capDiffValue replaces values whose JSON encoding exceeds maxExecutionDiffValueBytes with a
+// short placeholder so a single large subtree cannot dominate the response.
+func capDiffValue(value interface{}) interface{} {
+	encoded, err := json.Marshal(value)
+	if err != nil || len(encoded) <= maxExecutionDiffValueBytes {
+		return value
+	}
+	return fmt.Sprintf("<omitted: %d bytes>", len(encoded))
+}
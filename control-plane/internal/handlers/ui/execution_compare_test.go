@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareExecutionsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store := newTestExecutionRecordStore(
+		&types.Execution{
+			ExecutionID:   "exec-a",
+			RunID:         "run-a",
+			ReasonerID:    "summarize",
+			Status:        string(types.ExecutionStatusSucceeded),
+			InputPayload:  json.RawMessage(`{"text":"hello","lang":"en"}`),
+			ResultPayload: json.RawMessage(`{"summary":"hi","score":0.5,"tags":["a","b"],"meta":{"model":"small"}}`),
+		},
+		&types.Execution{
+			ExecutionID:   "exec-b",
+			RunID:         "run-b",
+			ReasonerID:    "summarize",
+			Status:        string(types.ExecutionStatusSucceeded),
+			InputPayload:  json.RawMessage(`{"text":"hello","lang":"en"}`),
+			ResultPayload: json.RawMessage(`{"summary":"hello there","score":0.5,"tags":["a"],"meta":{"model":"large","tokens":12}}`),
+		},
+	)
+	handler := &ExecutionHandler{store: store}
+
+	router := gin.New()
+	router.GET("/api/ui/v1/executions/compare", handler.CompareExecutionsHandler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/compare?a=exec-a&b=exec-b", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp ExecutionComparisonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	require.Equal(t, "exec-a", resp.A.ExecutionID)
+	require.Equal(t, "exec-b", resp.B.ExecutionID)
+
+	require.Empty(t, resp.Input.Added)
+	require.Empty(t, resp.Input.Removed)
+	require.Empty(t, resp.Input.Changed)
+
+	require.Equal(t, []ExecutionDiffEntry{{Path: "$.meta.tokens", After: float64(12)}}, resp.Output.Added)
+	require.Equal(t, []ExecutionDiffEntry{{Path: "$.tags[1]", Before: "b"}}, resp.Output.Removed)
+	require.Equal(t, []ExecutionDiffEntry{
+		{Path: "$.meta.model", Before: "small", After: "large"},
+		{Path: "$.summary", Before: "hi", After: "hello there"},
+	}, resp.Output.Changed)
+	require.False(t, resp.Output.Truncated)
+
+	t.Run("missing execution", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/compare?a=exec-a&b=missing", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing ids", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/compare?a=exec-a", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestDiffExecutionDataCapsLargePayloads(t *testing.T) {
+	before := make([]interface{}, 0, maxExecutionDiffEntries+50)
+	after := make([]interface{}, 0, maxExecutionDiffEntries+50)
+	for i := 0; i < maxExecutionDiffEntries+50; i++ {
+		before = append(before, float64(i))
+		after = append(after, float64(i+1))
+	}
+
+	diff := diffExecutionData(before, after)
+	require.True(t, diff.Truncated)
+	require.Len(t, diff.Changed, maxExecutionDiffEntries)
+
+	large := strings.Repeat("x", maxExecutionDiffValueBytes*2)
+	diff = diffExecutionData(map[string]interface{}{}, map[string]interface{}{"blob": large})
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, "$.blob", diff.Added[0].Path)
+	require.Contains(t, diff.Added[0].After, "<omitted:")
+}
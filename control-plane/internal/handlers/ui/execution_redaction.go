@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redactedValue replaces masked execution data in UI responses.
+const redactedValue = "[REDACTED]"
+
+// ExecutionRedactionConfigKey is the storage config key holding the RedactionRules set
+// through the settings API.
+const ExecutionRedactionConfigKey = "ui.execution_redaction"
+
+// errUnredactablePayload is returned by ApplyJSON for payloads that rules cannot be
+// applied to.
+var errUnredactablePayload = errors.New("payload is not JSON and is withheld while redaction rules are set")
+
+// RedactionConfigStore reads and writes control plane settings; it is satisfied by
+// storage.StorageProvider.
+type RedactionConfigStore interface {
+	SetConfig(ctx context.Context, key string, value interface{}) error
+	GetConfig(ctx context.Context, key string) (interface{}, error)
+}
+
+// RedactionRules select execution input and output values to mask in UI responses.
+// Stored payloads are never modified.
+type RedactionRules struct {
+	// Paths are dot-separated field paths into the payload as stored, e.g.
+	// "input.credentials.api_key". A "*" segment matches any key or array element, and
+	// a numeric segment matches that array index.
+	Paths []string `json:"paths"`
+	// KeyPatterns are regular expressions matched against field names at any depth,
+	// e.g. "(?i)password|secret".
+	KeyPatterns []string `json:"key_patterns"`
+}
+
+// compiledRedaction is a validated, immutable form of RedactionRules.
+type compiledRedaction struct {
+	rules    RedactionRules
+	paths    [][]string
+	patterns []*regexp.Regexp
+}
+
+func compileRedactionRules(rules RedactionRules) (*compiledRedaction, error) {
+	compiled := &compiledRedaction{}
+	for _, path := range rules.Paths {
+		trimmed := strings.TrimSpace(path)
+		if trimmed == "" {
+			continue
+		}
+		segments := strings.Split(trimmed, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid redaction path %q: empty segment", path)
+			}
+		}
+		compiled.rules.Paths = append(compiled.rules.Paths, trimmed)
+		compiled.paths = append(compiled.paths, segments)
+	}
+	for _, pattern := range rules.KeyPatterns {
+		if strings.TrimSpace(pattern) == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction key pattern %q: %w", pattern, err)
+		}
+		compiled.rules.KeyPatterns = append(compiled.rules.KeyPatterns, pattern)
+		compiled.patterns = append(compiled.patterns, re)
+	}
+	if compiled.rules.Paths == nil {
+		compiled.rules.Paths = []string{}
+	}
+	if compiled.rules.KeyPatterns == nil {
+		compiled.rules.KeyPatterns = []string{}
+	}
+	return compiled, nil
+}
+
+// ExecutionRedactor masks execution data according to rules that can be replaced at
+// runtime. A nil ExecutionRedactor leaves data unchanged.
+type ExecutionRedactor struct {
+	current atomic.Pointer[compiledRedaction]
+}
+
+// NewExecutionRedactor validates rules and returns a redactor applying them.
+func NewExecutionRedactor(rules RedactionRules) (*ExecutionRedactor, error) {
+	r := &ExecutionRedactor{}
+	if err := r.Update(rules); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Update replaces the active rules. Invalid rules are rejected and the previous ones kept.
+func (r *ExecutionRedactor) Update(rules RedactionRules) error {
+	compiled, err := compileRedactionRules(rules)
+	if err != nil {
+		return err
+	}
+	r.current.Store(compiled)
+	return nil
+}
+
+// Rules returns the active rules.
+func (r *ExecutionRedactor) Rules() RedactionRules {
+	if r == nil || r.current.Load() == nil {
+		return RedactionRules{Paths: []string{}, KeyPatterns: []string{}}
+	}
+	return r.current.Load().rules
+}
+
+// Apply returns a copy of data with matching values replaced by redactedValue. Data
+// without a match is returned as is.
+func (r *ExecutionRedactor) Apply(data interface{}) interface{} {
+	compiled := r.active()
+	if compiled == nil {
+		return data
+	}
+	return compiled.redact(data, nil)
+}
+
+// ApplyJSON redacts an encoded JSON payload. The bytes are returned unchanged when no
+// rules are set. With rules set, a payload that is not a JSON document cannot be
+// inspected and an error is returned instead of the bytes.
+func (r *ExecutionRedactor) ApplyJSON(data []byte) ([]byte, error) {
+	compiled := r.active()
+	if compiled == nil {
+		return data, nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, errUnredactablePayload
+	}
+	encoded, err := json.Marshal(compiled.redact(decoded, nil))
+	if err != nil {
+		return nil, fmt.Errorf("encode redacted payload: %w", err)
+	}
+	return encoded, nil
+}
+
+// active returns the current rules, or nil when there are none to apply.
+func (r *ExecutionRedactor) active() *compiledRedaction {
+	if r == nil {
+		return nil
+	}
+	compiled := r.current.Load()
+	if compiled == nil || (len(compiled.paths) == 0 && len(compiled.patterns) == 0) {
+		return nil
+	}
+	return compiled
+}
+
+func (c *compiledRedaction) redact(value interface{}, path []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			childPath := append(path[:len(path):len(path)], key)
+			if c.matchesKey(key) || c.matchesPath(childPath) {
+				out[key] = redactedValue
+				continue
+			}
+			out[key] = c.redact(child, childPath)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			childPath := append(path[:len(path):len(path)], strconv.Itoa(i))
+			if c.matchesPath(childPath) {
+				out[i] = redactedValue
+				continue
+			}
+			out[i] = c.redact(child, childPath)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+func (c *compiledRedaction) matchesKey(key string) bool {
+	for _, re := range c.patterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *compiledRedaction) matchesPath(path []string) bool {
+	for _, rule := range c.paths {
+		if len(rule) != len(path) {
+			continue
+		}
+		matched := true
+		for i, segment := range rule {
+			if segment != "*" && segment != path[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadExecutionRedactionRules returns the rules stored through the settings API, or nil
+// when none have been stored.
+func LoadExecutionRedactionRules(ctx context.Context, store RedactionConfigStore) (*RedactionRules, error) {
+	value, err := store.GetConfig(ctx, ExecutionRedactionConfigKey)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encode execution redaction rules: %w", err)
+	}
+	var rules RedactionRules
+	if err := json.Unmarshal(encoded, &rules); err != nil {
+		return nil, fmt.Errorf("decode execution redaction rules: %w", err)
+	}
+	return &rules, nil
+}
+
+// ExecutionRedactionHandler exposes the execution redaction rules for runtime changes.
+type ExecutionRedactionHandler struct {
+	redactor *ExecutionRedactor
+	store    RedactionConfigStore
+}
+
+// NewExecutionRedactionHandler creates a new ExecutionRedactionHandler.
+func NewExecutionRedactionHandler(redactor *ExecutionRedactor, store RedactionConfigStore) *ExecutionRedactionHandler {
+	return &ExecutionRedactionHandler{redactor: redactor, store: store}
+}
+
+// GetRulesHandler returns the active redaction rules.
+// GET /api/v1/settings/execution-redaction
+func (h *ExecutionRedactionHandler) GetRulesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, h.redactor.Rules())
+}
+
+// SetRulesHandler replaces the redaction rules. They are stored so they survive restarts
+// and apply to the next execution detail response.
+// PUT /api/v1/settings/execution-redaction
+func (h *ExecutionRedactionHandler) SetRulesHandler(c *gin.Context) {
+	var rules RedactionRules
+	if err := c.ShouldBindJSON(&rules); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+	compiled, err := compileRedactionRules(rules)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := h.store.SetConfig(c.Request.Context(), ExecutionRedactionConfigKey, compiled.rules); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to store redaction rules: " + err.Error()})
+		return
+	}
+	h.redactor.current.Store(compiled)
+	c.JSON(http.StatusOK, h.redactor.Rules())
+}
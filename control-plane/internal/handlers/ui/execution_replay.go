@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReplayExecutionRequest selects where a past execution's input is sent. An empty Target
+// replays against the original node_id.reasoner_name.
+type ReplayExecutionRequest struct {
+	Target string `json:"target,omitempty"`
+}
+
+// ReplayExecutionHandler re-dispatches the input of a past execution, optionally to a
+// different target, through asyncExecute (the async execute handler). The new execution
+// records the original's ID in its replay_of field.
+// POST /api/ui/v1/executions/:execution_id/replay
+func (h *ExecutionHandler) ReplayExecutionHandler(asyncExecute gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		executionID := strings.TrimSpace(c.Param("execution_id"))
+		if executionID == "" {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "execution_id is required"})
+			return
+		}
+
+		var req ReplayExecutionRequest
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+				return
+			}
+		}
+
+		exec, err := h.store.GetExecutionRecord(ctx, executionID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to load execution: " + err.Error()})
+			return
+		}
+		if exec == nil {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "execution not found"})
+			return
+		}
+
+		target := strings.TrimSpace(req.Target)
+		if target == "" {
+			target = exec.NodeID + "." + exec.ReasonerID
+		}
+
+		raw := []byte(exec.InputPayload)
+		if len(bytes.TrimSpace(raw)) == 0 && exec.InputURI != nil && strings.TrimSpace(*exec.InputURI) != "" {
+			raw, err = h.loadPayloadBytes(ctx, strings.TrimSpace(*exec.InputURI))
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to load execution input: " + err.Error()})
+				return
+			}
+		}
+		input, requestContext, err := splitStoredExecutionInput(raw)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: fmt.Sprintf("execution %s has no replayable input: %v", executionID, err)})
+			return
+		}
+		body, err := json.Marshal(map[string]interface{}{
+			"input":     input,
+			"context":   requestContext,
+			"replay_of": executionID,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to encode replay request: " + err.Error()})
+			return
+		}
+
+		// Hand the rewritten request to the async execute handler so the replay goes
+		// through the same target policy, rate limit, and queue as any other execution.
+		forwarded := c.Request.Clone(ctx)
+		forwarded.Body = io.NopCloser(bytes.NewReader(body))
+		forwarded.ContentLength = int64(len(body))
+		forwarded.Header.Set("Content-Type", "application/json")
+		forwarded.Header.Del("X-Run-ID")
+		forwarded.Header.Del("X-Parent-Execution-ID")
+		c.Request = forwarded
+		c.Params = append(c.Params, gin.Param{Key: "target", Value: target})
+
+		asyncExecute(c)
+	}
+}
+
+// splitStoredExecutionInput separates a stored execution payload, saved as
+// {"input": ..., "context": ...} by the execute handlers, into its input and context.
+// Payloads without an "input" key are treated as the input itself.
+func splitStoredExecutionInput(raw []byte) (map[string]interface{}, map[string]interface{}, error) {
+	var stored map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(raw), &stored); err != nil {
+		return nil, nil, fmt.Errorf("decode stored input: %w", err)
+	}
+
+	input := stored
+	requestContext := map[string]interface{}{}
+	if wrapped, ok := stored["input"].(map[string]interface{}); ok {
+		input = wrapped
+		if storedContext, ok := stored["context"].(map[string]interface{}); ok {
+			for key, value := range storedContext {
+				requestContext[key] = value
+			}
+		}
+	}
+	if len(input) == 0 {
+		return nil, nil, fmt.Errorf("stored input is empty")
+	}
+	return input, requestContext, nil
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Agent-Field/agentfield/control-plane/internal/handlers"
+	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayExecutionHandler_DispatchesToNewTarget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	var (
+		mu        sync.Mutex
+		agentPath string
+		agentBody map[string]interface{}
+	)
+	agentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		agentPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&agentBody)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"summary":"v2"}`))
+	}))
+	defer agentServer.Close()
+
+	realStorage := setupTestStorage(t)
+	require.NoError(t, realStorage.RegisterAgent(ctx, &types.AgentNode{
+		ID:        "node-1",
+		BaseURL:   agentServer.URL,
+		Reasoners: []types.ReasonerDefinition{{ID: "summarize"}, {ID: "summarize_v2"}},
+	}))
+
+	now := time.Now().UTC()
+	require.NoError(t, realStorage.CreateExecutionRecord(ctx, &types.Execution{
+		ExecutionID:  "exec-original",
+		RunID:        "run-original",
+		AgentNodeID:  "node-1",
+		NodeID:       "node-1",
+		ReasonerID:   "summarize",
+		Status:       string(types.ExecutionStatusSucceeded),
+		InputPayload: json.RawMessage(`{"input":{"text":"hello"},"context":{"source":"ui"}}`),
+		StartedAt:    now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}))
+
+	handler := NewExecutionHandler(realStorage, nil, nil)
+	router := gin.New()
+	router.POST("/api/ui/v1/executions/:execution_id/replay",
+		handler.ReplayExecutionHandler(handlers.ExecuteAsyncHandler(realStorage, nil, nil, 5*time.Second)))
+	router.GET("/api/ui/v1/executions/:execution_id/details", handler.GetExecutionDetailsGlobalHandler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/ui/v1/executions/exec-original/replay", strings.NewReader(`{"target":"node-1.summarize_v2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusAccepted, resp.Code, resp.Body.String())
+	var accepted handlers.AsyncExecuteResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &accepted))
+	require.NotEqual(t, "exec-original", accepted.ExecutionID)
+	require.NotEqual(t, "run-original", accepted.RunID)
+	require.Equal(t, "node-1.summarize_v2", accepted.Target)
+	require.NotNil(t, accepted.ReplayOf)
+	require.Equal(t, "exec-original", *accepted.ReplayOf)
+
+	replayed, err := realStorage.GetExecutionRecord(ctx, accepted.ExecutionID)
+	require.NoError(t, err)
+	require.NotNil(t, replayed)
+	require.Equal(t, "summarize_v2", replayed.ReasonerID)
+	require.JSONEq(t, `{"input":{"text":"hello"},"context":{"source":"ui"}}`, string(replayed.InputPayload))
+	require.NotNil(t, replayed.ReplayOf)
+	require.Equal(t, "exec-original", *replayed.ReplayOf)
+
+	detailsReq := httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/"+accepted.ExecutionID+"/details", nil)
+	detailsResp := httptest.NewRecorder()
+	router.ServeHTTP(detailsResp, detailsReq)
+	require.Equal(t, http.StatusOK, detailsResp.Code, detailsResp.Body.String())
+	var details ExecutionDetailsResponse
+	require.NoError(t, json.Unmarshal(detailsResp.Body.Bytes(), &details))
+	require.NotNil(t, details.ReplayOf)
+	require.Equal(t, "exec-original", *details.ReplayOf)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return agentPath != ""
+	}, 2*time.Second, 20*time.Millisecond)
+	mu.Lock()
+	require.Equal(t, "/reasoners/summarize_v2", agentPath)
+	require.Equal(t, map[string]interface{}{"text": "hello"}, agentBody)
+	mu.Unlock()
+}
+
+func TestReplayExecutionHandler_UnknownExecution(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := &ExecutionHandler{store: newTestExecutionRecordStore()}
+	router := gin.New()
+	router.POST("/api/ui/v1/executions/:execution_id/replay", handler.ReplayExecutionHandler(func(c *gin.Context) {
+		t.Fatal("async handler must not be called for a missing execution")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/ui/v1/executions/missing/replay", strings.NewReader(`{"target":"node-1.other"}`))
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestSplitStoredExecutionInput(t *testing.T) {
+	input, requestContext, err := splitStoredExecutionInput([]byte(`{"input":{"a":1},"context":{"k":"v"}}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"a": float64(1)}, input)
+	require.Equal(t, map[string]interface{}{"k": "v"}, requestContext)
+
+	input, requestContext, err = splitStoredExecutionInput([]byte(`{"a":1}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"a": float64(1)}, input)
+	require.Empty(t, requestContext)
+
+	_, _, err = splitStoredExecutionInput([]byte(`"text"`))
+	require.Error(t, err)
+}
@@ -4,43 +4,78 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Agent-Field/agentfield/control-plane/internal/events"
+	"github.com/Agent-Field/agentfield/control-plane/internal/handlers"
 	"github.com/Agent-Field/agentfield/control-plane/internal/logger"
 	"github.com/Agent-Field/agentfield/control-plane/internal/services"
 	"github.com/Agent-Field/agentfield/control-plane/internal/storage"
 	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 )
 
 type executionRecordStore interface {
 	QueryExecutionRecords(ctx context.Context, filter types.ExecutionFilter) ([]*types.Execution, error)
 	GetExecutionRecord(ctx context.Context, executionID string) (*types.Execution, error)
+	GetWorkflowExecution(ctx context.Context, executionID string) (*types.WorkflowExecution, error)
 }
 
+// maxWorkflowAncestry bounds the parent walk when resolving an execution's root workflow.
+const maxWorkflowAncestry = 64
+
 // ExecutionHandler provides handlers for agent execution history operations.
 type ExecutionHandler struct {
 	store    executionRecordStore
 	payloads services.PayloadStore
 	storage  storage.StorageProvider
 	webhooks services.WebhookDispatcher
+	redactor *ExecutionRedactor
 }
 
+// writeSSE writes one SSE data frame and flushes it. It returns false when either the
+// write or the flush fails, so stream handlers return and release their subscription
+// without waiting for the request context to be cancelled.
 func writeSSE(c *gin.Context, payload []byte) bool {
 	if _, err := c.Writer.WriteString("data: " + string(payload) + "\n\n"); err != nil {
 		logger.Logger.Warn().Err(err).Msg("failed to write SSE payload")
 		return false
 	}
-	c.Writer.Flush()
+	if err := handlers.FlushSSE(c.Writer); err != nil {
+		logger.Logger.Warn().Err(err).Msg("failed to flush SSE payload")
+		return false
+	}
 	return true
 }
 
+// WithRedactor masks execution input and output in responses using redactor's rules.
+func (h *ExecutionHandler) WithRedactor(redactor *ExecutionRedactor) *ExecutionHandler {
+	h.redactor = redactor
+	return h
+}
+
+// subscribeStream registers a client stream on the execution event bus. When the bus is
+// at its subscriber limit it responds 503 and returns false; callers must not set
+// streaming headers before calling it.
+func (h *ExecutionHandler) subscribeStream(c *gin.Context, subscriberID string) (*events.ExecutionEventBus, chan events.ExecutionEvent, bool) {
+	eventBus := h.storage.GetExecutionEventBus()
+	eventChan, err := eventBus.TrySubscribe(subscriberID)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
+		return nil, nil, false
+	}
+	return eventBus, eventChan, true
+}
+
 // NewExecutionHandler creates a new ExecutionHandler.
 func NewExecutionHandler(store storage.StorageProvider, payloadStore services.PayloadStore, webhooks services.WebhookDispatcher) *ExecutionHandler {
 	return &ExecutionHandler{
@@ -54,12 +89,6 @@ func NewExecutionHandler(store storage.StorageProvider, payloadStore services.Pa
 // StreamWorkflowNodeNotesHandler handles SSE connections for workflow node notes.
 // GET /api/ui/v1/workflows/:workflowId/notes/events
 func (h *ExecutionHandler) StreamWorkflowNodeNotesHandler(c *gin.Context) {
-	c.Header("Content-Type", "text/event-stream")
-	c.Header("Cache-Control", "no-cache")
-	c.Header("Connection", "keep-alive")
-	c.Header("Access-Control-Allow-Origin", "*")
-	c.Header("Access-Control-Allow-Headers", "Cache-Control")
-
 	workflowID := c.Param("workflowId")
 	if workflowID == "" {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "workflowId is required"})
@@ -67,10 +96,18 @@ func (h *ExecutionHandler) StreamWorkflowNodeNotesHandler(c *gin.Context) {
 	}
 
 	subscriberID := fmt.Sprintf("sse_notes_%d_%s", time.Now().UnixNano(), workflowID)
-	eventBus := h.storage.GetExecutionEventBus()
-	eventChan := eventBus.Subscribe(subscriberID)
+	eventBus, eventChan, ok := h.subscribeStream(c, subscriberID)
+	if !ok {
+		return
+	}
 	defer eventBus.Unsubscribe(subscriberID)
 
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("Access-Control-Allow-Origin", "*")
+	c.Header("Access-Control-Allow-Headers", "Cache-Control")
+
 	initialEvent := map[string]interface{}{
 		"type":        "connected",
 		"workflow_id": workflowID,
@@ -125,20 +162,22 @@ type ExecutionListResponse struct {
 
 // ExecutionSummary represents execution summary information in the list.
 type ExecutionSummary struct {
-	ID           int64                `json:"id"`
-	ExecutionID  string               `json:"execution_id"`
-	WorkflowID   string               `json:"workflow_id"`
-	SessionID    *string              `json:"session_id,omitempty"`
-	AgentNodeID  string               `json:"agent_node_id"`
-	ReasonerID   string               `json:"reasoner_id"`
-	Status       string               `json:"status"`
-	DurationMS   int                  `json:"duration_ms"`
-	InputSize    int                  `json:"input_size"`
-	OutputSize   int                  `json:"output_size"`
-	ErrorMessage *string              `json:"error_message,omitempty"`
-	CreatedAt    time.Time            `json:"created_at"`
-	NotesCount   int                  `json:"notes_count"`
-	LatestNote   *types.ExecutionNote `json:"latest_note,omitempty"`
+	ID            int64                `json:"id"`
+	ExecutionID   string               `json:"execution_id"`
+	WorkflowID    string               `json:"workflow_id"`
+	SessionID     *string              `json:"session_id,omitempty"`
+	AgentNodeID   string               `json:"agent_node_id"`
+	ReasonerID    string               `json:"reasoner_id"`
+	Status        string               `json:"status"`
+	DurationMS    int                  `json:"duration_ms"`
+	InputSize     int                  `json:"input_size"`
+	OutputSize    int                  `json:"output_size"`
+	ErrorMessage  *string              `json:"error_message,omitempty"`
+	ErrorCategory *string              `json:"error_category,omitempty"`
+	Tags          map[string]string    `json:"tags,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	NotesCount    int                  `json:"notes_count"`
+	LatestNote    *types.ExecutionNote `json:"latest_note,omitempty"`
 }
 
 // ExecutionStatsResponse represents execution statistics.
@@ -150,8 +189,36 @@ type ExecutionStatsResponse struct {
 	AverageDurationMS  float64        `json:"average_duration_ms"`
 	ExecutionsByStatus map[string]int `json:"executions_by_status"`
 	ExecutionsByAgent  map[string]int `json:"executions_by_agent"`
+
+	ExecutionsByReasoner map[string]ReasonerExecutionStats `json:"executions_by_reasoner"`
+	// ExecutionsByErrorCategory counts failed executions by their recorded error category.
+	ExecutionsByErrorCategory map[string]int `json:"executions_by_error_category"`
+	// Truncated reports that the scan cap was reached and the stats cover only the most recent executions.
+	Truncated bool `json:"truncated"`
+	// Buckets holds per-reasoner stats for each time window when the bucket parameter is set.
+	Buckets []ExecutionStatsBucket `json:"buckets,omitempty"`
+}
+
+// ExecutionStatsBucket groups the executions started within one time window.
+type ExecutionStatsBucket struct {
+	Start                time.Time                         `json:"start"`
+	TotalExecutions      int                               `json:"total_executions"`
+	ExecutionsByReasoner map[string]ReasonerExecutionStats `json:"executions_by_reasoner"`
+}
+
+// ReasonerExecutionStats summarizes executions of a single reasoner.
+type ReasonerExecutionStats struct {
+	TotalExecutions int     `json:"total_executions"`
+	SuccessfulCount int     `json:"successful_count"`
+	FailedCount     int     `json:"failed_count"`
+	SuccessRate     float64 `json:"success_rate"`
+	P50DurationMS   int64   `json:"p50_duration_ms"`
+	P95DurationMS   int64   `json:"p95_duration_ms"`
 }
 
+// maxExecutionStatsScan caps how many executions the stats handler aggregates in memory.
+const maxExecutionStatsScan = 1000
+
 // ExecutionDetailsResponse represents detailed execution information.
 type ExecutionDetailsResponse struct {
 	ID                  int64                          `json:"id"`
@@ -164,6 +231,7 @@ type ExecutionDetailsResponse struct {
 	ParentWorkflowID    *string                        `json:"parent_workflow_id,omitempty"`
 	RootWorkflowID      *string                        `json:"root_workflow_id,omitempty"`
 	WorkflowDepth       *int                           `json:"workflow_depth,omitempty"`
+	ReplayOf            *string                        `json:"replay_of,omitempty"`
 	ReasonerID          string                         `json:"reasoner_id"`
 	InputData           interface{}                    `json:"input_data"`
 	OutputData          interface{}                    `json:"output_data"`
@@ -176,6 +244,8 @@ type ExecutionDetailsResponse struct {
 	CompletedAt         *string                        `json:"completed_at,omitempty"`
 	DurationMS          *int                           `json:"duration_ms,omitempty"`
 	ErrorMessage        *string                        `json:"error_message,omitempty"`
+	ErrorCategory       *string                        `json:"error_category,omitempty"`
+	Tags                map[string]string              `json:"tags,omitempty"`
 	RetryCount          int                            `json:"retry_count"`
 	CreatedAt           string                         `json:"created_at"`
 	UpdatedAt           *string                        `json:"updated_at,omitempty"`
@@ -187,20 +257,21 @@ type ExecutionDetailsResponse struct {
 }
 
 type EnhancedExecution struct {
-	ExecutionID     string  `json:"execution_id"`
-	WorkflowID      string  `json:"workflow_id"`
-	Status          string  `json:"status"`
-	TaskName        string  `json:"task_name"`
-	WorkflowName    string  `json:"workflow_name"`
-	AgentName       string  `json:"agent_name"`
-	RelativeTime    string  `json:"relative_time"`
-	DurationDisplay string  `json:"duration_display"`
-	WorkflowContext *string `json:"workflow_context,omitempty"`
-	StartedAt       string  `json:"started_at"`
-	CompletedAt     *string `json:"completed_at,omitempty"`
-	DurationMS      *int64  `json:"duration_ms,omitempty"`
-	SessionID       *string `json:"session_id,omitempty"`
-	ActorID         *string `json:"actor_id,omitempty"`
+	ExecutionID     string            `json:"execution_id"`
+	WorkflowID      string            `json:"workflow_id"`
+	Status          string            `json:"status"`
+	TaskName        string            `json:"task_name"`
+	WorkflowName    string            `json:"workflow_name"`
+	AgentName       string            `json:"agent_name"`
+	RelativeTime    string            `json:"relative_time"`
+	DurationDisplay string            `json:"duration_display"`
+	WorkflowContext *string           `json:"workflow_context,omitempty"`
+	StartedAt       string            `json:"started_at"`
+	CompletedAt     *string           `json:"completed_at,omitempty"`
+	DurationMS      *int64            `json:"duration_ms,omitempty"`
+	SessionID       *string           `json:"session_id,omitempty"`
+	ActorID         *string           `json:"actor_id,omitempty"`
+	Tags            map[string]string `json:"tags,omitempty"`
 }
 
 type EnhancedExecutionsResponse struct {
@@ -226,16 +297,20 @@ func (h *ExecutionHandler) ListExecutionsHandler(c *gin.Context) {
 	pageSize := parseBoundedIntOrDefault(c.Query("pageSize"), 10, 1, 100)
 	status := strings.TrimSpace(c.Query("status"))
 	runID := strings.TrimSpace(c.Query("workflowId"))
-	sortField := sanitizeExecutionSortField(c.DefaultQuery("sortBy", "started_at"))
-	sortDesc := strings.ToLower(c.DefaultQuery("sortOrder", "desc")) != "asc"
+	sortKeys := parseExecutionSortKeys(c.DefaultQuery("sortBy", "started_at"), strings.ToLower(c.DefaultQuery("sortOrder", "desc")) != "asc")
+	tags, err := parseExecutionTagFilter(c.QueryArray("tag"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
 
 	filter := types.ExecutionFilter{
-		AgentNodeID:    &agentID,
-		Limit:          pageSize,
-		Offset:         (page - 1) * pageSize,
-		SortBy:         sortField,
-		SortDescending: sortDesc,
+		AgentNodeID: &agentID,
+		Tags:        tags,
+		Limit:       pageSize,
+		Offset:      (page - 1) * pageSize,
 	}
+	applyExecutionSortKeys(&filter, sortKeys)
 	if status != "" {
 		filter.Status = &status
 	}
@@ -320,6 +395,11 @@ func (h *ExecutionHandler) GetExecutionsSummaryHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid end_time format, expected RFC3339"})
 		return
 	}
+	tags, err := parseExecutionTagFilter(c.QueryArray("tag"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
 
 	filter := types.ExecutionFilter{
 		Limit:          pageSize,
@@ -328,6 +408,7 @@ func (h *ExecutionHandler) GetExecutionsSummaryHandler(c *gin.Context) {
 		SortDescending: true,
 		StartTime:      startTime,
 		EndTime:        endTime,
+		Tags:           tags,
 	}
 	if status != "" {
 		filter.Status = &status
@@ -390,11 +471,31 @@ func (h *ExecutionHandler) GetExecutionStatsHandler(c *gin.Context) {
 	agentID := strings.TrimSpace(c.Query("agent_node_id"))
 	sessionID := strings.TrimSpace(c.Query("session_id"))
 	runID := strings.TrimSpace(c.Query("workflow_id"))
+	startTime, err := parseTimePtrValue(c.Query("start_time"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid start_time format, expected RFC3339"})
+		return
+	}
+	endTime, err := parseTimePtrValue(c.Query("end_time"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid end_time format, expected RFC3339"})
+		return
+	}
+	var bucket time.Duration
+	if raw := strings.TrimSpace(c.Query("bucket")); raw != "" {
+		bucket, err = time.ParseDuration(raw)
+		if err != nil || bucket < time.Minute {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid bucket, expected a duration of at least 1m such as 15m or 1h"})
+			return
+		}
+	}
 
 	filter := types.ExecutionFilter{
-		Limit:          1000,
+		Limit:          maxExecutionStatsScan,
 		SortBy:         "started_at",
 		SortDescending: true,
+		StartTime:      startTime,
+		EndTime:        endTime,
 	}
 	if agentID != "" {
 		filter.AgentNodeID = &agentID
@@ -413,9 +514,12 @@ func (h *ExecutionHandler) GetExecutionStatsHandler(c *gin.Context) {
 	}
 
 	stats := ExecutionStatsResponse{
-		TotalExecutions:    len(execs),
-		ExecutionsByStatus: make(map[string]int),
-		ExecutionsByAgent:  make(map[string]int),
+		TotalExecutions:           len(execs),
+		ExecutionsByStatus:        make(map[string]int),
+		ExecutionsByAgent:         make(map[string]int),
+		ExecutionsByReasoner:      reasonerExecutionStats(execs),
+		ExecutionsByErrorCategory: make(map[string]int),
+		Truncated:                 len(execs) >= maxExecutionStatsScan,
 	}
 
 	var totalDuration int64
@@ -429,6 +533,9 @@ func (h *ExecutionHandler) GetExecutionStatsHandler(c *gin.Context) {
 			stats.SuccessfulCount++
 		case string(types.ExecutionStatusFailed):
 			stats.FailedCount++
+			if exec.ErrorCategory != nil && *exec.ErrorCategory != "" {
+				stats.ExecutionsByErrorCategory[*exec.ErrorCategory]++
+			}
 		case string(types.ExecutionStatusRunning), string(types.ExecutionStatusPending), string(types.ExecutionStatusQueued):
 			stats.RunningCount++
 		}
@@ -441,10 +548,79 @@ func (h *ExecutionHandler) GetExecutionStatsHandler(c *gin.Context) {
 	if stats.TotalExecutions > 0 {
 		stats.AverageDurationMS = float64(totalDuration) / float64(stats.TotalExecutions)
 	}
+	if bucket > 0 {
+		stats.Buckets = executionStatsBuckets(execs, bucket)
+	}
 
 	c.JSON(http.StatusOK, stats)
 }
 
+// reasonerExecutionStats computes per-reasoner counts, success rate and duration percentiles.
+func reasonerExecutionStats(execs []*types.Execution) map[string]ReasonerExecutionStats {
+	byReasoner := make(map[string]ReasonerExecutionStats)
+	durations := make(map[string][]int64)
+	for _, exec := range execs {
+		reasoner := byReasoner[exec.ReasonerID]
+		reasoner.TotalExecutions++
+		switch types.NormalizeExecutionStatus(exec.Status) {
+		case string(types.ExecutionStatusSucceeded):
+			reasoner.SuccessfulCount++
+		case string(types.ExecutionStatusFailed):
+			reasoner.FailedCount++
+		}
+		byReasoner[exec.ReasonerID] = reasoner
+
+		if exec.DurationMS != nil {
+			durations[exec.ReasonerID] = append(durations[exec.ReasonerID], *exec.DurationMS)
+		}
+	}
+
+	for reasonerID, reasoner := range byReasoner {
+		if finished := reasoner.SuccessfulCount + reasoner.FailedCount; finished > 0 {
+			reasoner.SuccessRate = float64(reasoner.SuccessfulCount) / float64(finished)
+		}
+		sorted := durations[reasonerID]
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		reasoner.P50DurationMS = durationPercentile(sorted, 50)
+		reasoner.P95DurationMS = durationPercentile(sorted, 95)
+		byReasoner[reasonerID] = reasoner
+	}
+	return byReasoner
+}
+
+// executionStatsBuckets groups executions into windows of the given size by start time and
+// returns the non-empty windows in chronological order.
+func executionStatsBuckets(execs []*types.Execution, size time.Duration) []ExecutionStatsBucket {
+	grouped := make(map[time.Time][]*types.Execution)
+	for _, exec := range execs {
+		start := exec.StartedAt.UTC().Truncate(size)
+		grouped[start] = append(grouped[start], exec)
+	}
+
+	buckets := make([]ExecutionStatsBucket, 0, len(grouped))
+	for start, bucketExecs := range grouped {
+		buckets = append(buckets, ExecutionStatsBucket{
+			Start:                start,
+			TotalExecutions:      len(bucketExecs),
+			ExecutionsByReasoner: reasonerExecutionStats(bucketExecs),
+		})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Start.Before(buckets[j].Start) })
+	return buckets
+}
+
+// durationPercentile returns the nearest-rank percentile of an ascending slice of durations.
+func durationPercentile(sorted []int64, percentile int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (percentile*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
 // GetEnhancedExecutionsHandler provides the flattened execution list used by the enhanced executions view.
 // GET /api/ui/v1/executions/enhanced
 func (h *ExecutionHandler) GetEnhancedExecutionsHandler(c *gin.Context) {
@@ -453,13 +629,18 @@ func (h *ExecutionHandler) GetEnhancedExecutionsHandler(c *gin.Context) {
 	page := parsePositiveIntOrDefault(c.Query("page"), 1)
 	limit := parseBoundedIntOrDefault(c.Query("limit"), 50, 1, 200)
 	offset := (page - 1) * limit
+	tags, err := parseExecutionTagFilter(c.QueryArray("tag"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
 
 	filter := types.ExecutionFilter{
-		Limit:          limit,
-		Offset:         offset,
-		SortBy:         sanitizeExecutionSortField(c.DefaultQuery("sort_by", "started_at")),
-		SortDescending: strings.ToLower(c.DefaultQuery("sort_order", "desc")) != "asc",
+		Limit:  limit,
+		Offset: offset,
+		Tags:   tags,
 	}
+	applyExecutionSortKeys(&filter, parseExecutionSortKeys(c.DefaultQuery("sort_by", "started_at"), strings.ToLower(c.DefaultQuery("sort_order", "desc")) != "asc"))
 
 	if status := strings.TrimSpace(c.Query("status")); status != "" {
 		normalized := types.NormalizeExecutionStatus(status)
@@ -517,6 +698,7 @@ func (h *ExecutionHandler) GetEnhancedExecutionsHandler(c *gin.Context) {
 			DurationMS:      exec.DurationMS,
 			SessionID:       exec.SessionID,
 			ActorID:         exec.ActorID,
+			Tags:            exec.Tags,
 		})
 	}
 
@@ -537,7 +719,9 @@ func (h *ExecutionHandler) GetEnhancedExecutionsHandler(c *gin.Context) {
 }
 
 // GetExecutionDetailsGlobalHandler handles requests for a single execution (global view).
-// GET /api/ui/v1/executions/:execution_id/details
+// With format=raw (or Accept: application/octet-stream) it returns the stored input or
+// output payload bytes verbatim instead of the decoded details.
+// GET /api/ui/v1/executions/:execution_id/details[?format=raw&payload=input|output]
 func (h *ExecutionHandler) GetExecutionDetailsGlobalHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 	executionID := strings.TrimSpace(c.Param("execution_id"))
@@ -556,9 +740,119 @@ func (h *ExecutionHandler) GetExecutionDetailsGlobalHandler(c *gin.Context) {
 		return
 	}
 
+	if wantsRawExecutionPayload(c) {
+		h.writeRawExecutionPayload(c, exec)
+		return
+	}
+
 	c.JSON(http.StatusOK, h.toExecutionDetails(ctx, exec))
 }
 
+// wantsRawExecutionPayload reports whether the caller asked for the stored payload bytes
+// via format=raw or an Accept header of application/octet-stream.
+func wantsRawExecutionPayload(c *gin.Context) bool {
+	if strings.EqualFold(strings.TrimSpace(c.Query("format")), "raw") {
+		return true
+	}
+	return strings.Contains(c.GetHeader("Accept"), "application/octet-stream")
+}
+
+// writeRawExecutionPayload writes the input or output payload (selected by the payload
+// query parameter, defaulting to output) exactly as stored, without decoding it. When
+// redaction rules are set, JSON payloads are re-encoded with them applied and other
+// payloads are withheld.
+func (h *ExecutionHandler) writeRawExecutionPayload(c *gin.Context, exec *types.Execution) {
+	var raw []byte
+	var uri *string
+	switch strings.ToLower(strings.TrimSpace(c.DefaultQuery("payload", "output"))) {
+	case "input":
+		raw, uri = exec.InputPayload, exec.InputURI
+	case "output":
+		raw, uri = exec.ResultPayload, exec.ResultURI
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "payload must be input or output"})
+		return
+	}
+
+	data := raw
+	if len(data) == 0 && uri != nil && strings.TrimSpace(*uri) != "" {
+		loaded, err := h.loadPayloadBytes(c.Request.Context(), strings.TrimSpace(*uri))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to load payload: " + err.Error()})
+			return
+		}
+		data = loaded
+	}
+	if len(data) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	contentType := http.DetectContentType(data)
+	if json.Valid(data) {
+		contentType = "application/json"
+	}
+	data, err := h.redactor.ApplyJSON(data)
+	if err != nil {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// DeleteExecutionHandler removes a single execution record along with its stored payloads and notes.
+// Running executions are only deleted when force=true.
+// DELETE /api/ui/v1/executions/:execution_id
+func (h *ExecutionHandler) DeleteExecutionHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	executionID := strings.TrimSpace(c.Param("execution_id"))
+	if executionID == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "execution_id is required"})
+		return
+	}
+
+	exec, err := h.store.GetExecutionRecord(ctx, executionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("failed to load execution: %v", err)})
+		return
+	}
+	if exec == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "execution not found"})
+		return
+	}
+
+	force, _ := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if !force && !types.IsTerminalExecutionStatus(exec.Status) {
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "execution is still running; pass force=true to delete it"})
+		return
+	}
+
+	if err := h.storage.DeleteExecutionRecord(ctx, executionID); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("failed to delete execution: %v", err)})
+		return
+	}
+
+	removedPayloads := 0
+	if h.payloads != nil {
+		for _, uri := range []*string{exec.InputURI, exec.ResultURI} {
+			if uri == nil || strings.TrimSpace(*uri) == "" {
+				continue
+			}
+			if err := h.payloads.Remove(ctx, strings.TrimSpace(*uri)); err != nil {
+				logger.Logger.Warn().Err(err).Str("execution_id", executionID).Str("uri", *uri).Msg("failed to remove execution payload")
+				continue
+			}
+			removedPayloads++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":          true,
+		"execution_id":     executionID,
+		"removed_payloads": removedPayloads,
+	})
+}
+
 // RetryExecutionWebhookHandler re-enqueues webhook delivery attempts for an execution.
 func (h *ExecutionHandler) RetryExecutionWebhookHandler(c *gin.Context) {
 	if h.webhooks == nil {
@@ -604,19 +898,48 @@ func (h *ExecutionHandler) RetryExecutionWebhookHandler(c *gin.Context) {
 	c.JSON(http.StatusAccepted, gin.H{"status": "queued"})
 }
 
-// StreamExecutionEventsHandler streams execution events for the UI dashboard.
+// executionEventFilter narrows an execution event stream to a single agent and/or workflow.
+// Empty fields match every event.
+type executionEventFilter struct {
+	agentID    string
+	workflowID string
+}
+
+func executionEventFilterFromQuery(c *gin.Context) executionEventFilter {
+	return executionEventFilter{
+		agentID:    strings.TrimSpace(c.Query("agent_id")),
+		workflowID: strings.TrimSpace(c.Query("workflow_id")),
+	}
+}
+
+func (f executionEventFilter) matches(event events.ExecutionEvent) bool {
+	if f.agentID != "" && event.AgentNodeID != f.agentID {
+		return false
+	}
+	if f.workflowID != "" && event.WorkflowID != f.workflowID {
+		return false
+	}
+	return true
+}
+
+// StreamExecutionEventsHandler streams execution events for the UI dashboard. The optional
+// agent_id and workflow_id query parameters restrict the stream to matching events.
 // GET /api/ui/v1/executions/events
 func (h *ExecutionHandler) StreamExecutionEventsHandler(c *gin.Context) {
+	filter := executionEventFilterFromQuery(c)
+
+	subscriberID := fmt.Sprintf("ui_exec_events_%d", time.Now().UnixNano())
+	eventBus, eventChan, ok := h.subscribeStream(c, subscriberID)
+	if !ok {
+		return
+	}
+	defer eventBus.Unsubscribe(subscriberID)
+
 	c.Header("Content-Type", "text/event-stream")
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
 	c.Header("Access-Control-Allow-Origin", "*")
 
-	subscriberID := fmt.Sprintf("ui_exec_events_%d", time.Now().UnixNano())
-	eventBus := h.storage.GetExecutionEventBus()
-	eventChan := eventBus.Subscribe(subscriberID)
-	defer eventBus.Unsubscribe(subscriberID)
-
 	ctx := c.Request.Context()
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
@@ -639,6 +962,9 @@ func (h *ExecutionHandler) StreamExecutionEventsHandler(c *gin.Context) {
 			if !ok {
 				return
 			}
+			if !filter.matches(event) {
+				continue
+			}
 			if payload, err := json.Marshal(event); err == nil {
 				if !writeSSE(c, payload) {
 					return
@@ -648,6 +974,92 @@ func (h *ExecutionHandler) StreamExecutionEventsHandler(c *gin.Context) {
 	}
 }
 
+const (
+	executionWSWriteWait    = 10 * time.Second
+	executionWSPongWait     = 60 * time.Second
+	executionWSPingInterval = 30 * time.Second
+)
+
+// executionEventsUpgrader upgrades dashboard connections for execution events.
+// Origin checking is not needed because auth middleware already validates API keys
+// before requests reach this handler.
+var executionEventsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// StreamExecutionEventsWebSocketHandler pushes the same execution events as the SSE stream over
+// a WebSocket, for clients behind proxies that strip SSE. Ping/pong frames keep the connection alive.
+// It accepts the same agent_id and workflow_id filters.
+// GET /api/ui/v1/executions/ws
+func (h *ExecutionHandler) StreamExecutionEventsWebSocketHandler(c *gin.Context) {
+	filter := executionEventFilterFromQuery(c)
+
+	// Subscribe before upgrading so a full bus can still be reported as a 503.
+	subscriberID := fmt.Sprintf("ui_exec_ws_%d", time.Now().UnixNano())
+	eventBus, eventChan, ok := h.subscribeStream(c, subscriberID)
+	if !ok {
+		return
+	}
+	defer eventBus.Unsubscribe(subscriberID)
+
+	conn, err := executionEventsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade already wrote an error response
+		return
+	}
+	defer conn.Close()
+
+	// Read pump: handles pongs and detects client disconnects
+	closed := make(chan struct{})
+	_ = conn.SetReadDeadline(time.Now().Add(executionWSPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(executionWSPongWait))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ctx := c.Request.Context()
+	ticker := time.NewTicker(executionWSPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-closed:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(executionWSWriteWait)); err != nil {
+				return
+			}
+		case event, ok := <-eventChan:
+			if !ok {
+				return
+			}
+			if !filter.matches(event) {
+				continue
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(executionWSWriteWait))
+			if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				logger.Logger.Debug().Err(err).Msg("failed to write execution event to websocket")
+				return
+			}
+		}
+	}
+}
+
 // Helper utilities ---------------------------------------------------------
 
 func (h *ExecutionHandler) toExecutionSummary(exec *types.Execution) ExecutionSummary {
@@ -657,23 +1069,34 @@ func (h *ExecutionHandler) toExecutionSummary(exec *types.Execution) ExecutionSu
 	}
 
 	return ExecutionSummary{
-		ID:           0,
-		ExecutionID:  exec.ExecutionID,
-		WorkflowID:   exec.RunID,
-		SessionID:    exec.SessionID,
-		AgentNodeID:  exec.AgentNodeID,
-		ReasonerID:   exec.ReasonerID,
-		Status:       types.NormalizeExecutionStatus(exec.Status),
-		DurationMS:   duration,
-		InputSize:    len(exec.InputPayload),
-		OutputSize:   len(exec.ResultPayload),
-		ErrorMessage: exec.ErrorMessage,
-		CreatedAt:    exec.StartedAt,
-		NotesCount:   0,
-		LatestNote:   nil,
+		ID:            0,
+		ExecutionID:   exec.ExecutionID,
+		WorkflowID:    exec.RunID,
+		SessionID:     exec.SessionID,
+		AgentNodeID:   exec.AgentNodeID,
+		ReasonerID:    exec.ReasonerID,
+		Status:        types.NormalizeExecutionStatus(exec.Status),
+		DurationMS:    duration,
+		InputSize:     payloadSize(exec.InputPayload, exec.InputSize),
+		OutputSize:    payloadSize(exec.ResultPayload, exec.ResultSize),
+		ErrorMessage:  exec.ErrorMessage,
+		ErrorCategory: exec.ErrorCategory,
+		Tags:          exec.Tags,
+		CreatedAt:     exec.StartedAt,
+		NotesCount:    0,
+		LatestNote:    nil,
 	}
 }
 
+// payloadSize reports the recorded size of a payload, which stays accurate once the
+// payload has been offloaded, falling back to the inline bytes for older records.
+func payloadSize(inline json.RawMessage, recorded *int64) int {
+	if recorded != nil {
+		return int(*recorded)
+	}
+	return len(inline)
+}
+
 func (h *ExecutionHandler) toExecutionDetails(ctx context.Context, exec *types.Execution) ExecutionDetailsResponse {
 	inputData, inputSize := h.resolveExecutionData(ctx, exec.InputPayload, exec.InputURI)
 	outputData, outputSize := h.resolveExecutionData(ctx, exec.ResultPayload, exec.ResultURI)
@@ -701,6 +1124,17 @@ func (h *ExecutionHandler) toExecutionDetails(ctx context.Context, exec *types.E
 	webhookRegistered := exec.WebhookRegistered
 	webhookEvents := exec.WebhookEvents
 
+	rootWorkflowID, workflowDepth := h.resolveWorkflowLineage(ctx, exec)
+
+	var workflowName *string
+	var workflowTags []string
+	if workflowExec, err := h.store.GetWorkflowExecution(ctx, exec.ExecutionID); err != nil {
+		logger.Logger.Debug().Err(err).Str("execution_id", exec.ExecutionID).Msg("failed to load workflow metadata for execution")
+	} else if workflowExec != nil {
+		workflowName = workflowExec.WorkflowName
+		workflowTags = workflowExec.WorkflowTags
+	}
+
 	return ExecutionDetailsResponse{
 		ID:                  0,
 		ExecutionID:         exec.ExecutionID,
@@ -710,20 +1144,23 @@ func (h *ExecutionHandler) toExecutionDetails(ctx context.Context, exec *types.E
 		ActorID:             exec.ActorID,
 		AgentNodeID:         exec.AgentNodeID,
 		ParentWorkflowID:    exec.ParentExecutionID,
-		RootWorkflowID:      nil,
-		WorkflowDepth:       nil,
+		RootWorkflowID:      &rootWorkflowID,
+		WorkflowDepth:       &workflowDepth,
+		ReplayOf:            exec.ReplayOf,
 		ReasonerID:          exec.ReasonerID,
 		InputData:           inputData,
 		OutputData:          outputData,
 		InputSize:           inputSize,
 		OutputSize:          outputSize,
-		WorkflowName:        nil,
-		WorkflowTags:        nil,
+		WorkflowName:        workflowName,
+		WorkflowTags:        workflowTags,
 		Status:              types.NormalizeExecutionStatus(exec.Status),
 		StartedAt:           startedAt,
 		CompletedAt:         completedAt,
 		DurationMS:          durationPtr,
 		ErrorMessage:        exec.ErrorMessage,
+		ErrorCategory:       exec.ErrorCategory,
+		Tags:                exec.Tags,
 		RetryCount:          0,
 		CreatedAt:           exec.StartedAt.Format(time.RFC3339),
 		UpdatedAt:           &updated,
@@ -735,7 +1172,47 @@ func (h *ExecutionHandler) toExecutionDetails(ctx context.Context, exec *types.E
 	}
 }
 
+// resolveWorkflowLineage walks ParentExecutionID links to find the run that started the
+// call chain and how deep the execution sits within it. Missing parents end the walk and
+// cycles are broken rather than followed.
+func (h *ExecutionHandler) resolveWorkflowLineage(ctx context.Context, exec *types.Execution) (string, int) {
+	rootWorkflowID := exec.RunID
+	depth := 0
+	visited := map[string]struct{}{exec.ExecutionID: {}}
+
+	parentID := exec.ParentExecutionID
+	for parentID != nil && *parentID != "" && depth < maxWorkflowAncestry {
+		if _, seen := visited[*parentID]; seen {
+			logger.Logger.Warn().Str("execution_id", exec.ExecutionID).Str("parent_execution_id", *parentID).Msg("cycle detected in execution parent chain")
+			break
+		}
+		visited[*parentID] = struct{}{}
+		depth++
+
+		parent, err := h.store.GetExecutionRecord(ctx, *parentID)
+		if err != nil || parent == nil {
+			break
+		}
+		if parent.RunID != "" {
+			rootWorkflowID = parent.RunID
+		}
+		parentID = parent.ParentExecutionID
+	}
+
+	return rootWorkflowID, depth
+}
+
+// resolveExecutionData returns execution data for a response, with the redaction rules
+// applied. The size is that of the unredacted payload.
 func (h *ExecutionHandler) resolveExecutionData(ctx context.Context, raw []byte, uri *string) (interface{}, int) {
+	data, size := h.loadExecutionData(ctx, raw, uri)
+	return h.redactor.Apply(data), size
+}
+
+// loadExecutionData returns the decoded inline payload, falling back to the payload
+// store when the inline copy is missing or corrupted. A stored payload that fails its
+// integrity check is reported as a payload_integrity_error object rather than data.
+func (h *ExecutionHandler) loadExecutionData(ctx context.Context, raw []byte, uri *string) (interface{}, int) {
 	data := decodePayload(raw)
 	size := len(raw)
 
@@ -753,6 +1230,16 @@ func (h *ExecutionHandler) resolveExecutionData(ctx context.Context, raw []byte,
 	}
 
 	payload, payloadSize, err := h.loadPayloadData(ctx, trimmed)
+	var integrityErr *services.PayloadIntegrityError
+	if errors.As(err, &integrityErr) {
+		logger.Logger.Error().Err(err).Str("uri", trimmed).Msg("stored payload failed integrity check")
+		return map[string]interface{}{
+			"error":           payloadIntegritySentinel,
+			"uri":             integrityErr.URI,
+			"expected_sha256": integrityErr.Expected,
+			"actual_sha256":   integrityErr.Actual,
+		}, size
+	}
 	if err != nil {
 		logger.Logger.Warn().Err(err).Str("uri", trimmed).Msg("failed to load payload for execution data")
 		return data, size
@@ -761,31 +1248,39 @@ func (h *ExecutionHandler) resolveExecutionData(ctx context.Context, raw []byte,
 }
 
 func (h *ExecutionHandler) loadPayloadData(ctx context.Context, uri string) (interface{}, int, error) {
+	payloadBytes, err := h.loadPayloadBytes(ctx, uri)
+	if err != nil {
+		return nil, 0, err
+	}
+	return decodePayload(payloadBytes), len(payloadBytes), nil
+}
+
+func (h *ExecutionHandler) loadPayloadBytes(ctx context.Context, uri string) ([]byte, error) {
 	if h.payloads == nil {
-		return nil, 0, fmt.Errorf("payload store unavailable")
+		return nil, fmt.Errorf("payload store unavailable")
 	}
 
 	reader, err := h.payloads.Open(ctx, uri)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer reader.Close()
 
 	payloadBytes, err := io.ReadAll(reader)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	if len(payloadBytes) > largePayloadWarningThreshold {
 		logger.Logger.Warn().Str("uri", uri).Int("bytes", len(payloadBytes)).Msg("large payload loaded for execution IO display")
 	}
-
-	return decodePayload(payloadBytes), len(payloadBytes), nil
+	return payloadBytes, nil
 }
 
 const (
 	largePayloadWarningThreshold = 5 * 1024 * 1024 // 5 MiB
 	corruptedJSONSentinel        = "corrupted_json_data"
+	payloadIntegritySentinel     = "payload_integrity_error"
 )
 
 func decodePayload(raw []byte) interface{} {
@@ -829,6 +1324,24 @@ func hasMeaningfulData(data interface{}) bool {
 	}
 }
 
+// parseExecutionTagFilter parses repeated tag=key=value query parameters into a tag
+// filter. Each value is split at its first "=".
+func parseExecutionTagFilter(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	tags := make(map[string]string, len(values))
+	for _, raw := range values {
+		key, value, ok := strings.Cut(raw, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid tag filter %q, expected key=value", raw)
+		}
+		tags[key] = strings.TrimSpace(value)
+	}
+	return tags, nil
+}
+
 func parsePositiveIntOrDefault(value string, fallback int) int {
 	if value == "" {
 		return fallback
@@ -861,26 +1374,88 @@ func parseTimePtrValue(value string) (*time.Time, error) {
 	return &parsed, nil
 }
 
-func sanitizeExecutionSortField(field string) string {
+// lookupExecutionSortField maps a friendly sort key to its storage field, reporting
+// whether the key is on the allowlist.
+func lookupExecutionSortField(field string) (string, bool) {
 	switch strings.ToLower(strings.TrimSpace(field)) {
 	case "status":
-		return "status"
+		return "status", true
 	case "task_name", "reasoner", "reasoner_id":
-		return "reasoner_id"
+		return "reasoner_id", true
 	case "duration_ms":
-		return "duration_ms"
+		return "duration_ms", true
 	case "duration":
-		return "duration_ms"
+		return "duration_ms", true
 	case "agent_node_id":
-		return "agent_node_id"
+		return "agent_node_id", true
 	case "execution_id":
-		return "execution_id"
+		return "execution_id", true
 	case "run_id", "workflow_id":
-		return "run_id"
+		return "run_id", true
 	case "when", "started", "started_at", "created_at":
-		return "started_at"
+		return "started_at", true
 	default:
-		return "started_at"
+		return "", false
+	}
+}
+
+// parseExecutionSortKeys parses a comma-separated sort spec such as "status:asc,started_at:desc"
+// (or "status asc, started_at desc"). Tokens without a direction use defaultDesc; unknown fields
+// and directions are ignored, as are repeated fields. An empty result falls back to started_at.
+func parseExecutionSortKeys(spec string, defaultDesc bool) []types.ExecutionSortKey {
+	var keys []types.ExecutionSortKey
+	seen := make(map[string]struct{})
+
+	for _, token := range strings.Split(spec, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		name, direction := token, ""
+		if idx := strings.IndexAny(token, ": "); idx >= 0 {
+			name, direction = token[:idx], strings.ToLower(strings.TrimSpace(token[idx+1:]))
+		}
+
+		field, ok := lookupExecutionSortField(name)
+		if !ok {
+			continue
+		}
+		if _, dup := seen[field]; dup {
+			continue
+		}
+
+		desc := defaultDesc
+		switch direction {
+		case "":
+		case "asc":
+			desc = false
+		case "desc":
+			desc = true
+		default:
+			continue
+		}
+
+		seen[field] = struct{}{}
+		keys = append(keys, types.ExecutionSortKey{Field: field, Descending: desc})
+	}
+
+	if len(keys) == 0 {
+		keys = append(keys, types.ExecutionSortKey{Field: "started_at", Descending: defaultDesc})
+	}
+	return keys
+}
+
+// applyExecutionSortKeys sets the filter's primary sort from the first key and keeps the
+// full ordered list only when more than one key was requested.
+func applyExecutionSortKeys(filter *types.ExecutionFilter, keys []types.ExecutionSortKey) {
+	if len(keys) == 0 {
+		return
+	}
+	filter.SortBy = keys[0].Field
+	filter.SortDescending = keys[0].Descending
+	if len(keys) > 1 {
+		filter.SortKeys = keys
 	}
 }
 
@@ -5,10 +5,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Agent-Field/agentfield/control-plane/internal/services"
+	"github.com/Agent-Field/agentfield/control-plane/internal/storage"
+	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 )
 
@@ -41,6 +51,38 @@ func (s *testPayloadStore) Remove(ctx context.Context, uri string) error {
 	return nil
 }
 
+type testExecutionRecordStore struct {
+	executions         map[string]*types.Execution
+	workflowExecutions map[string]*types.WorkflowExecution
+}
+
+func newTestExecutionRecordStore(executions ...*types.Execution) *testExecutionRecordStore {
+	store := &testExecutionRecordStore{
+		executions:         make(map[string]*types.Execution),
+		workflowExecutions: make(map[string]*types.WorkflowExecution),
+	}
+	for _, exec := range executions {
+		store.executions[exec.ExecutionID] = exec
+	}
+	return store
+}
+
+func (s *testExecutionRecordStore) QueryExecutionRecords(ctx context.Context, filter types.ExecutionFilter) ([]*types.Execution, error) {
+	results := make([]*types.Execution, 0, len(s.executions))
+	for _, exec := range s.executions {
+		results = append(results, exec)
+	}
+	return results, nil
+}
+
+func (s *testExecutionRecordStore) GetExecutionRecord(ctx context.Context, executionID string) (*types.Execution, error) {
+	return s.executions[executionID], nil
+}
+
+func (s *testExecutionRecordStore) GetWorkflowExecution(ctx context.Context, executionID string) (*types.WorkflowExecution, error) {
+	return s.workflowExecutions[executionID], nil
+}
+
 func TestHasMeaningfulDataDetectsCorruptedPlaceholder(t *testing.T) {
 	placeholder := map[string]interface{}{
 		"error":   corruptedJSONSentinel,
@@ -71,3 +113,569 @@ func TestResolveExecutionDataFallsBackForCorruptedPreview(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, `{"full":true}`, string(marshalled))
 }
+
+func TestResolveExecutionDataReportsIntegrityFailure(t *testing.T) {
+	baseDir := t.TempDir()
+	payloads := services.NewFilePayloadStore(baseDir)
+	handler := &ExecutionHandler{payloads: payloads}
+
+	record, err := payloads.SaveBytes(context.Background(), []byte(`{"full":true}`))
+	require.NoError(t, err)
+	stored := filepath.Join(baseDir, strings.TrimPrefix(record.URI, "payload://"))
+	require.NoError(t, os.WriteFile(stored, []byte(`{"full":false}`), 0o600))
+
+	data, _ := handler.resolveExecutionData(context.Background(), nil, &record.URI)
+
+	report, ok := data.(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, payloadIntegritySentinel, report["error"])
+	require.Equal(t, record.URI, report["uri"])
+	require.Equal(t, record.SHA256, report["expected_sha256"])
+	require.NotEqual(t, record.SHA256, report["actual_sha256"])
+}
+
+func TestGetExecutionDetailsGlobalHandlerRawPayload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	payloads := newTestPayloadStore()
+	binaryOutput := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0xff, 0x10}
+	outputURI := "payload://output"
+	payloads.data[outputURI] = binaryOutput
+	rawInput := []byte(`{"b": 1,   "a": [1,2]}`)
+
+	handler := &ExecutionHandler{
+		store: newTestExecutionRecordStore(&types.Execution{
+			ExecutionID:  "exec-1",
+			RunID:        "run-1",
+			Status:       string(types.ExecutionStatusSucceeded),
+			InputPayload: json.RawMessage(rawInput),
+			ResultURI:    &outputURI,
+		}),
+		payloads: payloads,
+	}
+	router := gin.New()
+	router.GET("/api/ui/v1/executions/:execution_id/details", handler.GetExecutionDetailsGlobalHandler)
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/ui/v1/executions/exec-1/details", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var details ExecutionDetailsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+	require.Equal(t, map[string]interface{}{"a": []interface{}{float64(1), float64(2)}, "b": float64(1)}, details.InputData)
+
+	w = get("/api/ui/v1/executions/exec-1/details?format=raw&payload=input", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, rawInput, w.Body.Bytes())
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	w = get("/api/ui/v1/executions/exec-1/details?format=raw", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, binaryOutput, w.Body.Bytes())
+	require.Equal(t, "image/png", w.Header().Get("Content-Type"))
+
+	w = get("/api/ui/v1/executions/exec-1/details", "application/octet-stream")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, binaryOutput, w.Body.Bytes())
+
+	w = get("/api/ui/v1/executions/exec-1/details?format=raw&payload=metadata", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetExecutionDetailsGlobalHandlerRedactsConfiguredFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	storedInput := []byte(`{"input":{"api_key":"sk-live-123","query":"weather"},"context":{"user":"u1"}}`)
+	storedOutput := []byte(`{"summary":"sunny","auth":{"Access_Token":"tok-456"}}`)
+	store := newTestExecutionRecordStore(&types.Execution{
+		ExecutionID:   "exec-1",
+		RunID:         "run-1",
+		Status:        string(types.ExecutionStatusSucceeded),
+		InputPayload:  json.RawMessage(storedInput),
+		ResultPayload: json.RawMessage(storedOutput),
+	})
+	redactor, err := NewExecutionRedactor(RedactionRules{
+		Paths:       []string{"input.api_key"},
+		KeyPatterns: []string{"(?i)token"},
+	})
+	require.NoError(t, err)
+
+	handler := (&ExecutionHandler{store: store}).WithRedactor(redactor)
+	router := gin.New()
+	router.GET("/api/ui/v1/executions/:execution_id/details", handler.GetExecutionDetailsGlobalHandler)
+	configStore := &memoryConfigStore{values: map[string]interface{}{}}
+	settings := NewExecutionRedactionHandler(redactor, configStore)
+	router.PUT("/api/v1/settings/execution-redaction", settings.SetRulesHandler)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		return w
+	}
+
+	var details ExecutionDetailsResponse
+	require.NoError(t, json.Unmarshal(get("/api/ui/v1/executions/exec-1/details").Body.Bytes(), &details))
+	marshalledInput, err := json.Marshal(details.InputData)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"input":{"api_key":"[REDACTED]","query":"weather"},"context":{"user":"u1"}}`, string(marshalledInput))
+	marshalledOutput, err := json.Marshal(details.OutputData)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"summary":"sunny","auth":{"Access_Token":"[REDACTED]"}}`, string(marshalledOutput))
+	require.Equal(t, len(storedInput), details.InputSize)
+
+	raw := get("/api/ui/v1/executions/exec-1/details?format=raw&payload=input")
+	require.NotContains(t, raw.Body.String(), "sk-live-123")
+
+	// The stored record keeps the original values.
+	record, err := store.GetExecutionRecord(context.Background(), "exec-1")
+	require.NoError(t, err)
+	require.Equal(t, storedInput, []byte(record.InputPayload))
+	require.Equal(t, storedOutput, []byte(record.ResultPayload))
+
+	// Rules can be replaced at runtime; invalid ones are rejected.
+	put := func(body string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/settings/execution-redaction", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	require.Equal(t, http.StatusBadRequest, put(`{"key_patterns":["("]}`))
+	require.Equal(t, []string{"input.api_key"}, redactor.Rules().Paths)
+	require.Equal(t, http.StatusOK, put(`{"paths":["input.query"]}`))
+
+	require.NoError(t, json.Unmarshal(get("/api/ui/v1/executions/exec-1/details").Body.Bytes(), &details))
+	marshalledInput, err = json.Marshal(details.InputData)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"input":{"api_key":"sk-live-123","query":"[REDACTED]"},"context":{"user":"u1"}}`, string(marshalledInput))
+
+	// Accepted rules are stored and restored on the next start.
+	stored, err := LoadExecutionRedactionRules(context.Background(), configStore)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.Equal(t, []string{"input.query"}, stored.Paths)
+	restored, err := NewExecutionRedactor(*stored)
+	require.NoError(t, err)
+	require.Equal(t, redactor.Rules(), restored.Rules())
+}
+
+func TestGetExecutionDetailsGlobalHandlerWithholdsUnredactableRawPayloads(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	binaryOutput := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 's', 'k', '-', '1'}
+	store := newTestExecutionRecordStore(&types.Execution{
+		ExecutionID:   "exec-1",
+		RunID:         "run-1",
+		Status:        string(types.ExecutionStatusSucceeded),
+		InputPayload:  json.RawMessage(`{"token":"abc"}`),
+		ResultPayload: json.RawMessage(binaryOutput),
+	})
+	redactor, err := NewExecutionRedactor(RedactionRules{KeyPatterns: []string{"(?i)token"}})
+	require.NoError(t, err)
+
+	handler := (&ExecutionHandler{store: store}).WithRedactor(redactor)
+	router := gin.New()
+	router.GET("/api/ui/v1/executions/:execution_id/details", handler.GetExecutionDetailsGlobalHandler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/exec-1/details?format=raw", nil))
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.NotContains(t, w.Body.String(), "sk-1")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/exec-1/details?format=raw&payload=input", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"token":"[REDACTED]"}`, w.Body.String())
+}
+
+// memoryConfigStore is an in-memory RedactionConfigStore.
+type memoryConfigStore struct {
+	values map[string]interface{}
+}
+
+func (m *memoryConfigStore) SetConfig(_ context.Context, key string, value interface{}) error {
+	m.values[key] = value
+	return nil
+}
+
+func (m *memoryConfigStore) GetConfig(_ context.Context, key string) (interface{}, error) {
+	return m.values[key], nil
+}
+
+func TestToExecutionDetailsResolvesWorkflowLineage(t *testing.T) {
+	root := &types.Execution{ExecutionID: "exec-root", RunID: "run-root", Status: "succeeded"}
+	child := &types.Execution{ExecutionID: "exec-child", RunID: "run-child", ParentExecutionID: &root.ExecutionID, Status: "succeeded"}
+	grandchild := &types.Execution{ExecutionID: "exec-grandchild", RunID: "run-child", ParentExecutionID: &child.ExecutionID, Status: "running"}
+
+	store := newTestExecutionRecordStore(root, child, grandchild)
+	workflowName := "agent.summarize"
+	store.workflowExecutions["exec-grandchild"] = &types.WorkflowExecution{
+		ExecutionID:  "exec-grandchild",
+		WorkflowName: &workflowName,
+		WorkflowTags: []string{"reasoner"},
+	}
+	handler := &ExecutionHandler{store: store}
+
+	details := handler.toExecutionDetails(context.Background(), grandchild)
+	require.NotNil(t, details.RootWorkflowID)
+	require.Equal(t, "run-root", *details.RootWorkflowID)
+	require.NotNil(t, details.WorkflowDepth)
+	require.Equal(t, 2, *details.WorkflowDepth)
+	require.NotNil(t, details.WorkflowName)
+	require.Equal(t, workflowName, *details.WorkflowName)
+	require.Equal(t, []string{"reasoner"}, details.WorkflowTags)
+
+	details = handler.toExecutionDetails(context.Background(), root)
+	require.Equal(t, "run-root", *details.RootWorkflowID)
+	require.Equal(t, 0, *details.WorkflowDepth)
+	require.Nil(t, details.WorkflowName)
+}
+
+func TestToExecutionSummaryReportsOffloadedPayloadSizes(t *testing.T) {
+	inputURI := "file:///payloads/input"
+	resultURI := "file:///payloads/result"
+	inputSize, resultSize := int64(4096), int64(2<<20)
+	exec := &types.Execution{
+		ExecutionID: "exec-offloaded",
+		RunID:       "run-1",
+		Status:      "succeeded",
+		InputURI:    &inputURI,
+		ResultURI:   &resultURI,
+		InputSize:   &inputSize,
+		ResultSize:  &resultSize,
+	}
+
+	summary := (&ExecutionHandler{}).toExecutionSummary(exec)
+	require.Equal(t, 4096, summary.InputSize)
+	require.Equal(t, 2<<20, summary.OutputSize)
+
+	// Records without a stored size fall back to the inline payload.
+	legacy := &types.Execution{ExecutionID: "exec-legacy", InputPayload: json.RawMessage(`{"a":1}`)}
+	summary = (&ExecutionHandler{}).toExecutionSummary(legacy)
+	require.Equal(t, 7, summary.InputSize)
+	require.Equal(t, 0, summary.OutputSize)
+}
+
+func TestToExecutionDetailsStopsOnParentCycle(t *testing.T) {
+	a := &types.Execution{ExecutionID: "exec-a", RunID: "run-a"}
+	b := &types.Execution{ExecutionID: "exec-b", RunID: "run-b"}
+	a.ParentExecutionID = &b.ExecutionID
+	b.ParentExecutionID = &a.ExecutionID
+
+	handler := &ExecutionHandler{store: newTestExecutionRecordStore(a, b)}
+
+	details := handler.toExecutionDetails(context.Background(), a)
+	require.Equal(t, "run-b", *details.RootWorkflowID)
+	require.Equal(t, 1, *details.WorkflowDepth)
+}
+
+func TestDeleteExecutionHandlerRemovesRecordAndPayloads(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	cfg := storage.StorageConfig{
+		Mode: "local",
+		Local: storage.LocalStorageConfig{
+			DatabasePath: filepath.Join(tempDir, "test.db"),
+			KVStorePath:  filepath.Join(tempDir, "test.bolt"),
+		},
+	}
+
+	realStorage := storage.NewLocalStorage(storage.LocalStorageConfig{})
+	err := realStorage.Initialize(ctx, cfg)
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "fts5") {
+		t.Skip("sqlite3 compiled without FTS5")
+	}
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		realStorage.Close(ctx)
+	})
+
+	payloadStore := services.NewFilePayloadStore(t.TempDir())
+	inputRecord, err := payloadStore.SaveBytes(ctx, []byte(`{"api_key":"leaked"}`))
+	require.NoError(t, err)
+	resultRecord, err := payloadStore.SaveBytes(ctx, []byte(`{"answer":42}`))
+	require.NoError(t, err)
+
+	require.NoError(t, realStorage.CreateExecutionRecord(ctx, &types.Execution{
+		ExecutionID: "exec-done",
+		RunID:       "run-1",
+		AgentNodeID: "agent-1",
+		ReasonerID:  "reasoner-1",
+		NodeID:      "agent-1",
+		Status:      string(types.ExecutionStatusSucceeded),
+		InputURI:    &inputRecord.URI,
+		ResultURI:   &resultRecord.URI,
+		Notes:       []types.ExecutionNote{{Message: "contains secrets"}},
+	}))
+	require.NoError(t, realStorage.CreateExecutionRecord(ctx, &types.Execution{
+		ExecutionID: "exec-running",
+		RunID:       "run-1",
+		AgentNodeID: "agent-1",
+		ReasonerID:  "reasoner-1",
+		NodeID:      "agent-1",
+		Status:      string(types.ExecutionStatusRunning),
+	}))
+
+	handler := NewExecutionHandler(realStorage, payloadStore, nil)
+	router := gin.New()
+	router.DELETE("/api/ui/v1/executions/:execution_id", handler.DeleteExecutionHandler)
+
+	doDelete := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := doDelete("/api/ui/v1/executions/exec-done")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, true, resp["success"])
+	require.Equal(t, float64(2), resp["removed_payloads"])
+
+	exec, err := realStorage.GetExecutionRecord(ctx, "exec-done")
+	require.NoError(t, err)
+	require.Nil(t, exec)
+
+	for _, uri := range []string{inputRecord.URI, resultRecord.URI} {
+		_, err := payloadStore.Open(ctx, uri)
+		require.Error(t, err, "payload %s should be removed", uri)
+	}
+
+	w = doDelete("/api/ui/v1/executions/exec-done")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = doDelete("/api/ui/v1/executions/exec-running")
+	require.Equal(t, http.StatusConflict, w.Code)
+	exec, err = realStorage.GetExecutionRecord(ctx, "exec-running")
+	require.NoError(t, err)
+	require.NotNil(t, exec)
+
+	w = doDelete("/api/ui/v1/executions/exec-running?force=true")
+	require.Equal(t, http.StatusOK, w.Code)
+	exec, err = realStorage.GetExecutionRecord(ctx, "exec-running")
+	require.NoError(t, err)
+	require.Nil(t, exec)
+}
+
+func TestParseExecutionSortKeys(t *testing.T) {
+	t.Run("single field keeps sort order default", func(t *testing.T) {
+		keys := parseExecutionSortKeys("duration", true)
+		require.Equal(t, []types.ExecutionSortKey{{Field: "duration_ms", Descending: true}}, keys)
+
+		var filter types.ExecutionFilter
+		applyExecutionSortKeys(&filter, keys)
+		require.Equal(t, "duration_ms", filter.SortBy)
+		require.True(t, filter.SortDescending)
+		require.Nil(t, filter.SortKeys)
+	})
+
+	t.Run("ordered multi key spec", func(t *testing.T) {
+		keys := parseExecutionSortKeys("status:asc, started_at:desc", false)
+		require.Equal(t, []types.ExecutionSortKey{
+			{Field: "status", Descending: false},
+			{Field: "started_at", Descending: true},
+		}, keys)
+
+		var filter types.ExecutionFilter
+		applyExecutionSortKeys(&filter, keys)
+		require.Equal(t, "status", filter.SortBy)
+		require.False(t, filter.SortDescending)
+		require.Equal(t, keys, filter.SortKeys)
+	})
+
+	t.Run("space separated directions", func(t *testing.T) {
+		keys := parseExecutionSortKeys("status asc, reasoner desc", false)
+		require.Equal(t, []types.ExecutionSortKey{
+			{Field: "status", Descending: false},
+			{Field: "reasoner_id", Descending: true},
+		}, keys)
+	})
+
+	t.Run("invalid fields and directions are ignored", func(t *testing.T) {
+		keys := parseExecutionSortKeys("input_payload;drop:asc,status:sideways,agent_node_id,started:desc,when:asc", false)
+		require.Equal(t, []types.ExecutionSortKey{
+			{Field: "agent_node_id", Descending: false},
+			{Field: "started_at", Descending: true},
+		}, keys)
+	})
+
+	t.Run("nothing valid falls back to started_at", func(t *testing.T) {
+		keys := parseExecutionSortKeys("bogus,, ", true)
+		require.Equal(t, []types.ExecutionSortKey{{Field: "started_at", Descending: true}}, keys)
+	})
+}
+
+func TestParseExecutionTagFilter(t *testing.T) {
+	tags, err := parseExecutionTagFilter(nil)
+	require.NoError(t, err)
+	require.Nil(t, tags)
+
+	tags, err = parseExecutionTagFilter([]string{"env=staging", " team = search ", "empty="})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"env": "staging", "team": "search", "empty": ""}, tags)
+
+	for _, raw := range []string{"env", "=staging"} {
+		_, err := parseExecutionTagFilter([]string{raw})
+		require.Error(t, err, raw)
+	}
+}
+
+func TestGetExecutionStatsHandlerGroupsByReasoner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	duration := func(ms int64) *int64 { return &ms }
+	timeoutCategory := types.ExecutionErrorCategoryTimeout
+	var executions []*types.Execution
+	for i := 1; i <= 10; i++ {
+		executions = append(executions, &types.Execution{
+			ExecutionID: fmt.Sprintf("fast-%d", i),
+			AgentNodeID: "agent-1",
+			ReasonerID:  "fast",
+			Status:      string(types.ExecutionStatusSucceeded),
+			DurationMS:  duration(int64(i * 10)),
+		})
+	}
+	executions = append(executions,
+		&types.Execution{ExecutionID: "slow-1", AgentNodeID: "agent-2", ReasonerID: "slow", Status: string(types.ExecutionStatusSucceeded), DurationMS: duration(3000)},
+		&types.Execution{ExecutionID: "slow-2", AgentNodeID: "agent-2", ReasonerID: "slow", Status: string(types.ExecutionStatusFailed), DurationMS: duration(1000), ErrorCategory: &timeoutCategory},
+		&types.Execution{ExecutionID: "slow-3", AgentNodeID: "agent-2", ReasonerID: "slow", Status: string(types.ExecutionStatusSucceeded), DurationMS: duration(2000)},
+		&types.Execution{ExecutionID: "slow-4", AgentNodeID: "agent-2", ReasonerID: "slow", Status: string(types.ExecutionStatusRunning)},
+	)
+
+	handler := &ExecutionHandler{store: newTestExecutionRecordStore(executions...)}
+	router := gin.New()
+	router.GET("/api/ui/v1/executions/stats", handler.GetExecutionStatsHandler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/stats", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var stats ExecutionStatsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	require.Equal(t, 14, stats.TotalExecutions)
+	require.False(t, stats.Truncated)
+	require.Len(t, stats.ExecutionsByReasoner, 2)
+
+	require.Equal(t, ReasonerExecutionStats{
+		TotalExecutions: 10,
+		SuccessfulCount: 10,
+		SuccessRate:     1,
+		P50DurationMS:   50,
+		P95DurationMS:   100,
+	}, stats.ExecutionsByReasoner["fast"])
+
+	slow := stats.ExecutionsByReasoner["slow"]
+	require.Equal(t, 4, slow.TotalExecutions)
+	require.Equal(t, 2, slow.SuccessfulCount)
+	require.Equal(t, 1, slow.FailedCount)
+	require.InDelta(t, 2.0/3.0, slow.SuccessRate, 1e-9)
+	require.Equal(t, int64(2000), slow.P50DurationMS)
+	require.Equal(t, int64(3000), slow.P95DurationMS)
+	require.Equal(t, map[string]int{types.ExecutionErrorCategoryTimeout: 1}, stats.ExecutionsByErrorCategory)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/stats?start_time=yesterday", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetExecutionStatsHandlerGroupsByTimeBucket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	duration := func(ms int64) *int64 { return &ms }
+	execution := func(id, reasoner string, offset time.Duration, status types.ExecutionStatus, ms int64) *types.Execution {
+		return &types.Execution{
+			ExecutionID: id,
+			AgentNodeID: "agent-1",
+			ReasonerID:  reasoner,
+			Status:      string(status),
+			StartedAt:   base.Add(offset),
+			DurationMS:  duration(ms),
+		}
+	}
+	executions := []*types.Execution{
+		execution("a-1", "alpha", 5*time.Minute, types.ExecutionStatusSucceeded, 100),
+		execution("a-2", "alpha", 50*time.Minute, types.ExecutionStatusFailed, 300),
+		execution("b-1", "beta", 20*time.Minute, types.ExecutionStatusSucceeded, 40),
+		execution("a-3", "alpha", 70*time.Minute, types.ExecutionStatusSucceeded, 200),
+		// 11:00-12:00 has no executions and is omitted.
+		execution("b-2", "beta", 2*time.Hour+10*time.Minute, types.ExecutionStatusSucceeded, 60),
+		execution("b-3", "beta", 2*time.Hour+40*time.Minute, types.ExecutionStatusFailed, 80),
+	}
+
+	handler := &ExecutionHandler{store: newTestExecutionRecordStore(executions...)}
+	router := gin.New()
+	router.GET("/api/ui/v1/executions/stats", handler.GetExecutionStatsHandler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/stats?bucket=1h", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var stats ExecutionStatsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	require.Equal(t, 6, stats.TotalExecutions)
+	require.Len(t, stats.Buckets, 3)
+
+	first := stats.Buckets[0]
+	require.True(t, base.Equal(first.Start))
+	require.Equal(t, 3, first.TotalExecutions)
+	require.Len(t, first.ExecutionsByReasoner, 2)
+	require.Equal(t, ReasonerExecutionStats{
+		TotalExecutions: 2,
+		SuccessfulCount: 1,
+		FailedCount:     1,
+		SuccessRate:     0.5,
+		P50DurationMS:   100,
+		P95DurationMS:   300,
+	}, first.ExecutionsByReasoner["alpha"])
+	require.Equal(t, 1, first.ExecutionsByReasoner["beta"].TotalExecutions)
+
+	second := stats.Buckets[1]
+	require.True(t, base.Add(time.Hour).Equal(second.Start))
+	require.Equal(t, 1, second.TotalExecutions)
+	require.Equal(t, int64(200), second.ExecutionsByReasoner["alpha"].P50DurationMS)
+
+	third := stats.Buckets[2]
+	require.True(t, base.Add(2*time.Hour).Equal(third.Start))
+	require.Equal(t, 2, third.TotalExecutions)
+	require.Equal(t, ReasonerExecutionStats{
+		TotalExecutions: 2,
+		SuccessfulCount: 1,
+		FailedCount:     1,
+		SuccessRate:     0.5,
+		P50DurationMS:   60,
+		P95DurationMS:   80,
+	}, third.ExecutionsByReasoner["beta"])
+
+	// Without a bucket the response carries no buckets; invalid sizes are rejected.
+	req = httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/stats", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), `"buckets"`)
+
+	for _, bucket := range []string{"hourly", "10s", "-1h"} {
+		req = httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/stats?bucket="+bucket, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, bucket)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Agent-Field/agentfield/control-plane/internal/core/domain"
 	"github.com/Agent-Field/agentfield/control-plane/internal/core/interfaces"
+	"github.com/Agent-Field/agentfield/control-plane/internal/handlers"
 	"github.com/Agent-Field/agentfield/control-plane/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -260,9 +261,6 @@ func (h *MCPHandler) GetMCPEventsHandler(c *gin.Context) {
 	c.Header("Access-Control-Allow-Origin", "*")
 	c.Header("Access-Control-Allow-Headers", "Cache-Control")
 
-	// Get the response writer
-	w := c.Writer
-
 	// Send initial connection event
 	initialEvent := map[string]interface{}{
 		"type":      "connection",
@@ -272,8 +270,9 @@ func (h *MCPHandler) GetMCPEventsHandler(c *gin.Context) {
 	}
 
 	// Write SSE formatted data
-	c.SSEvent("mcp-event", initialEvent)
-	w.Flush()
+	if !handlers.WriteSSEvent(c, "mcp-event", initialEvent) {
+		return
+	}
 
 	// Keep connection alive with periodic heartbeat
 	ticker := time.NewTicker(30 * time.Second)
@@ -294,8 +293,9 @@ func (h *MCPHandler) GetMCPEventsHandler(c *gin.Context) {
 				"node_id":   nodeID,
 				"timestamp": time.Now().Format(time.RFC3339),
 			}
-			c.SSEvent("heartbeat", heartbeat)
-			w.Flush()
+			if !handlers.WriteSSEvent(c, "heartbeat", heartbeat) {
+				return
+			}
 		}
 	}
 }
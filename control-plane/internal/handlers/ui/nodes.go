@@ -2,6 +2,7 @@ package ui
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/Agent-Field/agentfield/control-plane/internal/events"
 	"github.com/Agent-Field/agentfield/control-plane/internal/logger"
 	"github.com/Agent-Field/agentfield/control-plane/internal/services"
+	"github.com/Agent-Field/agentfield/control-plane/internal/storage"
 
 	"github.com/gin-gonic/gin"
 )
@@ -177,6 +179,41 @@ func (h *NodesHandler) RefreshNodeStatusHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, status)
 }
 
+// RefreshAgentHandler forces a live health check for one agent and returns the
+// freshly-checked status
+// POST /api/ui/v1/agents/:agentId/refresh
+func (h *NodesHandler) RefreshAgentHandler(c *gin.Context) {
+	agentID := c.Param("agentId")
+	if agentID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "agentId is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.service.GetNodeDetails(ctx, agentID); err != nil {
+		if errors.Is(err, storage.ErrAgentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "agent not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get agent"})
+		return
+	}
+
+	if err := h.service.RefreshNodeStatus(ctx, agentID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to refresh agent status"})
+		return
+	}
+
+	// The refresh cached the live result; read it back rather than checking again
+	status, err := h.service.GetNodeStatusSnapshot(ctx, agentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get refreshed agent status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
 // BulkNodeStatusHandler handles requests for bulk status operations
 // POST /api/ui/v1/nodes/status/bulk
 func (h *NodesHandler) BulkNodeStatusHandler(c *gin.Context) {
@@ -199,6 +236,23 @@ func (h *NodesHandler) BulkNodeStatusHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"statuses": statuses})
 }
 
+// GetAllAgentStatusHandler handles requests for every agent's current status. It serves
+// StatusManager snapshots, so no live health checks are made.
+// GET /api/ui/v1/agents/status
+func (h *NodesHandler) GetAllAgentStatusHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	statuses, err := h.service.GetAllNodeStatusSnapshots(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get agent statuses"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statuses": statuses,
+		"count":    len(statuses),
+	})
+}
+
 // RefreshAllNodeStatusHandler handles requests for refreshing all node statuses
 // POST /api/ui/v1/nodes/status/refresh
 func (h *NodesHandler) RefreshAllNodeStatusHandler(c *gin.Context) {
@@ -1,9 +1,12 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/Agent-Field/agentfield/control-plane/internal/services"
@@ -58,7 +61,8 @@ func (h *ObservabilityWebhookHandler) GetWebhookHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// SetWebhookHandler creates or updates the observability webhook configuration.
+// SetWebhookHandler creates or updates the observability webhook configuration. With
+// ?validate=true a test event is delivered first and the config is saved only on success.
 // POST /api/v1/settings/observability-webhook
 func (h *ObservabilityWebhookHandler) SetWebhookHandler(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -75,10 +79,18 @@ func (h *ObservabilityWebhookHandler) SetWebhookHandler(c *gin.Context) {
 		return
 	}
 
-	parsedURL, err := url.Parse(req.URL)
-	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid url: must be http or https"})
-		return
+	// Templated URLs must parse both as stored (the fallback for mixed batches)
+	// and once their {event_source}/{event_type} placeholders are substituted.
+	sampleURL := services.ResolveObservabilityWebhookURL(req.URL, []types.ObservabilityEvent{{
+		EventType:   "execution_completed",
+		EventSource: "execution",
+	}})
+	for _, candidate := range []string{req.URL, sampleURL} {
+		parsedURL, err := url.Parse(candidate)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid url: must be http or https"})
+			return
+		}
 	}
 
 	// Build config
@@ -112,6 +124,26 @@ func (h *ObservabilityWebhookHandler) SetWebhookHandler(c *gin.Context) {
 		config.CreatedAt = existing.CreatedAt
 	}
 
+	// With ?validate=true, only persist once the endpoint accepts a test delivery
+	if validate, _ := strconv.ParseBool(c.Query("validate")); validate {
+		if h.forwarder == nil {
+			c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "forwarder not available to validate webhook"})
+			return
+		}
+		result := h.forwarder.TestWebhookConfig(ctx, config)
+		if !result.Success {
+			detail := result.Message
+			if result.Error != "" {
+				detail = result.Error
+			}
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":      "webhook validation failed: " + detail,
+				"validation": result,
+			})
+			return
+		}
+	}
+
 	// Store config
 	if err := h.storage.SetObservabilityWebhook(ctx, config); err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to save observability webhook config"})
@@ -187,6 +219,30 @@ func (h *ObservabilityWebhookHandler) GetStatusHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, status)
 }
 
+// PauseHandler stops webhook delivery while keeping the configuration and queued events.
+// POST /api/v1/settings/observability-webhook/pause
+func (h *ObservabilityWebhookHandler) PauseHandler(c *gin.Context) {
+	if h.forwarder == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "forwarder not available"})
+		return
+	}
+
+	h.forwarder.Pause()
+	c.JSON(http.StatusOK, h.forwarder.GetStatus())
+}
+
+// ResumeHandler restarts webhook delivery after a pause.
+// POST /api/v1/settings/observability-webhook/resume
+func (h *ObservabilityWebhookHandler) ResumeHandler(c *gin.Context) {
+	if h.forwarder == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "forwarder not available"})
+		return
+	}
+
+	h.forwarder.Resume()
+	c.JSON(http.StatusOK, h.forwarder.GetStatus())
+}
+
 // RedriveHandler attempts to resend all events in the dead letter queue.
 // POST /api/v1/settings/observability-webhook/redrive
 func (h *ObservabilityWebhookHandler) RedriveHandler(c *gin.Context) {
@@ -208,7 +264,48 @@ func (h *ObservabilityWebhookHandler) RedriveHandler(c *gin.Context) {
 	}
 }
 
-// GetDeadLetterQueueHandler retrieves entries from the dead letter queue.
+// RedriveDeadLetterEntriesHandler attempts to resend only the selected dead letter queue entries.
+// POST /api/v1/settings/observability-webhook/dlq/redrive
+func (h *ObservabilityWebhookHandler) RedriveDeadLetterEntriesHandler(c *gin.Context) {
+	if h.forwarder == nil {
+		c.JSON(http.StatusServiceUnavailable, types.ObservabilityRedriveResponse{
+			Success: false,
+			Message: "forwarder not available",
+		})
+		return
+	}
+
+	var req types.ObservabilityDeadLetterIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request: " + err.Error()})
+		return
+	}
+	if len(req.IDs) == 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "at least one id is required"})
+		return
+	}
+
+	response := h.forwarder.RedriveEntries(c.Request.Context(), req.IDs)
+	c.JSON(http.StatusOK, response) // Still 200 as the operation completed, just with failures
+}
+
+// TestWebhookHandler sends a synthetic event to the configured webhook immediately.
+// POST /api/v1/settings/observability-webhook/test
+func (h *ObservabilityWebhookHandler) TestWebhookHandler(c *gin.Context) {
+	if h.forwarder == nil {
+		c.JSON(http.StatusServiceUnavailable, types.ObservabilityWebhookTestResponse{
+			Success: false,
+			Message: "forwarder not available",
+		})
+		return
+	}
+
+	response := h.forwarder.TestWebhook(c.Request.Context())
+	c.JSON(http.StatusOK, response) // 200 even on delivery failure; the outcome is in the body
+}
+
+// GetDeadLetterQueueHandler retrieves entries from the dead letter queue, optionally
+// filtered by the event_type and event_source query params.
 // GET /api/v1/settings/observability-webhook/dlq
 func (h *ObservabilityWebhookHandler) GetDeadLetterQueueHandler(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -227,15 +324,14 @@ func (h *ObservabilityWebhookHandler) GetDeadLetterQueueHandler(c *gin.Context)
 		}
 	}
 
-	entries, err := h.storage.GetDeadLetterQueue(ctx, limit, offset)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to get dead letter queue"})
-		return
+	filter := types.ObservabilityDeadLetterFilter{
+		EventType:   c.Query("event_type"),
+		EventSource: c.Query("event_source"),
 	}
 
-	count, err := h.storage.GetDeadLetterQueueCount(ctx)
+	entries, count, err := h.storage.GetDeadLetterQueueFiltered(ctx, filter, limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to get dead letter queue count"})
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to get dead letter queue"})
 		return
 	}
 
@@ -254,6 +350,9 @@ func (h *ObservabilityWebhookHandler) ClearDeadLetterQueueHandler(c *gin.Context
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to clear dead letter queue"})
 		return
 	}
+	if h.forwarder != nil {
+		h.forwarder.CheckDeadLetterThreshold(ctx)
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -261,6 +360,49 @@ func (h *ObservabilityWebhookHandler) ClearDeadLetterQueueHandler(c *gin.Context
 	})
 }
 
+// DeleteDeadLetterEntriesHandler removes the selected entries from the dead letter queue.
+// DELETE /api/v1/settings/observability-webhook/dlq/entries
+func (h *ObservabilityWebhookHandler) DeleteDeadLetterEntriesHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req types.ObservabilityDeadLetterIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request: " + err.Error()})
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": "no entries deleted",
+			"deleted": 0,
+		})
+		return
+	}
+
+	for _, id := range req.IDs {
+		if id <= 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("invalid id: %d", id)})
+			return
+		}
+	}
+
+	deleted, err := h.storage.DeleteFromDeadLetterQueue(ctx, req.IDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to delete dead letter queue entries"})
+		return
+	}
+	if h.forwarder != nil {
+		h.forwarder.CheckDeadLetterThreshold(ctx)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": fmt.Sprintf("deleted %d entries", deleted),
+		"deleted": deleted,
+	})
+}
+
 func parseIntParam(s string) (int, error) {
 	var n int
 	_, err := fmt.Sscanf(s, "%d", &n)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
@@ -11,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Agent-Field/agentfield/control-plane/internal/services"
 	"github.com/Agent-Field/agentfield/control-plane/internal/storage"
 	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
 	"github.com/gin-gonic/gin"
@@ -22,6 +24,10 @@ type mockForwarder struct {
 	status      types.ObservabilityForwarderStatus
 	reloadErr   error
 	redriveResp types.ObservabilityRedriveResponse
+	testResp    types.ObservabilityWebhookTestResponse
+	redriveIDs  []int64
+
+	thresholdChecks int
 }
 
 func (m *mockForwarder) Start(ctx context.Context) error {
@@ -40,10 +46,43 @@ func (m *mockForwarder) GetStatus() types.ObservabilityForwarderStatus {
 	return m.status
 }
 
+func (m *mockForwarder) Metrics() types.ObservabilityForwarderMetrics {
+	return types.ObservabilityForwarderMetrics{}
+}
+
 func (m *mockForwarder) Redrive(ctx context.Context) types.ObservabilityRedriveResponse {
 	return m.redriveResp
 }
 
+func (m *mockForwarder) RedriveEntries(ctx context.Context, ids []int64) types.ObservabilityRedriveResponse {
+	m.redriveIDs = ids
+	return m.redriveResp
+}
+
+func (m *mockForwarder) TestWebhook(ctx context.Context) types.ObservabilityWebhookTestResponse {
+	return m.testResp
+}
+
+func (m *mockForwarder) TestWebhookConfig(ctx context.Context, cfg *types.ObservabilityWebhookConfig) types.ObservabilityWebhookTestResponse {
+	return m.testResp
+}
+
+func (m *mockForwarder) Healthy() bool {
+	return m.status.Healthy
+}
+
+func (m *mockForwarder) Pause() {
+	m.status.Paused = true
+}
+
+func (m *mockForwarder) Resume() {
+	m.status.Paused = false
+}
+
+func (m *mockForwarder) CheckDeadLetterThreshold(ctx context.Context) {
+	m.thresholdChecks++
+}
+
 // setupTestEnvironment creates test storage and handler for observability webhook tests.
 func setupTestEnvironment(t *testing.T) (*storage.LocalStorage, *mockForwarder, *ObservabilityWebhookHandler, *gin.Engine) {
 	t.Helper()
@@ -90,9 +129,13 @@ func setupTestEnvironment(t *testing.T) (*storage.LocalStorage, *mockForwarder,
 	router.POST("/api/v1/settings/observability-webhook", handler.SetWebhookHandler)
 	router.DELETE("/api/v1/settings/observability-webhook", handler.DeleteWebhookHandler)
 	router.GET("/api/v1/settings/observability-webhook/status", handler.GetStatusHandler)
+	router.POST("/api/v1/settings/observability-webhook/pause", handler.PauseHandler)
+	router.POST("/api/v1/settings/observability-webhook/resume", handler.ResumeHandler)
 	router.POST("/api/v1/settings/observability-webhook/redrive", handler.RedriveHandler)
 	router.GET("/api/v1/settings/observability-webhook/dlq", handler.GetDeadLetterQueueHandler)
 	router.DELETE("/api/v1/settings/observability-webhook/dlq", handler.ClearDeadLetterQueueHandler)
+	router.POST("/api/v1/settings/observability-webhook/dlq/redrive", handler.RedriveDeadLetterEntriesHandler)
+	router.DELETE("/api/v1/settings/observability-webhook/dlq/entries", handler.DeleteDeadLetterEntriesHandler)
 
 	return realStorage, mockFwd, handler, router
 }
@@ -175,6 +218,54 @@ func TestSetWebhookHandler_Create(t *testing.T) {
 	require.Contains(t, result["message"].(string), "configured successfully")
 }
 
+// Test POST /api/v1/settings/observability-webhook?validate=true
+func TestSetWebhookHandler_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantCode  int
+		wantSaved bool
+	}{
+		{name: "endpoint accepts", status: http.StatusOK, wantCode: http.StatusOK, wantSaved: true},
+		{name: "endpoint rejects", status: http.StatusNotFound, wantCode: http.StatusBadRequest, wantSaved: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, _, _, _ := setupTestEnvironment(t)
+
+			webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer webhook.Close()
+
+			forwarder := services.NewObservabilityForwarder(store, services.ObservabilityForwarderConfig{})
+			handler := NewObservabilityWebhookHandler(store, forwarder)
+			router := gin.New()
+			router.POST("/api/v1/settings/observability-webhook", handler.SetWebhookHandler)
+
+			body, _ := json.Marshal(types.ObservabilityWebhookConfigRequest{URL: webhook.URL})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/settings/observability-webhook?validate=true", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			require.Equal(t, tt.wantCode, resp.Code)
+
+			saved, err := store.GetObservabilityWebhook(context.Background())
+			require.NoError(t, err)
+			if !tt.wantSaved {
+				require.Nil(t, saved)
+				require.Contains(t, resp.Body.String(), "webhook validation failed")
+				require.Contains(t, resp.Body.String(), "404")
+				return
+			}
+			require.NotNil(t, saved)
+			require.Equal(t, webhook.URL, saved.URL)
+		})
+	}
+}
+
 // Test POST /api/v1/settings/observability-webhook - missing URL
 func TestSetWebhookHandler_MissingURL(t *testing.T) {
 	_, _, _, router := setupTestEnvironment(t)
@@ -236,6 +327,24 @@ func TestSetWebhookHandler_NonHTTPURL(t *testing.T) {
 	require.Contains(t, strings.ToLower(result.Error), "http")
 }
 
+// Test POST /api/v1/settings/observability-webhook - templated URLs
+func TestSetWebhookHandler_TemplatedURL(t *testing.T) {
+	_, _, _, router := setupTestEnvironment(t)
+
+	for url, want := range map[string]int{
+		"https://ingest.example.com/{event_source}/{event_type}": http.StatusOK,
+		"https://{event_source}.example.com/hook":                http.StatusBadRequest,
+	} {
+		body, _ := json.Marshal(map[string]interface{}{"url": url})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/settings/observability-webhook", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		require.Equal(t, want, resp.Code, url)
+	}
+}
+
 // Test POST /api/v1/settings/observability-webhook - defaults enabled to true
 func TestSetWebhookHandler_DefaultsEnabled(t *testing.T) {
 	store, _, _, router := setupTestEnvironment(t)
@@ -367,6 +476,30 @@ func TestGetStatusHandler_NoForwarder(t *testing.T) {
 	require.False(t, result.Enabled)
 }
 
+// Test POST /api/v1/settings/observability-webhook/pause and /resume
+func TestPauseResumeHandlers(t *testing.T) {
+	_, mockFwd, _, router := setupTestEnvironment(t)
+
+	post := func(path string) types.ObservabilityForwarderStatus {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var status types.ObservabilityForwarderStatus
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &status))
+		return status
+	}
+
+	status := post("/api/v1/settings/observability-webhook/pause")
+	require.True(t, status.Paused)
+	require.True(t, mockFwd.status.Paused)
+
+	status = post("/api/v1/settings/observability-webhook/resume")
+	require.False(t, status.Paused)
+	require.False(t, mockFwd.status.Paused)
+}
+
 // Test POST /api/v1/settings/observability-webhook/redrive - success
 func TestRedriveHandler_Success(t *testing.T) {
 	_, mockFwd, _, router := setupTestEnvironment(t)
@@ -450,6 +583,110 @@ func TestRedriveHandler_NoForwarder(t *testing.T) {
 	require.Equal(t, http.StatusServiceUnavailable, resp.Code)
 }
 
+// Test POST /api/v1/settings/observability-webhook/dlq/redrive - selected entries
+func TestRedriveDeadLetterEntriesHandler(t *testing.T) {
+	_, mockFwd, _, router := setupTestEnvironment(t)
+
+	mockFwd.redriveResp = types.ObservabilityRedriveResponse{
+		Success:   true,
+		Message:   "redrove 2 events",
+		Processed: 2,
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/settings/observability-webhook/dlq/redrive", strings.NewReader(`{"ids":[3,7]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, []int64{3, 7}, mockFwd.redriveIDs)
+
+	var result types.ObservabilityRedriveResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	require.True(t, result.Success)
+	require.Equal(t, 2, result.Processed)
+
+	// Missing IDs are rejected
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/settings/observability-webhook/dlq/redrive", strings.NewReader(`{"ids":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+// Test POST /api/v1/settings/observability-webhook/test - success and failure
+func TestTestWebhookHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		wantSuccess bool
+		wantError   string
+	}{
+		{name: "success", status: http.StatusOK, wantSuccess: true},
+		{name: "failure", status: http.StatusBadGateway, wantSuccess: false, wantError: "non-2xx response: 502"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, _, _, _ := setupTestEnvironment(t)
+
+			var received types.ObservabilityEventBatch
+			webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&received)
+				w.WriteHeader(tt.status)
+			}))
+			defer webhook.Close()
+
+			require.NoError(t, store.SetObservabilityWebhook(context.Background(), &types.ObservabilityWebhookConfig{
+				ID:      "global",
+				URL:     webhook.URL,
+				Enabled: true,
+			}))
+
+			forwarder := services.NewObservabilityForwarder(store, services.ObservabilityForwarderConfig{})
+			handler := NewObservabilityWebhookHandler(store, forwarder)
+			router := gin.New()
+			router.POST("/api/v1/settings/observability-webhook/test", handler.TestWebhookHandler)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/settings/observability-webhook/test", nil)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			require.Equal(t, http.StatusOK, resp.Code)
+
+			var result types.ObservabilityWebhookTestResponse
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+			require.Equal(t, tt.wantSuccess, result.Success)
+			require.Equal(t, tt.status, result.StatusCode)
+			require.GreaterOrEqual(t, result.LatencyMS, int64(0))
+			require.Equal(t, tt.wantError, result.Error)
+			require.Equal(t, 1, received.EventCount)
+		})
+	}
+}
+
+// Test POST /api/v1/settings/observability-webhook/test - not configured
+func TestTestWebhookHandler_NotConfigured(t *testing.T) {
+	store, _, _, _ := setupTestEnvironment(t)
+
+	forwarder := services.NewObservabilityForwarder(store, services.ObservabilityForwarderConfig{})
+	handler := NewObservabilityWebhookHandler(store, forwarder)
+	router := gin.New()
+	router.POST("/api/v1/settings/observability-webhook/test", handler.TestWebhookHandler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/settings/observability-webhook/test", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var result types.ObservabilityWebhookTestResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	require.False(t, result.Success)
+	require.Equal(t, "webhook not configured", result.Message)
+}
+
 // Test GET /api/v1/settings/observability-webhook/dlq
 func TestGetDeadLetterQueueHandler(t *testing.T) {
 	store, _, _, router := setupTestEnvironment(t)
@@ -479,6 +716,54 @@ func TestGetDeadLetterQueueHandler(t *testing.T) {
 	require.Len(t, result.Entries, 5)
 }
 
+// Test GET /api/v1/settings/observability-webhook/dlq - with filters
+func TestGetDeadLetterQueueHandler_Filtered(t *testing.T) {
+	store, _, _, router := setupTestEnvironment(t)
+
+	// Add mixed DLQ entries
+	for i := 0; i < 6; i++ {
+		eventType, eventSource := "execution_failed", "execution"
+		if i%2 == 1 {
+			eventType, eventSource = "node_offline", "node"
+		}
+		event := &types.ObservabilityEvent{
+			EventType:   eventType,
+			EventSource: eventSource,
+			Timestamp:   time.Now().UTC().Format(time.RFC3339),
+			Data:        map[string]interface{}{"index": i},
+		}
+		err := store.AddToDeadLetterQueue(context.Background(), event, "webhook unavailable", 3)
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/settings/observability-webhook/dlq?event_type=node_offline&limit=2", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var result types.ObservabilityDeadLetterListResponse
+	err := json.Unmarshal(resp.Body.Bytes(), &result)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.TotalCount)
+	require.Len(t, result.Entries, 2)
+	for _, entry := range result.Entries {
+		require.Equal(t, "node_offline", entry.EventType)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/settings/observability-webhook/dlq?event_source=execution&event_type=node_offline", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var empty types.ObservabilityDeadLetterListResponse
+	err = json.Unmarshal(resp.Body.Bytes(), &empty)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), empty.TotalCount)
+	require.Empty(t, empty.Entries)
+}
+
 // Test GET /api/v1/settings/observability-webhook/dlq - with pagination
 func TestGetDeadLetterQueueHandler_Pagination(t *testing.T) {
 	store, _, _, router := setupTestEnvironment(t)
@@ -577,7 +862,7 @@ func TestGetDeadLetterQueueHandler_LimitCap(t *testing.T) {
 
 // Test DELETE /api/v1/settings/observability-webhook/dlq
 func TestClearDeadLetterQueueHandler(t *testing.T) {
-	store, _, _, router := setupTestEnvironment(t)
+	store, forwarder, _, router := setupTestEnvironment(t)
 
 	// Add DLQ entries
 	for i := 0; i < 5; i++ {
@@ -612,6 +897,80 @@ func TestClearDeadLetterQueueHandler(t *testing.T) {
 	count, err = store.GetDeadLetterQueueCount(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, int64(0), count)
+	require.Equal(t, 1, forwarder.thresholdChecks)
+}
+
+// Test DELETE /api/v1/settings/observability-webhook/dlq/entries - subset
+func TestDeleteDeadLetterEntriesHandler(t *testing.T) {
+	store, forwarder, _, router := setupTestEnvironment(t)
+
+	// Add DLQ entries
+	for i := 0; i < 5; i++ {
+		event := &types.ObservabilityEvent{
+			EventType:   "test_event",
+			EventSource: "test",
+			Timestamp:   time.Now().UTC().Format(time.RFC3339),
+			Data:        map[string]interface{}{"index": i},
+		}
+		err := store.AddToDeadLetterQueue(context.Background(), event, "test error", 3)
+		require.NoError(t, err)
+	}
+
+	entries, err := store.GetDeadLetterQueue(context.Background(), 10, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+
+	body := fmt.Sprintf(`{"ids":[%d,%d]}`, entries[0].ID, entries[2].ID)
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/settings/observability-webhook/dlq/entries", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var result map[string]interface{}
+	err = json.Unmarshal(resp.Body.Bytes(), &result)
+	require.NoError(t, err)
+	require.Equal(t, true, result["success"])
+	require.Equal(t, float64(2), result["deleted"])
+	require.Equal(t, 1, forwarder.thresholdChecks)
+
+	// Verify only the selected entries were removed
+	count, err := store.GetDeadLetterQueueCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	remaining, err := store.GetDeadLetterQueue(context.Background(), 10, 0)
+	require.NoError(t, err)
+	for _, entry := range remaining {
+		require.NotEqual(t, entries[0].ID, entry.ID)
+		require.NotEqual(t, entries[2].ID, entry.ID)
+	}
+}
+
+// Test DELETE /api/v1/settings/observability-webhook/dlq/entries - empty and invalid IDs
+func TestDeleteDeadLetterEntriesHandler_Validation(t *testing.T) {
+	_, _, _, router := setupTestEnvironment(t)
+
+	for _, body := range []string{"", `{}`, `{"ids":[]}`} {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/settings/observability-webhook/dlq/entries", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusOK, resp.Code, "body %q", body)
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+		require.Equal(t, float64(0), result["deleted"])
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/settings/observability-webhook/dlq/entries", strings.NewReader(`{"ids":[1,-2]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusBadRequest, resp.Code)
 }
 
 // Test DELETE /api/v1/settings/observability-webhook/dlq - already empty
@@ -1,10 +1,14 @@
 package ui
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -12,6 +16,7 @@ import (
 	"github.com/Agent-Field/agentfield/control-plane/internal/storage"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -98,6 +103,82 @@ func TestStreamExecutionEventsHandler(t *testing.T) {
 	// Real storage doesn't need expectations
 }
 
+// TestStreamExecutionEventsHandler_FiltersByAgent verifies agent_id filtering happens server-side.
+func TestStreamExecutionEventsHandler_FiltersByAgent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	realStorage := setupTestStorage(t)
+	eventBus := realStorage.GetExecutionEventBus()
+
+	handler := NewExecutionHandler(realStorage, nil, nil)
+	router := gin.New()
+	router.GET("/api/ui/v1/executions/events", handler.StreamExecutionEventsHandler)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/ui/v1/executions/events?agent_id=agent-1", nil)
+	require.NoError(t, err)
+
+	// The stream only sends headers with its first event, so connect in the background.
+	received := make(chan events.ExecutionEvent, 8)
+	go func() {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			var event events.ExecutionEvent
+			if json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event) == nil {
+				received <- event
+			}
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		return eventBus.GetSubscriberCount() == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	for _, event := range []events.ExecutionEvent{
+		{Type: events.ExecutionCreated, ExecutionID: "exec-other-1", WorkflowID: "wf-2", AgentNodeID: "agent-2", Timestamp: time.Now()},
+		{Type: events.ExecutionCreated, ExecutionID: "exec-mine", WorkflowID: "wf-1", AgentNodeID: "agent-1", Timestamp: time.Now()},
+		{Type: events.ExecutionCreated, ExecutionID: "exec-other-2", WorkflowID: "wf-2", AgentNodeID: "agent-2", Timestamp: time.Now()},
+	} {
+		eventBus.Publish(event)
+	}
+
+	select {
+	case event := <-received:
+		assert.Equal(t, "exec-mine", event.ExecutionID)
+		assert.Equal(t, "agent-1", event.AgentNodeID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for filtered event")
+	}
+
+	select {
+	case event := <-received:
+		t.Fatalf("unexpected event for another agent: %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestExecutionEventFilterMatches(t *testing.T) {
+	event := events.ExecutionEvent{AgentNodeID: "agent-1", WorkflowID: "wf-1"}
+
+	assert.True(t, executionEventFilter{}.matches(event))
+	assert.True(t, executionEventFilter{agentID: "agent-1"}.matches(event))
+	assert.True(t, executionEventFilter{agentID: "agent-1", workflowID: "wf-1"}.matches(event))
+	assert.False(t, executionEventFilter{agentID: "agent-2"}.matches(event))
+	assert.False(t, executionEventFilter{agentID: "agent-1", workflowID: "wf-2"}.matches(event))
+}
+
 // TestStreamExecutionEventsHandler_Headers tests that SSE headers are set correctly
 func TestStreamExecutionEventsHandler_Headers(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -620,3 +701,175 @@ func TestSSEPerformance(t *testing.T) {
 	case <-time.After(100 * time.Millisecond):
 	}
 }
+
+// eventBusOnlyStorage exposes just an execution event bus for streaming handlers.
+type eventBusOnlyStorage struct {
+	storage.StorageProvider
+	bus *events.ExecutionEventBus
+}
+
+func (s *eventBusOnlyStorage) GetExecutionEventBus() *events.ExecutionEventBus {
+	return s.bus
+}
+
+// TestStreamExecutionEventsWebSocketHandler tests that published execution events reach WebSocket clients
+func TestStreamExecutionEventsWebSocketHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	eventBus := events.NewExecutionEventBus()
+	handler := NewExecutionHandler(&eventBusOnlyStorage{bus: eventBus}, nil, nil)
+	router := gin.New()
+	router.GET("/api/ui/v1/executions/ws", handler.StreamExecutionEventsWebSocketHandler)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/ui/v1/executions/ws"
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	defer conn.Close()
+
+	// Wait for subscription
+	require.Eventually(t, func() bool {
+		return eventBus.GetSubscriberCount() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	eventBus.Publish(events.ExecutionEvent{
+		Type:        events.ExecutionUpdated,
+		ExecutionID: "exec-ws-1",
+		WorkflowID:  "workflow-1",
+		AgentNodeID: "agent-1",
+		Status:      "running",
+		Timestamp:   time.Now(),
+	})
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var received events.ExecutionEvent
+	require.NoError(t, conn.ReadJSON(&received))
+	assert.Equal(t, events.ExecutionUpdated, received.Type)
+	assert.Equal(t, "exec-ws-1", received.ExecutionID)
+	assert.Equal(t, "running", received.Status)
+
+	// Closing the client unsubscribes the handler
+	require.NoError(t, conn.Close())
+	require.Eventually(t, func() bool {
+		return eventBus.GetSubscriberCount() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+// closedStreamWriter models a client whose connection has gone away without the request
+// context being cancelled: once closed, writes or flushes fail.
+type closedStreamWriter struct {
+	*httptest.ResponseRecorder
+	closed     atomic.Bool
+	failWrites bool
+}
+
+func (w *closedStreamWriter) Write(p []byte) (int, error) {
+	if w.closed.Load() && w.failWrites {
+		return 0, errors.New("write: broken pipe")
+	}
+	return w.ResponseRecorder.Write(p)
+}
+
+func (w *closedStreamWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *closedStreamWriter) FlushError() error {
+	if w.closed.Load() {
+		return errors.New("flush: connection reset by peer")
+	}
+	w.ResponseRecorder.Flush()
+	return nil
+}
+
+// TestStreamExecutionEventsHandler_UnsubscribesOnWriteFailure tests that a failed write or
+// flush ends the stream immediately instead of waiting for a heartbeat or cancellation
+func TestStreamExecutionEventsHandler_UnsubscribesOnWriteFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, tc := range []struct {
+		name       string
+		failWrites bool
+	}{
+		{name: "write fails", failWrites: true},
+		{name: "flush fails", failWrites: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			eventBus := events.NewExecutionEventBus()
+			handler := NewExecutionHandler(&eventBusOnlyStorage{bus: eventBus}, nil, nil)
+			router := gin.New()
+			router.GET("/api/ui/v1/executions/events", handler.StreamExecutionEventsHandler)
+
+			writer := &closedStreamWriter{ResponseRecorder: httptest.NewRecorder(), failWrites: tc.failWrites}
+			req := httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/events", nil)
+
+			done := make(chan struct{})
+			go func() {
+				router.ServeHTTP(writer, req)
+				close(done)
+			}()
+
+			require.Eventually(t, func() bool {
+				return eventBus.GetSubscriberCount() == 1
+			}, time.Second, 10*time.Millisecond)
+
+			writer.closed.Store(true)
+			eventBus.Publish(events.ExecutionEvent{
+				Type:        events.ExecutionUpdated,
+				ExecutionID: "exec-gone",
+				Timestamp:   time.Now(),
+			})
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("handler kept streaming to a closed client")
+			}
+			assert.Equal(t, 0, eventBus.GetSubscriberCount())
+		})
+	}
+}
+
+// TestExecutionEventStreams_RejectPastSubscriberLimit tests that the SSE and WebSocket
+// handlers answer 503 once the event bus is at its subscriber limit
+func TestExecutionEventStreams_RejectPastSubscriberLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	eventBus := events.NewExecutionEventBus()
+	eventBus.SetMaxSubscribers(1)
+	handler := NewExecutionHandler(&eventBusOnlyStorage{bus: eventBus}, nil, nil)
+	router := gin.New()
+	router.GET("/api/ui/v1/executions/events", handler.StreamExecutionEventsHandler)
+	router.GET("/api/ui/v1/executions/ws", handler.StreamExecutionEventsWebSocketHandler)
+	router.GET("/api/ui/v1/workflows/:workflowId/notes/events", handler.StreamWorkflowNodeNotesHandler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first := httptest.NewRequest(http.MethodGet, "/api/ui/v1/executions/events", nil).WithContext(ctx)
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(httptest.NewRecorder(), first)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		return eventBus.GetSubscriberCount() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	for _, path := range []string{
+		"/api/ui/v1/executions/events",
+		"/api/ui/v1/executions/ws",
+		"/api/ui/v1/workflows/wf-1/notes/events",
+	} {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code, path)
+		assert.Contains(t, resp.Body.String(), events.ErrTooManySubscribers.Error(), path)
+	}
+
+	cancel()
+	<-done
+	assert.Equal(t, 0, eventBus.GetSubscriberCount())
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Agent-Field/agentfield/control-plane/internal/events"
+	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWorkflowNoteLength caps the size of a single note's text.
+const maxWorkflowNoteLength = 4096
+
+// validWorkflowNoteLevels lists the accepted note levels; an empty level defaults to "info".
+var validWorkflowNoteLevels = map[string]bool{
+	"debug":   true,
+	"info":    true,
+	"warning": true,
+	"error":   true,
+}
+
+// CreateWorkflowNoteRequest is the body accepted when attaching a note to a workflow node.
+type CreateWorkflowNoteRequest struct {
+	NodeID string `json:"node_id"`
+	Text   string `json:"text"`
+	Level  string `json:"level"`
+}
+
+// CreateWorkflowNoteResponse echoes the stored note along with where it was attached.
+type CreateWorkflowNoteResponse struct {
+	WorkflowID string              `json:"workflow_id"`
+	NodeID     string              `json:"node_id"`
+	Note       types.ExecutionNote `json:"note"`
+}
+
+// CreateWorkflowNoteHandler stores a note on a workflow node and publishes it to the
+// execution event bus so clients streaming workflow notes receive it live.
+// POST /api/ui/v1/workflows/:workflowId/notes
+func (h *ExecutionHandler) CreateWorkflowNoteHandler(c *gin.Context) {
+	workflowID := strings.TrimSpace(c.Param("workflowId"))
+	if workflowID == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "workflowId is required"})
+		return
+	}
+
+	var req CreateWorkflowNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+		return
+	}
+
+	nodeID := strings.TrimSpace(req.NodeID)
+	text := strings.TrimSpace(req.Text)
+	level := strings.ToLower(strings.TrimSpace(req.Level))
+	if level == "" {
+		level = "info"
+	}
+	switch {
+	case nodeID == "":
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "node_id is required"})
+		return
+	case text == "":
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "text cannot be empty"})
+		return
+	case len(text) > maxWorkflowNoteLength:
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("text exceeds %d bytes", maxWorkflowNoteLength)})
+		return
+	case !validWorkflowNoteLevels[level]:
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("invalid level %q: must be one of debug, info, warning, error", req.Level)})
+		return
+	}
+
+	ctx := c.Request.Context()
+	execution, err := h.storage.GetExecutionRecord(ctx, nodeID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("failed to load node %s: %v", nodeID, err)})
+		return
+	}
+	if execution == nil || execution.RunID != workflowID {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: fmt.Sprintf("node %s not found in workflow %s", nodeID, workflowID)})
+		return
+	}
+
+	note := types.ExecutionNote{
+		Message:   text,
+		Tags:      []string{},
+		Level:     level,
+		Timestamp: time.Now(),
+	}
+	updated, err := h.storage.UpdateExecutionRecord(ctx, nodeID, func(current *types.Execution) (*types.Execution, error) {
+		if current == nil {
+			return nil, fmt.Errorf("execution with ID %s not found", nodeID)
+		}
+		current.Notes = append(current.Notes, note)
+		current.UpdatedAt = time.Now()
+		return current, nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("failed to store note: %v", err)})
+		return
+	}
+
+	h.storage.GetExecutionEventBus().Publish(events.ExecutionEvent{
+		Type:        "workflow_note_added",
+		ExecutionID: nodeID,
+		WorkflowID:  workflowID,
+		AgentNodeID: updated.AgentNodeID,
+		Status:      "note_added",
+		Timestamp:   note.Timestamp,
+		Data: map[string]interface{}{
+			"workflow_id":  workflowID,
+			"execution_id": nodeID,
+			"note":         note,
+			"timestamp":    note.Timestamp.Format(time.RFC3339),
+		},
+	})
+
+	c.JSON(http.StatusCreated, CreateWorkflowNoteResponse{
+		WorkflowID: workflowID,
+		NodeID:     nodeID,
+		Note:       note,
+	})
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWorkflowNoteHandler_StreamsNote(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	realStorage := setupTestStorage(t)
+	ctx := context.Background()
+	require.NoError(t, realStorage.CreateExecutionRecord(ctx, &types.Execution{
+		ExecutionID: "exec-1",
+		RunID:       "run-1",
+		AgentNodeID: "agent-1",
+		ReasonerID:  "reasoner-1",
+		NodeID:      "agent-1",
+		Status:      string(types.ExecutionStatusRunning),
+	}))
+
+	handler := NewExecutionHandler(realStorage, nil, nil)
+	router := gin.New()
+	router.GET("/api/ui/v1/workflows/:workflowId/notes/events", handler.StreamWorkflowNodeNotesHandler)
+	router.POST("/api/ui/v1/workflows/:workflowId/notes", handler.CreateWorkflowNoteHandler)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	streamCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	streamReq, err := http.NewRequestWithContext(streamCtx, http.MethodGet, server.URL+"/api/ui/v1/workflows/run-1/notes/events", nil)
+	require.NoError(t, err)
+	streamResp, err := http.DefaultClient.Do(streamReq)
+	require.NoError(t, err)
+	defer streamResp.Body.Close()
+
+	events := make(chan map[string]interface{}, 8)
+	go func() {
+		scanner := bufio.NewScanner(streamResp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			var event map[string]interface{}
+			if json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event) == nil {
+				events <- event
+			}
+		}
+	}()
+
+	select {
+	case event := <-events:
+		require.Equal(t, "connected", event["type"])
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for stream to connect")
+	}
+
+	body := []byte(`{"node_id":"exec-1","text":"halfway there","level":"warning"}`)
+	resp, err := http.Post(server.URL+"/api/ui/v1/workflows/run-1/notes", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created CreateWorkflowNoteResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	require.Equal(t, "run-1", created.WorkflowID)
+	require.Equal(t, "exec-1", created.NodeID)
+	require.Equal(t, "halfway there", created.Note.Message)
+	require.Equal(t, "warning", created.Note.Level)
+
+	select {
+	case event := <-events:
+		require.Equal(t, "workflow_note_added", event["type"])
+		require.Equal(t, "run-1", event["workflow_id"])
+		data, ok := event["data"].(map[string]interface{})
+		require.True(t, ok)
+		note, ok := data["note"].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, "halfway there", note["message"])
+		require.Equal(t, "warning", note["level"])
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for note on stream")
+	}
+
+	stored, err := realStorage.GetExecutionRecord(ctx, "exec-1")
+	require.NoError(t, err)
+	require.Len(t, stored.Notes, 1)
+	require.Equal(t, "warning", stored.Notes[0].Level)
+}
+
+func TestCreateWorkflowNoteHandler_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	realStorage := setupTestStorage(t)
+	require.NoError(t, realStorage.CreateExecutionRecord(context.Background(), &types.Execution{
+		ExecutionID: "exec-1",
+		RunID:       "run-1",
+		AgentNodeID: "agent-1",
+		ReasonerID:  "reasoner-1",
+		NodeID:      "agent-1",
+		Status:      string(types.ExecutionStatusRunning),
+	}))
+
+	handler := NewExecutionHandler(realStorage, nil, nil)
+	router := gin.New()
+	router.POST("/api/ui/v1/workflows/:workflowId/notes", handler.CreateWorkflowNoteHandler)
+
+	cases := []struct {
+		name     string
+		workflow string
+		body     string
+		status   int
+	}{
+		{"missing node", "run-1", `{"text":"hi"}`, http.StatusBadRequest},
+		{"empty text", "run-1", `{"node_id":"exec-1","text":"  "}`, http.StatusBadRequest},
+		{"bad level", "run-1", `{"node_id":"exec-1","text":"hi","level":"loud"}`, http.StatusBadRequest},
+		{"unknown node", "run-1", `{"node_id":"missing","text":"hi"}`, http.StatusNotFound},
+		{"wrong workflow", "run-2", `{"node_id":"exec-1","text":"hi"}`, http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/ui/v1/workflows/"+tc.workflow+"/notes", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.status, w.Code)
+		})
+	}
+}
@@ -320,6 +320,161 @@ func (h *WorkflowRunHandler) GetWorkflowRunDetailHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, detail)
 }
 
+// WorkflowTreeNode is a single execution within a run's call tree.
+type WorkflowTreeNode struct {
+	ExecutionID       string              `json:"execution_id"`
+	ParentExecutionID *string             `json:"parent_execution_id,omitempty"`
+	AgentNodeID       string              `json:"agent_node_id"`
+	ReasonerID        string              `json:"reasoner_id"`
+	Status            string              `json:"status"`
+	StartedAt         string              `json:"started_at"`
+	CompletedAt       *string             `json:"completed_at,omitempty"`
+	DurationMS        *int64              `json:"duration_ms,omitempty"`
+	Depth             int                 `json:"depth"`
+	Children          []*WorkflowTreeNode `json:"children"`
+}
+
+// WorkflowTreeResponse is the nested call tree for a workflow run.
+type WorkflowTreeResponse struct {
+	RunID      string              `json:"run_id"`
+	TotalNodes int                 `json:"total_nodes"`
+	Roots      []*WorkflowTreeNode `json:"roots"`
+}
+
+// GetWorkflowRunTreeHandler returns the executions of a run as a nested call tree.
+// GET /api/ui/v1/workflows/:workflowId/tree
+func (h *WorkflowRunHandler) GetWorkflowRunTreeHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	runID := strings.TrimSpace(c.Param("run_id"))
+	if runID == "" {
+		runID = strings.TrimSpace(c.Param("workflowId"))
+	}
+	if runID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "run_id is required"})
+		return
+	}
+
+	filter := types.ExecutionFilter{
+		RunID:          &runID,
+		SortBy:         "started_at",
+		SortDescending: false,
+		Limit:          10000,
+	}
+
+	executions, err := h.storage.QueryExecutionRecords(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query executions"})
+		return
+	}
+	if len(executions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "workflow run not found"})
+		return
+	}
+
+	roots, total := buildWorkflowTree(executions)
+	c.JSON(http.StatusOK, WorkflowTreeResponse{
+		RunID:      runID,
+		TotalNodes: total,
+		Roots:      roots,
+	})
+}
+
+// buildWorkflowTree links executions into trees using their parent IDs. Executions whose
+// parent is outside the set become roots, and each execution is placed at most once so
+// parent cycles cannot recurse forever. Siblings are ordered by start time.
+func buildWorkflowTree(executions []*types.Execution) ([]*WorkflowTreeNode, int) {
+	ordered := make([]*types.Execution, 0, len(executions))
+	byID := make(map[string]*types.Execution, len(executions))
+	for _, exec := range executions {
+		if exec == nil || exec.ExecutionID == "" {
+			continue
+		}
+		if _, exists := byID[exec.ExecutionID]; exists {
+			continue
+		}
+		byID[exec.ExecutionID] = exec
+		ordered = append(ordered, exec)
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if !ordered[i].StartedAt.Equal(ordered[j].StartedAt) {
+			return ordered[i].StartedAt.Before(ordered[j].StartedAt)
+		}
+		return ordered[i].ExecutionID < ordered[j].ExecutionID
+	})
+
+	children := make(map[string][]*types.Execution, len(ordered))
+	var rootExecs []*types.Execution
+	for _, exec := range ordered {
+		parentID := ""
+		if exec.ParentExecutionID != nil {
+			parentID = *exec.ParentExecutionID
+		}
+		if _, ok := byID[parentID]; ok && parentID != exec.ExecutionID {
+			children[parentID] = append(children[parentID], exec)
+			continue
+		}
+		rootExecs = append(rootExecs, exec)
+	}
+
+	visited := make(map[string]bool, len(ordered))
+	var build func(exec *types.Execution, depth int) *WorkflowTreeNode
+	build = func(exec *types.Execution, depth int) *WorkflowTreeNode {
+		visited[exec.ExecutionID] = true
+		node := newWorkflowTreeNode(exec, depth)
+		for _, child := range children[exec.ExecutionID] {
+			if visited[child.ExecutionID] {
+				continue
+			}
+			node.Children = append(node.Children, build(child, depth+1))
+		}
+		return node
+	}
+
+	roots := make([]*WorkflowTreeNode, 0, len(rootExecs))
+	for _, exec := range rootExecs {
+		roots = append(roots, build(exec, 0))
+	}
+
+	// Executions only reachable through a cycle have no natural root; surface them
+	// from the earliest one so nothing is dropped.
+	for _, exec := range ordered {
+		if visited[exec.ExecutionID] {
+			continue
+		}
+		logger.Logger.Warn().
+			Str("run_id", exec.RunID).
+			Str("execution_id", exec.ExecutionID).
+			Msg("cycle detected in workflow execution tree")
+		roots = append(roots, build(exec, 0))
+	}
+
+	return roots, len(visited)
+}
+
+func newWorkflowTreeNode(exec *types.Execution, depth int) *WorkflowTreeNode {
+	node := &WorkflowTreeNode{
+		ExecutionID:       exec.ExecutionID,
+		ParentExecutionID: exec.ParentExecutionID,
+		AgentNodeID:       exec.AgentNodeID,
+		ReasonerID:        exec.ReasonerID,
+		Status:            types.NormalizeExecutionStatus(exec.Status),
+		StartedAt:         exec.StartedAt.Format(time.RFC3339),
+		DurationMS:        exec.DurationMS,
+		Depth:             depth,
+		Children:          []*WorkflowTreeNode{},
+	}
+	if exec.CompletedAt != nil {
+		completed := exec.CompletedAt.Format(time.RFC3339)
+		node.CompletedAt = &completed
+		if node.DurationMS == nil && !exec.StartedAt.IsZero() {
+			duration := exec.CompletedAt.Sub(exec.StartedAt).Milliseconds()
+			node.DurationMS = &duration
+		}
+	}
+	return node
+}
+
 func summarizeRun(runID string, executions []*types.Execution) WorkflowRunSummary {
 	summary := WorkflowRunSummary{
 		WorkflowID:      runID,
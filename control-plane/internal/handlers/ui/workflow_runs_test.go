@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildWorkflowTree(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	parent := func(id string) *string { return &id }
+	completed := base.Add(5 * time.Second)
+
+	executions := []*types.Execution{
+		{ExecutionID: "grandchild", RunID: "run-1", ParentExecutionID: parent("child-a"), ReasonerID: "leaf", Status: "running", StartedAt: base.Add(3 * time.Second)},
+		{ExecutionID: "child-b", RunID: "run-1", ParentExecutionID: parent("root"), ReasonerID: "second", Status: "succeeded", StartedAt: base.Add(2 * time.Second)},
+		{ExecutionID: "root", RunID: "run-1", ReasonerID: "entry", Status: "succeeded", StartedAt: base, CompletedAt: &completed},
+		{ExecutionID: "child-a", RunID: "run-1", ParentExecutionID: parent("root"), ReasonerID: "first", Status: "succeeded", StartedAt: base.Add(time.Second)},
+	}
+
+	roots, total := buildWorkflowTree(executions)
+	require.Equal(t, 4, total)
+	require.Len(t, roots, 1)
+
+	root := roots[0]
+	require.Equal(t, "root", root.ExecutionID)
+	require.Equal(t, "entry", root.ReasonerID)
+	require.Equal(t, 0, root.Depth)
+	require.NotNil(t, root.DurationMS)
+	require.Equal(t, int64(5000), *root.DurationMS)
+
+	require.Len(t, root.Children, 2)
+	require.Equal(t, "child-a", root.Children[0].ExecutionID)
+	require.Equal(t, "child-b", root.Children[1].ExecutionID)
+	require.Equal(t, 1, root.Children[0].Depth)
+	require.Empty(t, root.Children[1].Children)
+
+	require.Len(t, root.Children[0].Children, 1)
+	leaf := root.Children[0].Children[0]
+	require.Equal(t, "grandchild", leaf.ExecutionID)
+	require.Equal(t, "running", leaf.Status)
+	require.Equal(t, 2, leaf.Depth)
+	require.Nil(t, leaf.DurationMS)
+}
+
+func TestBuildWorkflowTreeHandlesCycles(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	parent := func(id string) *string { return &id }
+
+	executions := []*types.Execution{
+		{ExecutionID: "a", RunID: "run-1", ParentExecutionID: parent("b"), StartedAt: base},
+		{ExecutionID: "b", RunID: "run-1", ParentExecutionID: parent("a"), StartedAt: base.Add(time.Second)},
+		{ExecutionID: "self", RunID: "run-1", ParentExecutionID: parent("self"), StartedAt: base.Add(2 * time.Second)},
+	}
+
+	roots, total := buildWorkflowTree(executions)
+	require.Equal(t, 3, total)
+	require.Len(t, roots, 2)
+
+	require.Equal(t, "self", roots[0].ExecutionID)
+	require.Empty(t, roots[0].Children)
+
+	require.Equal(t, "a", roots[1].ExecutionID)
+	require.Len(t, roots[1].Children, 1)
+	require.Equal(t, "b", roots[1].Children[0].ExecutionID)
+	require.Empty(t, roots[1].Children[0].Children)
+}
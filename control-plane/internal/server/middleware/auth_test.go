@@ -168,6 +168,21 @@ func TestAPIKeyAuth_SkipHealthSubpaths(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestAPIKeyAuth_SkipHealthzEndpoint(t *testing.T) {
+	router := gin.New()
+	router.Use(APIKeyAuth(AuthConfig{APIKey: "secret-key"}))
+	router.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
 func TestAPIKeyAuth_SkipMetricsEndpoint(t *testing.T) {
 	router := setupRouter(AuthConfig{APIKey: "secret-key"})
 
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GzipRequestDecoder transparently decompresses request bodies sent with
+// Content-Encoding: gzip so handlers can bind them as plain JSON.
+func GzipRequestDecoder() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || !strings.EqualFold(strings.TrimSpace(c.GetHeader("Content-Encoding")), "gzip") {
+			c.Next()
+			return
+		}
+
+		reader, err := gzip.NewReader(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_body",
+				"message": "request body is not valid gzip",
+			})
+			return
+		}
+		defer reader.Close()
+
+		c.Request.Body = reader
+		c.Request.Header.Del("Content-Encoding")
+		c.Request.Header.Del("Content-Length")
+		c.Request.ContentLength = -1
+		c.Next()
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDecoderRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(GzipRequestDecoder())
+	router.POST("/echo", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.String(http.StatusOK, "%s|%s", c.GetHeader("Content-Encoding"), body)
+	})
+	return router
+}
+
+func TestGzipRequestDecoder_DecompressesBody(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(`{"id":"node-1"}`))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", &buf)
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	setupDecoderRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `|{"id":"node-1"}`, w.Body.String())
+}
+
+func TestGzipRequestDecoder_PassesThroughPlainBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"id":"node-1"}`))
+	w := httptest.NewRecorder()
+	setupDecoderRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `|{"id":"node-1"}`, w.Body.String())
+}
+
+func TestGzipRequestDecoder_RejectsInvalidGzip(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("not gzip"))
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	setupDecoderRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_body")
+}
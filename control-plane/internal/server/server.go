@@ -71,6 +71,7 @@ type AgentFieldServer struct {
 	adminGRPCPort            int
 	webhookDispatcher        services.WebhookDispatcher
 	observabilityForwarder   services.ObservabilityForwarder
+	executionRedactor        *ui.ExecutionRedactor
 }
 
 // NewAgentFieldServer creates a new instance of the AgentFieldServer.
@@ -95,6 +96,32 @@ func NewAgentFieldServer(cfg *config.Config) (*AgentFieldServer, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cfg.UI.MaxEventSubscribers != 0 {
+		storageProvider.GetExecutionEventBus().SetMaxSubscribers(cfg.UI.MaxEventSubscribers)
+	}
+	dropPolicy, err := events.ParseDropPolicy(cfg.UI.EventDropPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ui.event_drop_policy: %w", err)
+	}
+	storageProvider.GetExecutionEventBus().SetDropPolicy(dropPolicy)
+
+	executionRedactor, err := ui.NewExecutionRedactor(ui.RedactionRules{
+		Paths:       cfg.UI.Redaction.Paths,
+		KeyPatterns: cfg.UI.Redaction.KeyPatterns,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid ui.redaction: %w", err)
+	}
+	// Rules set through the settings API take precedence over the configured ones.
+	storedRedaction, err := ui.LoadExecutionRedactionRules(context.Background(), storageProvider)
+	if err != nil {
+		return nil, fmt.Errorf("load execution redaction rules: %w", err)
+	}
+	if storedRedaction != nil {
+		if err := executionRedactor.Update(*storedRedaction); err != nil {
+			return nil, fmt.Errorf("invalid stored execution redaction rules: %w", err)
+		}
+	}
 
 	Router := gin.Default()
 
@@ -226,7 +253,7 @@ func NewAgentFieldServer(cfg *config.Config) (*AgentFieldServer, error) {
 
 	payloadStore := services.NewFilePayloadStore(dirs.PayloadsDir)
 
-	webhookDispatcher := services.NewWebhookDispatcher(storageProvider, services.WebhookDispatcherConfig{
+	webhookDispatcher := services.NewWebhookDispatcher(storageProvider, payloadStore, services.WebhookDispatcherConfig{
 		Timeout:         cfg.AgentField.ExecutionQueue.WebhookTimeout,
 		MaxAttempts:     cfg.AgentField.ExecutionQueue.WebhookMaxAttempts,
 		RetryBackoff:    cfg.AgentField.ExecutionQueue.WebhookRetryBackoff,
@@ -284,6 +311,7 @@ func NewAgentFieldServer(cfg *config.Config) (*AgentFieldServer, error) {
 		payloadStore:          payloadStore,
 		webhookDispatcher:        webhookDispatcher,
 		observabilityForwarder:   observabilityForwarder,
+		executionRedactor:        executionRedactor,
 		registryWatcherCancel:    nil,
 		adminGRPCPort:            adminPort,
 	}, nil
@@ -523,6 +551,20 @@ func (s *AgentFieldServer) healthCheckHandler(c *gin.Context) {
 		}
 	}
 
+	// Observability forwarder health check. A stalled or failing webhook only degrades
+	// the report: it must never fail the probe and get the control plane restarted.
+	if s.observabilityForwarder != nil {
+		if s.observabilityForwarder.Healthy() {
+			checks["observability_forwarder"] = gin.H{"status": "healthy"}
+		} else {
+			checks["observability_forwarder"] = gin.H{
+				"status":  "degraded",
+				"message": "observability forwarder is stopped or not keeping up with events",
+			}
+			healthStatus["degraded"] = true
+		}
+	}
+
 	// Overall status
 	if !allHealthy {
 		healthStatus["status"] = "unhealthy"
@@ -652,6 +694,9 @@ func (s *AgentFieldServer) setupRoutes() {
 		c.Next()
 	})
 
+	// Decode gzip-compressed request bodies (SDK clients using WithRequestCompression)
+	s.Router.Use(middleware.GzipRequestDecoder())
+
 	// API key authentication middleware (supports headers + api_key query param)
 	s.Router.Use(middleware.APIKeyAuth(middleware.AuthConfig{
 		APIKey:    s.config.API.Auth.APIKey,
@@ -664,6 +709,9 @@ func (s *AgentFieldServer) setupRoutes() {
 	// Expose Prometheus metrics
 	s.Router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// Liveness probe for load balancers and orchestrators
+	s.Router.GET("/healthz", s.healthCheckHandler)
+
 	// Serve UI files - embedded or filesystem based on availability
 	if s.config.UI.Enabled {
 		// Check if UI is embedded in the binary
@@ -732,6 +780,10 @@ func (s *AgentFieldServer) setupRoutes() {
 				lifecycleHandler := ui.NewLifecycleHandler(s.storage, s.agentService)
 				agents.GET("/running", lifecycleHandler.ListRunningAgentsHandler)
 
+				// Snapshot status of every agent, without live health checks
+				agentStatusHandler := ui.NewNodesHandler(s.uiService)
+				agents.GET("/status", agentStatusHandler.GetAllAgentStatusHandler)
+
 				// Individual agent operations
 				agents.GET("/:agentId/details", func(c *gin.Context) {
 					// TODO: Implement agent details
@@ -741,6 +793,7 @@ func (s *AgentFieldServer) setupRoutes() {
 				agents.POST("/:agentId/start", lifecycleHandler.StartAgentHandler)
 				agents.POST("/:agentId/stop", lifecycleHandler.StopAgentHandler)
 				agents.POST("/:agentId/reconcile", lifecycleHandler.ReconcileAgentHandler)
+				agents.POST("/:agentId/refresh", agentStatusHandler.RefreshAgentHandler)
 
 				// Configuration endpoints
 				configHandler := ui.NewConfigHandler(s.storage)
@@ -756,7 +809,7 @@ func (s *AgentFieldServer) setupRoutes() {
 				agents.DELETE("/:agentId/env/:key", envHandler.DeleteEnvVarHandler)
 
 				// Agent execution history endpoints
-				agentExecutionHandler := ui.NewExecutionHandler(s.storage, s.payloadStore, s.webhookDispatcher)
+				agentExecutionHandler := ui.NewExecutionHandler(s.storage, s.payloadStore, s.webhookDispatcher).WithRedactor(s.executionRedactor)
 				agents.GET("/:agentId/executions", agentExecutionHandler.ListExecutionsHandler)
 				agents.GET("/:agentId/executions/:executionId", agentExecutionHandler.GetExecutionDetailsHandler)
 			}
@@ -796,11 +849,13 @@ func (s *AgentFieldServer) setupRoutes() {
 			executions := uiAPI.Group("/executions")
 			{
 				// Executions UI endpoints
-				uiExecutionsHandler := ui.NewExecutionHandler(s.storage, s.payloadStore, s.webhookDispatcher)
+				uiExecutionsHandler := ui.NewExecutionHandler(s.storage, s.payloadStore, s.webhookDispatcher).WithRedactor(s.executionRedactor)
 				executions.GET("/summary", uiExecutionsHandler.GetExecutionsSummaryHandler)
 				executions.GET("/stats", uiExecutionsHandler.GetExecutionStatsHandler)
 				executions.GET("/enhanced", uiExecutionsHandler.GetEnhancedExecutionsHandler)
 				executions.GET("/events", uiExecutionsHandler.StreamExecutionEventsHandler)
+				executions.GET("/ws", uiExecutionsHandler.StreamExecutionEventsWebSocketHandler)
+				executions.GET("/compare", uiExecutionsHandler.CompareExecutionsHandler)
 
 				// Timeline endpoint for hourly aggregated data
 				timelineHandler := ui.NewExecutionTimelineHandler(s.storage)
@@ -813,6 +868,8 @@ func (s *AgentFieldServer) setupRoutes() {
 				// Individual execution operations
 				executions.GET("/:execution_id/details", uiExecutionsHandler.GetExecutionDetailsGlobalHandler)
 				executions.POST("/:execution_id/webhook/retry", uiExecutionsHandler.RetryExecutionWebhookHandler)
+				executions.POST("/:execution_id/replay", uiExecutionsHandler.ReplayExecutionHandler(handlers.ExecuteAsyncHandler(s.storage, s.payloadStore, s.webhookDispatcher, s.config.AgentField.ExecutionQueue.AgentCallTimeout)))
+				executions.DELETE("/:execution_id", uiExecutionsHandler.DeleteExecutionHandler)
 
 				// Execution notes endpoints for UI
 				executions.POST("/note", handlers.AddExecutionNoteHandler(s.storage))
@@ -829,14 +886,17 @@ func (s *AgentFieldServer) setupRoutes() {
 			workflows := uiAPI.Group("/workflows")
 			{
 				workflows.GET("/:workflowId/dag", handlers.GetWorkflowDAGHandler(s.storage))
+				workflowTreeHandler := ui.NewWorkflowRunHandler(s.storage)
+				workflows.GET("/:workflowId/tree", workflowTreeHandler.GetWorkflowRunTreeHandler)
 				didHandler := ui.NewDIDHandler(s.storage, s.didService, s.vcService)
 				workflows.POST("/vc-status", didHandler.GetWorkflowVCStatusBatchHandler)
 				workflows.GET("/:workflowId/vc-chain", didHandler.GetWorkflowVCChainHandler)
 				workflows.POST("/:workflowId/verify-vc", didHandler.VerifyWorkflowVCComprehensiveHandler)
 
-				// Workflow notes SSE streaming
-				workflowNotesHandler := ui.NewExecutionHandler(s.storage, s.payloadStore, s.webhookDispatcher)
+				// Workflow notes creation and SSE streaming
+				workflowNotesHandler := ui.NewExecutionHandler(s.storage, s.payloadStore, s.webhookDispatcher).WithRedactor(s.executionRedactor)
 				workflows.GET("/:workflowId/notes/events", workflowNotesHandler.StreamWorkflowNodeNotesHandler)
+				workflows.POST("/:workflowId/notes", workflowNotesHandler.CreateWorkflowNoteHandler)
 			}
 
 			// Reasoners management group
@@ -945,14 +1005,17 @@ func (s *AgentFieldServer) setupRoutes() {
 		// Unified execution endpoints (path-based)
 		agentAPI.POST("/execute/:target", handlers.ExecuteHandler(s.storage, s.payloadStore, s.webhookDispatcher, s.config.AgentField.ExecutionQueue.AgentCallTimeout))
 		agentAPI.POST("/execute/async/:target", handlers.ExecuteAsyncHandler(s.storage, s.payloadStore, s.webhookDispatcher, s.config.AgentField.ExecutionQueue.AgentCallTimeout))
-		agentAPI.GET("/executions/:execution_id", handlers.GetExecutionStatusHandler(s.storage))
-		agentAPI.POST("/executions/batch-status", handlers.BatchExecutionStatusHandler(s.storage))
+		agentAPI.GET("/execute/async/policy", handlers.GetAsyncTargetPolicyHandler(s.storage))
+		agentAPI.PUT("/execute/async/policy", handlers.SetAsyncTargetPolicyHandler(s.storage))
+		agentAPI.GET("/executions/:execution_id", handlers.GetExecutionStatusHandler(s.storage, s.payloadStore))
+		agentAPI.POST("/executions/batch-status", handlers.BatchExecutionStatusHandler(s.storage, s.payloadStore))
 		agentAPI.POST("/executions/:execution_id/status", handlers.UpdateExecutionStatusHandler(s.storage, s.payloadStore, s.webhookDispatcher, s.config.AgentField.ExecutionQueue.AgentCallTimeout))
 
 		// Execution notes endpoints for app.note() feature
 		agentAPI.POST("/executions/note", handlers.AddExecutionNoteHandler(s.storage))
 		agentAPI.GET("/executions/:execution_id/notes", handlers.GetExecutionNotesHandler(s.storage))
 		agentAPI.POST("/workflow/executions/events", handlers.WorkflowExecutionEventHandler(s.storage))
+		agentAPI.POST("/events", handlers.AgentEventHandler(events.GlobalCustomEventBus))
 
 		// Workflow endpoints will be reintroduced once the simplified execution pipeline lands.
 
@@ -1048,9 +1111,19 @@ func (s *AgentFieldServer) setupRoutes() {
 			settings.POST("/observability-webhook", obsHandler.SetWebhookHandler)
 			settings.DELETE("/observability-webhook", obsHandler.DeleteWebhookHandler)
 			settings.GET("/observability-webhook/status", obsHandler.GetStatusHandler)
+			settings.POST("/observability-webhook/pause", obsHandler.PauseHandler)
+			settings.POST("/observability-webhook/resume", obsHandler.ResumeHandler)
 			settings.POST("/observability-webhook/redrive", obsHandler.RedriveHandler)
+			settings.POST("/observability-webhook/test", obsHandler.TestWebhookHandler)
 			settings.GET("/observability-webhook/dlq", obsHandler.GetDeadLetterQueueHandler)
 			settings.DELETE("/observability-webhook/dlq", obsHandler.ClearDeadLetterQueueHandler)
+			settings.POST("/observability-webhook/dlq/redrive", obsHandler.RedriveDeadLetterEntriesHandler)
+			settings.DELETE("/observability-webhook/dlq/entries", obsHandler.DeleteDeadLetterEntriesHandler)
+
+			// Execution input/output redaction rules
+			redactionHandler := ui.NewExecutionRedactionHandler(s.executionRedactor, s.storage)
+			settings.GET("/execution-redaction", redactionHandler.GetRulesHandler)
+			settings.PUT("/execution-redaction", redactionHandler.SetRulesHandler)
 		}
 	}
 
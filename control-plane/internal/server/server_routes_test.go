@@ -42,6 +42,9 @@ func (s *stubStorage) GetExecutionRecord(ctx context.Context, executionID string
 func (s *stubStorage) UpdateExecutionRecord(ctx context.Context, executionID string, update func(*types.Execution) (*types.Execution, error)) (*types.Execution, error) {
 	return nil, nil
 }
+func (s *stubStorage) DeleteExecutionRecord(ctx context.Context, executionID string) error {
+	return nil
+}
 func (s *stubStorage) QueryExecutionRecords(ctx context.Context, filter types.ExecutionFilter) ([]*types.Execution, error) {
 	return nil, nil
 }
@@ -351,11 +354,19 @@ func (s *stubStorage) AddToDeadLetterQueue(ctx context.Context, event *types.Obs
 	return nil
 }
 func (s *stubStorage) GetDeadLetterQueueCount(ctx context.Context) (int64, error) { return 0, nil }
+func (s *stubStorage) GetDeadLetterQueueAgeBuckets(ctx context.Context, now time.Time) (types.ObservabilityDeadLetterAgeBuckets, error) {
+	return types.ObservabilityDeadLetterAgeBuckets{}, nil
+}
 func (s *stubStorage) GetDeadLetterQueue(ctx context.Context, limit, offset int) ([]types.ObservabilityDeadLetterEntry, error) {
 	return nil, nil
 }
-func (s *stubStorage) DeleteFromDeadLetterQueue(ctx context.Context, ids []int64) error { return nil }
-func (s *stubStorage) ClearDeadLetterQueue(ctx context.Context) error                   { return nil }
+func (s *stubStorage) GetDeadLetterQueueFiltered(ctx context.Context, filter types.ObservabilityDeadLetterFilter, limit, offset int) ([]types.ObservabilityDeadLetterEntry, int64, error) {
+	return nil, 0, nil
+}
+func (s *stubStorage) DeleteFromDeadLetterQueue(ctx context.Context, ids []int64) (int64, error) {
+	return 0, nil
+}
+func (s *stubStorage) ClearDeadLetterQueue(ctx context.Context) error { return nil }
 
 // stubPayloadStore implements services.PayloadStore
 type stubPayloadStore struct{}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Agent-Field/agentfield/control-plane/internal/services"
 	"github.com/Agent-Field/agentfield/control-plane/internal/storage"
 
 	"github.com/gin-gonic/gin"
@@ -212,6 +213,46 @@ func TestHealthCheckHandlerUnhealthyStorage(t *testing.T) {
 	}
 }
 
+// unhealthyForwarder is an observability forwarder whose workers have stopped.
+type unhealthyForwarder struct {
+	services.ObservabilityForwarder
+}
+
+func (unhealthyForwarder) Healthy() bool { return false }
+
+func TestHealthCheckHandlerDegradedObservabilityForwarder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := &AgentFieldServer{
+		storageHealthOverride:  func(context.Context) gin.H { return gin.H{"status": "healthy"} },
+		cacheHealthOverride:    func(context.Context) gin.H { return gin.H{"status": "healthy"} },
+		observabilityForwarder: unhealthyForwarder{},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, _ := http.NewRequest(http.MethodGet, "/healthz", nil)
+	c.Request = req
+
+	srv.healthCheckHandler(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 status, got %d", w.Code)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if payload["status"] != "healthy" || payload["degraded"] != true {
+		t.Fatalf("expected healthy but degraded report, got %+v", payload)
+	}
+	checks := payload["checks"].(map[string]any)
+	forwarderCheck := checks["observability_forwarder"].(map[string]any)
+	if forwarderCheck["status"] != "degraded" {
+		t.Fatalf("expected degraded forwarder check, got %+v", forwarderCheck)
+	}
+}
+
 func TestHealthCheckHandlerWithoutStorage(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	srv := &AgentFieldServer{}
@@ -7,9 +7,16 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
+	"math"
+	"math/rand"
 	"net/http"
+	"net/url"
+	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -25,8 +32,9 @@ type ObservabilityWebhookStore interface {
 	GetObservabilityWebhook(ctx context.Context) (*types.ObservabilityWebhookConfig, error)
 	AddToDeadLetterQueue(ctx context.Context, event *types.ObservabilityEvent, errorMessage string, retryCount int) error
 	GetDeadLetterQueueCount(ctx context.Context) (int64, error)
+	GetDeadLetterQueueAgeBuckets(ctx context.Context, now time.Time) (types.ObservabilityDeadLetterAgeBuckets, error)
 	GetDeadLetterQueue(ctx context.Context, limit, offset int) ([]types.ObservabilityDeadLetterEntry, error)
-	DeleteFromDeadLetterQueue(ctx context.Context, ids []int64) error
+	DeleteFromDeadLetterQueue(ctx context.Context, ids []int64) (int64, error)
 	ClearDeadLetterQueue(ctx context.Context) error
 }
 
@@ -36,7 +44,15 @@ type ObservabilityForwarder interface {
 	Stop(ctx context.Context) error
 	ReloadConfig(ctx context.Context) error
 	GetStatus() types.ObservabilityForwarderStatus
+	Metrics() types.ObservabilityForwarderMetrics
 	Redrive(ctx context.Context) types.ObservabilityRedriveResponse
+	RedriveEntries(ctx context.Context, ids []int64) types.ObservabilityRedriveResponse
+	TestWebhook(ctx context.Context) types.ObservabilityWebhookTestResponse
+	TestWebhookConfig(ctx context.Context, cfg *types.ObservabilityWebhookConfig) types.ObservabilityWebhookTestResponse
+	Healthy() bool
+	Pause()
+	Resume()
+	CheckDeadLetterThreshold(ctx context.Context)
 }
 
 // ObservabilityForwarderConfig holds configuration for the forwarder.
@@ -50,8 +66,72 @@ type ObservabilityForwarderConfig struct {
 	WorkerCount       int           // Number of parallel workers (default: 2)
 	QueueSize         int           // Internal queue size (default: 1000)
 	ResponseBodyLimit int           // Max response body to capture (default: 16KB)
+	MaxBatchBytes     int           // Max marshaled batch size in bytes; 0 disables the cap
+	DeliveryDeadline  time.Duration // Max total time spent delivering one batch across attempts; 0 disables
+	MaxEventAge       time.Duration // Events older than this when dequeued are dropped as expired; 0 disables
+
+	// MaxConcurrentDeliveries bounds simultaneous webhook deliveries independently of
+	// WorkerCount. Workers hand batches off to delivery goroutines and keep batching,
+	// waiting only while every slot is busy. Zero disables the bound and hand-off.
+	MaxConcurrentDeliveries int
+
+	// SampleRate is the fraction (0..1) of entities whose events are forwarded.
+	// Sampling is keyed on the execution/node/reasoner ID so related events are
+	// kept or dropped together. Zero or values >= 1 disable sampling.
+	SampleRate float64
+	// SampleEventTypes restricts sampling to these event types; empty applies it to all.
+	SampleEventTypes []string
+
+	// ForwardHeartbeats delivers node and reasoner heartbeats instead of dropping them
+	// (default: false). Useful for uptime-monitoring integrations.
+	ForwardHeartbeats bool
+
+	// OrderBy controls delivery ordering across workers (default: "none"). With
+	// "execution", events are partitioned to workers by entity ID so events for one
+	// execution/node are always delivered in publish order.
+	OrderBy string
+
+	// InstanceID identifies this control-plane replica on every forwarded event and
+	// batch so receivers can tell replicas apart (default: the host name).
+	InstanceID string
+
+	// UserAgent is sent on every webhook request (default: DefaultObservabilityUserAgent).
+	// A User-Agent entry in the webhook's custom headers still takes precedence.
+	UserAgent string
+
+	// DeadLetterAlertThreshold publishes a DeadLetterThresholdExceeded system event once
+	// when the dead letter queue grows past this many entries, re-arming after it drops
+	// back to or below the threshold. The forwarder delivers the event to the webhook like
+	// any other control plane event. Zero disables the alert.
+	DeadLetterAlertThreshold int64
 }
 
+// DefaultObservabilityUserAgent is the User-Agent used when none is configured.
+const DefaultObservabilityUserAgent = "AgentField-Observability/1.0"
+
+// Placeholders a webhook URL may contain; they are substituted per batch.
+const (
+	ObservabilityURLPlaceholderEventSource = "{event_source}"
+	ObservabilityURLPlaceholderEventType   = "{event_type}"
+)
+
+// Observability forwarder delivery ordering modes.
+const (
+	ObservabilityOrderNone      = "none"
+	ObservabilityOrderExecution = "execution"
+)
+
+const (
+	// observabilityInitialConfigAttempts bounds how many times Start tries to load the
+	// webhook config before falling back to background reloads.
+	observabilityInitialConfigAttempts = 3
+	// observabilitySubscriptionCount is the number of event buses the forwarder listens to.
+	observabilitySubscriptionCount = 5
+	// observabilitySaturationWindow is how long a worker queue may stay full without the
+	// worker making progress before the forwarder reports itself unhealthy.
+	observabilitySaturationWindow = 30 * time.Second
+)
+
 type observabilityForwarder struct {
 	store  ObservabilityWebhookStore
 	cfg    ObservabilityForwarderConfig
@@ -60,32 +140,69 @@ type observabilityForwarder struct {
 	// Runtime state
 	mu         sync.RWMutex
 	webhookCfg *types.ObservabilityWebhookConfig
+	resumed    chan struct{} // non-nil while delivery is paused, closed by Resume; guarded by mu
 
 	// Event collection
 	eventQueue chan types.ObservabilityEvent
+	partitions []chan types.ObservabilityEvent // per-worker queues when OrderBy is set
+
+	// batchOverhead is the marshaled size of a batch envelope without events, used to
+	// enforce MaxBatchBytes.
+	batchOverhead int
 
 	// Lifecycle
-	ctx    context.Context
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	stopCtx context.Context // deadline for the shutdown drain, guarded by mu
 
 	// Metrics
 	forwarded   atomic.Int64
 	dropped     atomic.Int64
+	sampled     atomic.Int64
+	expired     atomic.Int64
 	lastForward atomic.Pointer[time.Time]
 	lastError   atomic.Pointer[string]
+
+	// Runtime metrics; see Metrics
+	enqueuedBySource  sourceCounters
+	forwardedBySource sourceCounters
+	batchesDelivered  atomic.Int64
+	deliveryLatency   atomic.Int64   // total nanoseconds across batchesDelivered
+	workerBatchFill   []atomic.Int64 // events in the batch being collected, one per worker
+
+	// Retry health
+	consecutiveFailures atomic.Int64
+	nextRetryAt         atomic.Pointer[time.Time]
+
+	// Delivery concurrency
+	deliverySlots chan struct{} // nil when MaxConcurrentDeliveries is unbounded
+	inFlight      atomic.Int64
+
+	// Dead letter alerting
+	deadLetterAlerting atomic.Bool
+
+	// Liveness
+	runningWorkers      atomic.Int32
+	activeSubscriptions atomic.Int32
+	workerLastProcessed []atomic.Int64 // unix nanos, one per batch worker
 }
 
 // NewObservabilityForwarder creates a new observability forwarder.
 func NewObservabilityForwarder(store ObservabilityWebhookStore, cfg ObservabilityForwarderConfig) ObservabilityForwarder {
 	normalized := normalizeObservabilityConfig(cfg)
-	return &observabilityForwarder{
+	f := &observabilityForwarder{
 		store: store,
 		cfg:   normalized,
 		client: &http.Client{
 			Timeout: normalized.HTTPTimeout,
 		},
+		batchOverhead: observabilityBatchOverhead(normalized),
+	}
+	if normalized.MaxConcurrentDeliveries > 0 {
+		f.deliverySlots = make(chan struct{}, normalized.MaxConcurrentDeliveries)
 	}
+	return f
 }
 
 func normalizeObservabilityConfig(cfg ObservabilityForwarderConfig) ObservabilityForwarderConfig {
@@ -117,6 +234,20 @@ func normalizeObservabilityConfig(cfg ObservabilityForwarderConfig) Observabilit
 	if result.ResponseBodyLimit <= 0 {
 		result.ResponseBodyLimit = 16 * 1024
 	}
+	if result.SampleRate <= 0 || result.SampleRate > 1 {
+		result.SampleRate = 1
+	}
+	if result.OrderBy != ObservabilityOrderExecution {
+		result.OrderBy = ObservabilityOrderNone
+	}
+	if result.UserAgent == "" {
+		result.UserAgent = DefaultObservabilityUserAgent
+	}
+	if result.InstanceID == "" {
+		if hostname, err := os.Hostname(); err == nil {
+			result.InstanceID = hostname
+		}
+	}
 	return result
 }
 
@@ -126,35 +257,103 @@ func (f *observabilityForwarder) Start(ctx context.Context) error {
 		return fmt.Errorf("observability forwarder requires a store")
 	}
 
-	// Load initial config
-	if err := f.ReloadConfig(ctx); err != nil {
-		logger.Logger.Warn().Err(err).Msg("failed to load initial observability webhook config")
-	}
-
 	f.eventQueue = make(chan types.ObservabilityEvent, f.cfg.QueueSize)
 	f.ctx, f.cancel = context.WithCancel(ctx)
 
+	// Load initial config, retrying briefly so a transient storage error at boot does not
+	// leave the forwarder disabled until a manual reload.
+	if err := f.loadInitialConfig(); err != nil {
+		logger.Logger.Warn().Err(err).Msg("failed to load initial observability webhook config, retrying in background")
+		f.wg.Add(1)
+		go f.retryConfigLoad()
+	}
+
+	// Partition the queue per worker so each entity's events stay on one worker
+	if f.cfg.OrderBy == ObservabilityOrderExecution {
+		partitionSize := f.cfg.QueueSize / f.cfg.WorkerCount
+		if partitionSize < 1 {
+			partitionSize = 1
+		}
+		f.partitions = make([]chan types.ObservabilityEvent, f.cfg.WorkerCount)
+		for i := range f.partitions {
+			f.partitions[i] = make(chan types.ObservabilityEvent, partitionSize)
+		}
+	}
+
 	// Start batch workers
+	f.workerLastProcessed = make([]atomic.Int64, f.cfg.WorkerCount)
+	f.workerBatchFill = make([]atomic.Int64, f.cfg.WorkerCount)
+	now := time.Now().UnixNano()
 	for i := 0; i < f.cfg.WorkerCount; i++ {
+		f.workerLastProcessed[i].Store(now)
 		f.wg.Add(1)
-		go f.batchWorker()
+		f.runningWorkers.Add(1)
+		go f.batchWorker(i, f.workerQueue(i))
 	}
 
 	// Subscribe to event buses
-	f.wg.Add(3)
+	f.wg.Add(observabilitySubscriptionCount)
+	f.activeSubscriptions.Add(observabilitySubscriptionCount)
 	go f.subscribeExecutionEvents()
 	go f.subscribeNodeEvents()
 	go f.subscribeReasonerEvents()
+	go f.subscribeCustomEvents()
+	go f.subscribeSystemEvents()
 
 	logger.Logger.Info().Msg("observability forwarder started")
 	return nil
 }
 
+// loadInitialConfig attempts ReloadConfig up to observabilityInitialConfigAttempts times,
+// backing off between attempts like webhook deliveries do.
+func (f *observabilityForwarder) loadInitialConfig() error {
+	var err error
+	for attempt := 1; attempt <= observabilityInitialConfigAttempts; attempt++ {
+		if err = f.ReloadConfig(f.ctx); err == nil {
+			return nil
+		}
+		if attempt == observabilityInitialConfigAttempts {
+			break
+		}
+		select {
+		case <-f.ctx.Done():
+			return err
+		case <-time.After(f.retryDelay(attempt)):
+		}
+	}
+	return err
+}
+
+// retryConfigLoad keeps retrying the config load every MaxRetryBackoff until it succeeds
+// or the forwarder stops.
+func (f *observabilityForwarder) retryConfigLoad() {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.cfg.MaxRetryBackoff)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.ReloadConfig(f.ctx); err != nil {
+				logger.Logger.Warn().Err(err).Msg("observability webhook config reload failed")
+				continue
+			}
+			return
+		}
+	}
+}
+
 // Stop gracefully shuts down the forwarder.
 func (f *observabilityForwarder) Stop(ctx context.Context) error {
 	if f.cancel == nil {
 		return nil
 	}
+	f.mu.Lock()
+	f.stopCtx = ctx
+	f.mu.Unlock()
 	f.cancel()
 
 	done := make(chan struct{})
@@ -192,6 +391,61 @@ func (f *observabilityForwarder) ReloadConfig(ctx context.Context) error {
 	return nil
 }
 
+// Pause stops delivery without dropping events: workers hold their current batch and
+// events keep queuing until the queue is full, after which they go to the dead letter
+// queue. Redrives are refused while paused. Pausing an already paused forwarder has no
+// effect.
+func (f *observabilityForwarder) Pause() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.resumed == nil {
+		f.resumed = make(chan struct{})
+		logger.Logger.Info().Msg("observability forwarder paused")
+	}
+}
+
+// Resume restarts delivery after Pause, draining whatever queued up meanwhile.
+func (f *observabilityForwarder) Resume() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.resumed != nil {
+		close(f.resumed)
+		f.resumed = nil
+		logger.Logger.Info().Msg("observability forwarder resumed")
+	}
+}
+
+// paused reports whether delivery is currently paused.
+func (f *observabilityForwarder) paused() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.resumed != nil
+}
+
+// awaitResume blocks while delivery is paused. It returns false if ctx ends or the
+// forwarder stops before delivery is resumed.
+func (f *observabilityForwarder) awaitResume(ctx context.Context) bool {
+	var stopping <-chan struct{}
+	if f.ctx != nil {
+		stopping = f.ctx.Done()
+	}
+	for {
+		f.mu.RLock()
+		resumed := f.resumed
+		f.mu.RUnlock()
+		if resumed == nil {
+			return true
+		}
+		select {
+		case <-resumed:
+		case <-ctx.Done():
+			return false
+		case <-stopping:
+			return false
+		}
+	}
+}
+
 // GetStatus returns the current forwarder status.
 func (f *observabilityForwarder) GetStatus() types.ObservabilityForwarderStatus {
 	f.mu.RLock()
@@ -201,10 +455,15 @@ func (f *observabilityForwarder) GetStatus() types.ObservabilityForwarderStatus
 	status := types.ObservabilityForwarderStatus{
 		EventsForwarded: f.forwarded.Load(),
 		EventsDropped:   f.dropped.Load(),
+		EventsSampled:   f.sampled.Load(),
+		EventsExpired:   f.expired.Load(),
 	}
 
 	if f.eventQueue != nil {
 		status.QueueDepth = len(f.eventQueue)
+		for _, partition := range f.partitions {
+			status.QueueDepth += len(partition)
+		}
 	}
 
 	if cfg != nil && cfg.Enabled {
@@ -220,16 +479,120 @@ func (f *observabilityForwarder) GetStatus() types.ObservabilityForwarderStatus
 		status.LastError = lastErr
 	}
 
+	status.InFlightDeliveries = int(f.inFlight.Load())
+	status.Paused = f.paused()
+	status.Healthy = f.Healthy()
+	for i := range f.workerLastProcessed {
+		status.WorkerLastProcessedAt = append(status.WorkerLastProcessedAt, time.Unix(0, f.workerLastProcessed[i].Load()).UTC())
+	}
+	status.ConsecutiveFailures = int(f.consecutiveFailures.Load())
+	if nextRetry := f.nextRetryAt.Load(); nextRetry != nil {
+		status.NextRetryAt = nextRetry
+	}
+
 	// Get DLQ count from storage
 	if f.store != nil {
 		if count, err := f.store.GetDeadLetterQueueCount(context.Background()); err == nil {
 			status.DeadLetterCount = count
 		}
+		if status.DeadLetterCount > 0 {
+			if buckets, err := f.store.GetDeadLetterQueueAgeBuckets(context.Background(), time.Now().UTC()); err == nil {
+				status.DeadLetterAgeBuckets = &buckets
+			}
+		}
 	}
 
 	return status
 }
 
+// Metrics returns a snapshot of the forwarder's runtime metrics. Unlike GetStatus it does
+// not touch storage.
+func (f *observabilityForwarder) Metrics() types.ObservabilityForwarderMetrics {
+	metrics := types.ObservabilityForwarderMetrics{
+		EnqueuedBySource:  f.enqueuedBySource.snapshot(),
+		ForwardedBySource: f.forwardedBySource.snapshot(),
+		BatchesDelivered:  f.batchesDelivered.Load(),
+	}
+
+	if len(f.workerBatchFill) > 0 && f.cfg.BatchSize > 0 {
+		var pending int64
+		for i := range f.workerBatchFill {
+			pending += f.workerBatchFill[i].Load()
+		}
+		metrics.BatchFillRatio = float64(pending) / float64(f.cfg.BatchSize*len(f.workerBatchFill))
+	}
+
+	if metrics.BatchesDelivered > 0 {
+		average := time.Duration(f.deliveryLatency.Load() / metrics.BatchesDelivered)
+		metrics.AverageDeliveryLatencyMs = float64(average) / float64(time.Millisecond)
+	}
+
+	return metrics
+}
+
+// sourceCounters counts events per event source.
+type sourceCounters struct {
+	counts sync.Map // event source -> *atomic.Int64
+}
+
+func (c *sourceCounters) add(source string, n int64) {
+	counter, ok := c.counts.Load(source)
+	if !ok {
+		counter, _ = c.counts.LoadOrStore(source, new(atomic.Int64))
+	}
+	counter.(*atomic.Int64).Add(n)
+}
+
+func (c *sourceCounters) snapshot() map[string]int64 {
+	snapshot := make(map[string]int64)
+	c.counts.Range(func(key, value any) bool {
+		snapshot[key.(string)] = value.(*atomic.Int64).Load()
+		return true
+	})
+	return snapshot
+}
+
+// Healthy reports whether the forwarder is running with every batch worker and event bus
+// subscription alive, and, unless delivery is paused, no worker queue has sat full without
+// progress for longer than observabilitySaturationWindow.
+func (f *observabilityForwarder) Healthy() bool {
+	if f.ctx == nil || f.ctx.Err() != nil {
+		return false
+	}
+	if int(f.runningWorkers.Load()) != f.cfg.WorkerCount {
+		return false
+	}
+	if f.activeSubscriptions.Load() != observabilitySubscriptionCount {
+		return false
+	}
+
+	if f.paused() {
+		// Workers are held on purpose and their queues are expected to fill.
+		return true
+	}
+
+	now := time.Now()
+	for i := range f.workerLastProcessed {
+		queue := f.workerQueue(i)
+		if len(queue) < cap(queue) {
+			continue
+		}
+		lastProcessed := time.Unix(0, f.workerLastProcessed[i].Load())
+		if now.Sub(lastProcessed) > observabilitySaturationWindow {
+			return false
+		}
+	}
+	return true
+}
+
+// workerQueue returns the queue consumed by the given batch worker.
+func (f *observabilityForwarder) workerQueue(index int) chan types.ObservabilityEvent {
+	if f.partitions != nil {
+		return f.partitions[index]
+	}
+	return f.eventQueue
+}
+
 // Redrive attempts to resend all events in the dead letter queue.
 func (f *observabilityForwarder) Redrive(ctx context.Context) types.ObservabilityRedriveResponse {
 	f.mu.RLock()
@@ -242,6 +605,12 @@ func (f *observabilityForwarder) Redrive(ctx context.Context) types.Observabilit
 			Message: "webhook not configured or disabled",
 		}
 	}
+	if f.paused() {
+		return types.ObservabilityRedriveResponse{
+			Success: false,
+			Message: "delivery is paused",
+		}
+	}
 
 	// Get all DLQ entries (in batches of 100)
 	var processed, failed int
@@ -266,54 +635,13 @@ func (f *observabilityForwarder) Redrive(ctx context.Context) types.Observabilit
 
 		// Process each entry
 		for _, entry := range entries {
-			// Reconstruct the event
-			event := types.ObservabilityEvent{
-				EventType:   entry.EventType,
-				EventSource: entry.EventSource,
-				Timestamp:   entry.EventTimestamp.Format(time.RFC3339),
-				Data:        json.RawMessage(entry.Payload),
-			}
-
-			// Try to parse the payload back to interface{}
-			var data interface{}
-			if err := json.Unmarshal([]byte(entry.Payload), &data); err == nil {
-				event.Data = data
-			}
-
-			// Create a single-event batch
-			batch := types.ObservabilityEventBatch{
-				BatchID:    uuid.New().String(),
-				EventCount: 1,
-				Events:     []types.ObservabilityEvent{event},
-				Timestamp:  time.Now().UTC().Format(time.RFC3339),
-			}
-
-			body, err := json.Marshal(batch)
-			if err != nil {
-				failed++
-				continue
-			}
-
-			// Try to send with retries
-			var sendErr error
-			for attempt := 0; attempt < f.cfg.MaxAttempts; attempt++ {
-				if attempt > 0 {
-					backoff := f.computeBackoff(attempt)
-					select {
-					case <-ctx.Done():
-						return types.ObservabilityRedriveResponse{
-							Success:   false,
-							Message:   "redrive cancelled",
-							Processed: processed,
-							Failed:    failed,
-						}
-					case <-time.After(backoff):
-					}
-				}
-
-				sendErr = f.doSend(cfg, body)
-				if sendErr == nil {
-					break
+			sendErr := f.redriveEntry(ctx, cfg, entry)
+			if ctx.Err() != nil {
+				return types.ObservabilityRedriveResponse{
+					Success:   false,
+					Message:   "redrive cancelled",
+					Processed: processed,
+					Failed:    failed,
 				}
 			}
 
@@ -323,15 +651,12 @@ func (f *observabilityForwarder) Redrive(ctx context.Context) types.Observabilit
 			} else {
 				processed++
 				successfulIDs = append(successfulIDs, entry.ID)
-				f.forwarded.Add(1)
-				now := time.Now().UTC()
-				f.lastForward.Store(&now)
 			}
 		}
 
 		// Delete successfully processed entries
 		if len(successfulIDs) > 0 {
-			if err := f.store.DeleteFromDeadLetterQueue(ctx, successfulIDs); err != nil {
+			if _, err := f.store.DeleteFromDeadLetterQueue(ctx, successfulIDs); err != nil {
 				logger.Logger.Error().Err(err).Int("count", len(successfulIDs)).Msg("failed to delete redriven entries from DLQ")
 			}
 			successfulIDs = successfulIDs[:0]
@@ -339,6 +664,7 @@ func (f *observabilityForwarder) Redrive(ctx context.Context) types.Observabilit
 
 		offset += batchSize
 	}
+	f.checkDeadLetterThreshold(context.WithoutCancel(ctx))
 
 	message := fmt.Sprintf("redrove %d events", processed)
 	if failed > 0 {
@@ -353,9 +679,232 @@ func (f *observabilityForwarder) Redrive(ctx context.Context) types.Observabilit
 	}
 }
 
+// TestWebhook sends a single synthetic event batch to the stored webhook config,
+// bypassing the queue, and reports the outcome inline.
+func (f *observabilityForwarder) TestWebhook(ctx context.Context) types.ObservabilityWebhookTestResponse {
+	cfg, err := f.store.GetObservabilityWebhook(ctx)
+	if err != nil {
+		return types.ObservabilityWebhookTestResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to load webhook config: %v", err),
+		}
+	}
+	return f.TestWebhookConfig(ctx, cfg)
+}
+
+// TestWebhookConfig sends a synthetic event using cfg rather than the stored config, so a
+// candidate webhook can be checked before it is saved.
+func (f *observabilityForwarder) TestWebhookConfig(ctx context.Context, cfg *types.ObservabilityWebhookConfig) types.ObservabilityWebhookTestResponse {
+	if cfg == nil || cfg.URL == "" {
+		return types.ObservabilityWebhookTestResponse{
+			Success: false,
+			Message: "webhook not configured",
+		}
+	}
+
+	now := time.Now().UTC()
+	batch := types.ObservabilityEventBatch{
+		BatchID:    uuid.New().String(),
+		EventCount: 1,
+		Events: []types.ObservabilityEvent{{
+			EventType:      "webhook.test",
+			EventSource:    "control_plane",
+			SourceInstance: f.cfg.InstanceID,
+			Timestamp:      now.Format(time.RFC3339),
+			Data: map[string]interface{}{
+				"message": "AgentField observability webhook test event",
+			},
+		}},
+		SourceInstance: f.cfg.InstanceID,
+		Timestamp:      now.Format(time.RFC3339),
+	}
+
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return types.ObservabilityWebhookTestResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to marshal test event: %v", err),
+		}
+	}
+
+	start := time.Now()
+	statusCode, sendErr := f.doSendWithContext(ctx, withResolvedWebhookURL(cfg, batch.Events), body)
+	response := types.ObservabilityWebhookTestResponse{
+		Success:    sendErr == nil,
+		StatusCode: statusCode,
+		LatencyMS:  time.Since(start).Milliseconds(),
+		Message:    "test event delivered",
+	}
+	if sendErr != nil {
+		response.Message = "test event delivery failed"
+		response.Error = sendErr.Error()
+	}
+	return response
+}
+
+// RedriveEntries attempts to resend only the dead letter queue entries with the given IDs.
+// Successfully redriven entries are removed; failed or unknown IDs remain untouched.
+func (f *observabilityForwarder) RedriveEntries(ctx context.Context, ids []int64) types.ObservabilityRedriveResponse {
+	f.mu.RLock()
+	cfg := f.webhookCfg
+	f.mu.RUnlock()
+
+	if cfg == nil || !cfg.Enabled || cfg.URL == "" {
+		return types.ObservabilityRedriveResponse{
+			Success: false,
+			Message: "webhook not configured or disabled",
+		}
+	}
+	if f.paused() {
+		return types.ObservabilityRedriveResponse{
+			Success: false,
+			Message: "delivery is paused",
+		}
+	}
+
+	wanted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	// Collect the requested entries (in batches of 100)
+	var selected []types.ObservabilityDeadLetterEntry
+	offset := 0
+	batchSize := 100
+	for len(selected) < len(wanted) {
+		entries, err := f.store.GetDeadLetterQueue(ctx, batchSize, offset)
+		if err != nil {
+			return types.ObservabilityRedriveResponse{
+				Success: false,
+				Message: fmt.Sprintf("failed to read dead letter queue: %v", err),
+			}
+		}
+		if len(entries) == 0 {
+			break
+		}
+		for _, entry := range entries {
+			if wanted[entry.ID] {
+				selected = append(selected, entry)
+			}
+		}
+		offset += batchSize
+	}
+
+	var processed, failed int
+	var successfulIDs []int64
+	for _, entry := range selected {
+		sendErr := f.redriveEntry(ctx, cfg, entry)
+		if ctx.Err() != nil {
+			break
+		}
+		if sendErr != nil {
+			failed++
+			logger.Logger.Warn().Err(sendErr).Int64("dlq_id", entry.ID).Msg("failed to redrive event")
+			continue
+		}
+		processed++
+		successfulIDs = append(successfulIDs, entry.ID)
+	}
+
+	if len(successfulIDs) > 0 {
+		if _, err := f.store.DeleteFromDeadLetterQueue(context.WithoutCancel(ctx), successfulIDs); err != nil {
+			logger.Logger.Error().Err(err).Int("count", len(successfulIDs)).Msg("failed to delete redriven entries from DLQ")
+		}
+	}
+	f.checkDeadLetterThreshold(context.WithoutCancel(ctx))
+
+	if ctx.Err() != nil {
+		return types.ObservabilityRedriveResponse{
+			Success:   false,
+			Message:   "redrive cancelled",
+			Processed: processed,
+			Failed:    failed,
+		}
+	}
+
+	missing := len(wanted) - len(selected)
+	failed += missing
+
+	message := fmt.Sprintf("redrove %d events", processed)
+	if failed > 0 {
+		message = fmt.Sprintf("redrove %d events, %d failed", processed, failed)
+	}
+	if missing > 0 {
+		message = fmt.Sprintf("%s (%d not found)", message, missing)
+	}
+
+	return types.ObservabilityRedriveResponse{
+		Success:   failed == 0,
+		Message:   message,
+		Processed: processed,
+		Failed:    failed,
+	}
+}
+
+// redriveEntry resends a single dead letter queue entry as a one-event batch, retrying
+// up to MaxAttempts times. It returns early with ctx.Err() if ctx is cancelled.
+func (f *observabilityForwarder) redriveEntry(ctx context.Context, cfg *types.ObservabilityWebhookConfig, entry types.ObservabilityDeadLetterEntry) error {
+	// Reconstruct the event
+	event := types.ObservabilityEvent{
+		EventType:      entry.EventType,
+		EventSource:    entry.EventSource,
+		SourceInstance: f.cfg.InstanceID,
+		Timestamp:      entry.EventTimestamp.Format(time.RFC3339),
+		Data:           json.RawMessage(entry.Payload),
+	}
+
+	// Try to parse the payload back to interface{}
+	var data interface{}
+	if err := json.Unmarshal([]byte(entry.Payload), &data); err == nil {
+		event.Data = data
+	}
+
+	// Create a single-event batch
+	batch := types.ObservabilityEventBatch{
+		BatchID:        uuid.New().String(),
+		EventCount:     1,
+		Events:         []types.ObservabilityEvent{event},
+		SourceInstance: f.cfg.InstanceID,
+		Timestamp:      time.Now().UTC().Format(time.RFC3339),
+	}
+
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("marshal redrive batch: %w", err)
+	}
+	cfg = withResolvedWebhookURL(cfg, batch.Events)
+
+	// Try to send with retries
+	var sendErr error
+	for attempt := 0; attempt < f.cfg.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			backoff := f.computeBackoff(attempt)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+
+		sendErr = f.doSend(cfg, body)
+		if sendErr == nil {
+			break
+		}
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+
+	f.forwarded.Add(1)
+	now := time.Now().UTC()
+	f.lastForward.Store(&now)
+	return nil
+}
+
 // subscribeExecutionEvents listens to the execution event bus.
 func (f *observabilityForwarder) subscribeExecutionEvents() {
 	defer f.wg.Done()
+	defer f.activeSubscriptions.Add(-1)
 
 	subscriberID := fmt.Sprintf("observability-forwarder-execution-%s", uuid.New().String()[:8])
 	ch := events.GlobalExecutionEventBus.Subscribe(subscriberID)
@@ -377,6 +926,7 @@ func (f *observabilityForwarder) subscribeExecutionEvents() {
 // subscribeNodeEvents listens to the node event bus.
 func (f *observabilityForwarder) subscribeNodeEvents() {
 	defer f.wg.Done()
+	defer f.activeSubscriptions.Add(-1)
 
 	subscriberID := fmt.Sprintf("observability-forwarder-node-%s", uuid.New().String()[:8])
 	ch := events.GlobalNodeEventBus.Subscribe(subscriberID)
@@ -391,7 +941,7 @@ func (f *observabilityForwarder) subscribeNodeEvents() {
 				return
 			}
 			// Skip heartbeat events - they're just keep-alives, not useful for observability
-			if event.Type == events.NodeHeartbeat {
+			if event.Type == events.NodeHeartbeat && !f.cfg.ForwardHeartbeats {
 				continue
 			}
 			f.enqueueEvent(f.transformNodeEvent(event))
@@ -402,6 +952,7 @@ func (f *observabilityForwarder) subscribeNodeEvents() {
 // subscribeReasonerEvents listens to the reasoner event bus.
 func (f *observabilityForwarder) subscribeReasonerEvents() {
 	defer f.wg.Done()
+	defer f.activeSubscriptions.Add(-1)
 
 	subscriberID := fmt.Sprintf("observability-forwarder-reasoner-%s", uuid.New().String()[:8])
 	ch := events.GlobalReasonerEventBus.Subscribe(subscriberID)
@@ -416,7 +967,7 @@ func (f *observabilityForwarder) subscribeReasonerEvents() {
 				return
 			}
 			// Skip heartbeat events - they're just keep-alives, not useful for observability
-			if event.Type == events.Heartbeat {
+			if event.Type == events.Heartbeat && !f.cfg.ForwardHeartbeats {
 				continue
 			}
 			f.enqueueEvent(f.transformReasonerEvent(event))
@@ -424,6 +975,51 @@ func (f *observabilityForwarder) subscribeReasonerEvents() {
 	}
 }
 
+// subscribeCustomEvents listens to the bus of agent-emitted custom events.
+func (f *observabilityForwarder) subscribeCustomEvents() {
+	defer f.wg.Done()
+	defer f.activeSubscriptions.Add(-1)
+
+	subscriberID := fmt.Sprintf("observability-forwarder-custom-%s", uuid.New().String()[:8])
+	ch := events.GlobalCustomEventBus.Subscribe(subscriberID)
+	defer events.GlobalCustomEventBus.Unsubscribe(subscriberID)
+
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			f.enqueueEvent(f.transformCustomEvent(event))
+		}
+	}
+}
+
+// subscribeSystemEvents listens to the bus of control plane operational events, such as
+// the dead letter threshold alert.
+func (f *observabilityForwarder) subscribeSystemEvents() {
+	defer f.wg.Done()
+	defer f.activeSubscriptions.Add(-1)
+
+	subscriberID := fmt.Sprintf("observability-forwarder-system-%s", uuid.New().String()[:8])
+	ch := events.GlobalSystemEventBus.Subscribe(subscriberID)
+	defer events.GlobalSystemEventBus.Unsubscribe(subscriberID)
+
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			f.enqueueEvent(f.transformSystemEvent(event))
+		}
+	}
+}
+
 // enqueueEvent adds an event to the queue, dropping if full.
 func (f *observabilityForwarder) enqueueEvent(event types.ObservabilityEvent) {
 	// Check if webhook is configured and enabled
@@ -435,21 +1031,87 @@ func (f *observabilityForwarder) enqueueEvent(event types.ObservabilityEvent) {
 		return
 	}
 
+	if !f.sampleEvent(event) {
+		f.sampled.Add(1)
+		return
+	}
+
 	select {
-	case f.eventQueue <- event:
-		// Event queued successfully
+	case f.queueFor(event) <- event:
+		f.enqueuedBySource.add(event.EventSource, 1)
 	default:
+		if f.paused() {
+			// Keep events that overflow a paused forwarder so they can be redriven.
+			f.deadLetterBatch([]types.ObservabilityEvent{event}, errors.New("observability forwarder paused: queue full"), 0)
+			return
+		}
 		// Queue full, drop event
 		f.dropped.Add(1)
 		logger.Logger.Warn().Str("event_type", event.EventType).Msg("observability event dropped: queue full")
 	}
 }
 
-// batchWorker collects events and sends them in batches.
-func (f *observabilityForwarder) batchWorker() {
+// sampleEvent reports whether the event survives sampling. The decision hashes the
+// event's entity ID so every event for one execution or node shares the same fate.
+func (f *observabilityForwarder) sampleEvent(event types.ObservabilityEvent) bool {
+	if f.cfg.SampleRate >= 1 {
+		return true
+	}
+	if len(f.cfg.SampleEventTypes) > 0 {
+		matched := false
+		for _, eventType := range f.cfg.SampleEventTypes {
+			if eventType == event.EventType {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return true
+		}
+	}
+
+	key := observabilityEntityKey(event)
+	if key == "" {
+		return true
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return float64(h.Sum32())/float64(math.MaxUint32) < f.cfg.SampleRate
+}
+
+// queueFor returns the queue an event should be placed on. When ordering is enabled
+// the event's entity key picks a fixed partition so its events stay in order.
+func (f *observabilityForwarder) queueFor(event types.ObservabilityEvent) chan types.ObservabilityEvent {
+	if len(f.partitions) == 0 {
+		return f.eventQueue
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(observabilityEntityKey(event)))
+	return f.partitions[h.Sum32()%uint32(len(f.partitions))]
+}
+
+// observabilityEntityKey returns the most specific entity identifier carried by the event.
+func observabilityEntityKey(event types.ObservabilityEvent) string {
+	data, ok := event.Data.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	for _, field := range []string{"execution_id", "node_id", "reasoner_id"} {
+		if id, ok := data[field].(string); ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// batchWorker collects events from queue and sends them in batches.
+func (f *observabilityForwarder) batchWorker(index int, queue <-chan types.ObservabilityEvent) {
 	defer f.wg.Done()
+	defer f.runningWorkers.Add(-1)
 
 	batch := make([]types.ObservabilityEvent, 0, f.cfg.BatchSize)
+	batchBytes := 0
 	timer := time.NewTimer(f.cfg.BatchTimeout)
 	defer timer.Stop()
 
@@ -462,6 +1124,8 @@ func (f *observabilityForwarder) batchWorker() {
 		toSend := make([]types.ObservabilityEvent, len(batch))
 		copy(toSend, batch)
 		batch = batch[:0]
+		batchBytes = 0
+		f.workerBatchFill[index].Store(0)
 
 		f.sendBatch(toSend)
 	}
@@ -469,17 +1133,27 @@ func (f *observabilityForwarder) batchWorker() {
 	for {
 		select {
 		case <-f.ctx.Done():
-			// Flush remaining events before exit
-			flushBatch()
+			f.drainQueue(queue, batch, batchBytes)
 			return
 
-		case event, ok := <-f.eventQueue:
+		case event, ok := <-queue:
 			if !ok {
-				flushBatch()
+				f.drainQueue(queue, batch, batchBytes)
 				return
 			}
+			f.workerLastProcessed[index].Store(time.Now().UnixNano())
+			if f.eventExpired(event, time.Now()) {
+				f.expired.Add(1)
+				continue
+			}
+			size := f.batchedEventSize(event)
+			if f.exceedsByteCap(len(batch), batchBytes, size) {
+				flushBatch()
+			}
+			batchBytes += size
 			batch = append(batch, event)
-			if len(batch) >= f.cfg.BatchSize {
+			f.workerBatchFill[index].Store(int64(len(batch)))
+			if f.batchComplete(len(batch), batchBytes) {
 				flushBatch()
 				// Reset timer after flush
 				if !timer.Stop() {
@@ -493,16 +1167,191 @@ func (f *observabilityForwarder) batchWorker() {
 
 		case <-timer.C:
 			flushBatch()
+			f.workerLastProcessed[index].Store(time.Now().UnixNano())
 			timer.Reset(f.cfg.BatchTimeout)
 		}
 	}
 }
 
-// sendBatch sends a batch of events to the configured webhook.
+// drainQueue delivers the pending batch and whatever is still buffered in queue when a
+// worker exits. Delivery is bounded by the context passed to Stop rather than the
+// forwarder's own (already cancelled) context; events that cannot be delivered before
+// that deadline are written to the dead letter queue instead of blocking shutdown.
+func (f *observabilityForwarder) drainQueue(queue <-chan types.ObservabilityEvent, pending []types.ObservabilityEvent, pendingBytes int) {
+	ctx := f.drainContext()
+	batch := append([]types.ObservabilityEvent(nil), pending...)
+	batchBytes := pendingBytes
+	flush := func() {
+		f.deliverBatch(ctx, batch)
+		batch = nil
+		batchBytes = 0
+	}
+	for drained := false; !drained; {
+		select {
+		case event, ok := <-queue:
+			if !ok {
+				drained = true
+				continue
+			}
+			if f.eventExpired(event, time.Now()) {
+				f.expired.Add(1)
+				continue
+			}
+			size := f.batchedEventSize(event)
+			if f.exceedsByteCap(len(batch), batchBytes, size) {
+				flush()
+			}
+			batchBytes += size
+			batch = append(batch, event)
+			if f.batchComplete(len(batch), batchBytes) {
+				flush()
+			}
+		default:
+			drained = true
+		}
+	}
+	f.deliverBatch(ctx, batch)
+}
+
+// drainContext returns the context passed to Stop, or a background context when the
+// worker is exiting for another reason.
+func (f *observabilityForwarder) drainContext() context.Context {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.stopCtx != nil {
+		return f.stopCtx
+	}
+	return context.Background()
+}
+
+// batchedEventSize returns the bytes an event adds to a batch, or zero when there is no
+// MaxBatchBytes cap to enforce.
+func (f *observabilityForwarder) batchedEventSize(event types.ObservabilityEvent) int {
+	if f.cfg.MaxBatchBytes <= 0 {
+		return 0
+	}
+	return f.eventSize(event)
+}
+
+// exceedsByteCap reports whether a batch of count events and batchBytes must be flushed
+// before adding an event of size bytes. An event larger than the cap on its own is sent
+// alone.
+func (f *observabilityForwarder) exceedsByteCap(count, batchBytes, size int) bool {
+	return f.cfg.MaxBatchBytes > 0 && count > 0 && f.batchOverhead+batchBytes+size > f.cfg.MaxBatchBytes
+}
+
+// batchComplete reports whether a batch of count events and batchBytes is ready to send.
+func (f *observabilityForwarder) batchComplete(count, batchBytes int) bool {
+	return count >= f.cfg.BatchSize || (f.cfg.MaxBatchBytes > 0 && f.batchOverhead+batchBytes >= f.cfg.MaxBatchBytes)
+}
+
+// eventExpired reports whether an event has waited longer than MaxEventAge since it was
+// emitted. Events without a parseable timestamp are never treated as expired.
+func (f *observabilityForwarder) eventExpired(event types.ObservabilityEvent, now time.Time) bool {
+	if f.cfg.MaxEventAge <= 0 {
+		return false
+	}
+	emitted, err := time.Parse(time.RFC3339, event.Timestamp)
+	if err != nil {
+		return false
+	}
+	return now.Sub(emitted) > f.cfg.MaxEventAge
+}
+
+// observabilityBatchOverhead returns the marshaled size of a batch envelope with no
+// events: batch ID, source instance, timestamp, and an event count as wide as BatchSize.
+func observabilityBatchOverhead(cfg ObservabilityForwarderConfig) int {
+	data, err := json.Marshal(types.ObservabilityEventBatch{
+		BatchID:        uuid.New().String(),
+		EventCount:     cfg.BatchSize,
+		Events:         []types.ObservabilityEvent{},
+		SourceInstance: cfg.InstanceID,
+		Timestamp:      time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// eventSize returns the marshaled size of an event plus its array separator, counting the
+// source instance that deliverBatch stamps on events without one.
+func (f *observabilityForwarder) eventSize(event types.ObservabilityEvent) int {
+	if event.SourceInstance == "" {
+		event.SourceInstance = f.cfg.InstanceID
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return 0
+	}
+	return len(data) + 1
+}
+
+// sendBatch sends a batch of events to the configured webhook. With MaxConcurrentDeliveries
+// set, the batch is delivered on its own goroutine once a delivery slot is free, so a slow
+// webhook holds up the worker only while every slot is busy. OrderBy keeps delivery inline
+// so a worker's batches still arrive in order.
 func (f *observabilityForwarder) sendBatch(events []types.ObservabilityEvent) {
+	if f.deliverySlots == nil || f.cfg.OrderBy != ObservabilityOrderNone {
+		f.deliverBatch(f.ctx, events)
+		return
+	}
+	if !f.acquireDeliverySlot(f.ctx) {
+		// Stopping: the delivery attempt gives up at once and dead-letters the batch.
+		f.deliverBatchInSlot(f.ctx, events)
+		return
+	}
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		defer f.releaseDeliverySlot()
+		f.deliverBatchInSlot(f.ctx, events)
+	}()
+}
+
+// acquireDeliverySlot waits for a free delivery slot, reporting false if ctx ends first.
+// It always succeeds when MaxConcurrentDeliveries is unbounded.
+func (f *observabilityForwarder) acquireDeliverySlot(ctx context.Context) bool {
+	if f.deliverySlots == nil {
+		return true
+	}
+	select {
+	case f.deliverySlots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// releaseDeliverySlot frees a slot taken by acquireDeliverySlot.
+func (f *observabilityForwarder) releaseDeliverySlot() {
+	if f.deliverySlots != nil {
+		<-f.deliverySlots
+	}
+}
+
+// deliverBatch sends a batch of events, retrying until it succeeds or parent is done, and
+// writes the batch to the dead letter queue if every attempt failed. It holds a delivery
+// slot for the whole delivery.
+func (f *observabilityForwarder) deliverBatch(parent context.Context, events []types.ObservabilityEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if f.acquireDeliverySlot(parent) {
+		defer f.releaseDeliverySlot()
+	}
+	f.deliverBatchInSlot(parent, events)
+}
+
+// deliverBatchInSlot is deliverBatch for a caller that already holds a delivery slot.
+func (f *observabilityForwarder) deliverBatchInSlot(parent context.Context, events []types.ObservabilityEvent) {
 	if len(events) == 0 {
 		return
 	}
+	if !f.awaitResume(parent) {
+		f.deadLetterBatch(events, errors.New("observability forwarder stopped while paused"), 0)
+		return
+	}
 
 	f.mu.RLock()
 	cfg := f.webhookCfg
@@ -512,11 +1361,18 @@ func (f *observabilityForwarder) sendBatch(events []types.ObservabilityEvent) {
 		return
 	}
 
+	for i := range events {
+		if events[i].SourceInstance == "" {
+			events[i].SourceInstance = f.cfg.InstanceID
+		}
+	}
+
 	batch := types.ObservabilityEventBatch{
-		BatchID:    uuid.New().String(),
-		EventCount: len(events),
-		Events:     events,
-		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		BatchID:        uuid.New().String(),
+		EventCount:     len(events),
+		Events:         events,
+		SourceInstance: f.cfg.InstanceID,
+		Timestamp:      time.Now().UTC().Format(time.RFC3339),
 	}
 
 	body, err := json.Marshal(batch)
@@ -524,59 +1380,168 @@ func (f *observabilityForwarder) sendBatch(events []types.ObservabilityEvent) {
 		logger.Logger.Error().Err(err).Msg("failed to marshal observability event batch")
 		return
 	}
+	cfg = withResolvedWebhookURL(cfg, events)
+
+	// Bound the total time spent on this batch so a failing endpoint can't hold the worker
+	sendCtx := parent
+	if f.cfg.DeliveryDeadline > 0 {
+		var cancel context.CancelFunc
+		sendCtx, cancel = context.WithTimeout(parent, f.cfg.DeliveryDeadline)
+		defer cancel()
+	}
 
 	// Retry logic
+	start := time.Now()
 	var lastErr error
-	for attempt := 0; attempt < f.cfg.MaxAttempts; attempt++ {
-		if attempt > 0 {
-			backoff := f.computeBackoff(attempt)
+	attempts := 0
+	for attempts < f.cfg.MaxAttempts {
+		if attempts > 0 {
+			backoff := f.retryDelay(attempts)
+			next := time.Now().UTC().Add(backoff)
+			f.nextRetryAt.Store(&next)
 			select {
-			case <-f.ctx.Done():
-				return
+			case <-sendCtx.Done():
 			case <-time.After(backoff):
 			}
 		}
+		if sendCtx.Err() != nil {
+			if lastErr == nil {
+				lastErr = sendCtx.Err()
+			}
+			if parent.Err() != nil {
+				lastErr = fmt.Errorf("forwarder stopping: %w", lastErr)
+			} else {
+				lastErr = fmt.Errorf("delivery deadline of %s exceeded: %w", f.cfg.DeliveryDeadline, lastErr)
+			}
+			break
+		}
 
-		err := f.doSend(cfg, body)
+		attempts++
+		_, err := f.postWebhook(sendCtx, cfg, body)
 		if err == nil {
 			// Success
 			now := time.Now().UTC()
 			f.lastForward.Store(&now)
 			f.forwarded.Add(int64(len(events)))
+			for i := range events {
+				f.forwardedBySource.add(events[i].EventSource, 1)
+			}
+			f.batchesDelivered.Add(1)
+			f.deliveryLatency.Add(int64(time.Since(start)))
+			f.consecutiveFailures.Store(0)
+			f.nextRetryAt.Store(nil)
 			return
 		}
 		lastErr = err
+		f.consecutiveFailures.Add(1)
 	}
+	f.nextRetryAt.Store(nil)
 
 	// All attempts failed - write to dead letter queue
 	if lastErr != nil {
-		errStr := lastErr.Error()
-		f.lastError.Store(&errStr)
-		f.dropped.Add(int64(len(events)))
-
-		// Write each event to DLQ
-		for i := range events {
-			if err := f.store.AddToDeadLetterQueue(context.Background(), &events[i], errStr, f.cfg.MaxAttempts); err != nil {
-				logger.Logger.Error().Err(err).Str("event_type", events[i].EventType).Msg("failed to add event to dead letter queue")
-			}
+		f.deadLetterBatch(events, lastErr, attempts)
+	}
+}
+
+// deadLetterBatch records events that could not be delivered in the dead letter queue.
+func (f *observabilityForwarder) deadLetterBatch(events []types.ObservabilityEvent, lastErr error, attempts int) {
+	errStr := lastErr.Error()
+	f.lastError.Store(&errStr)
+	f.dropped.Add(int64(len(events)))
+
+	// Write each event to DLQ
+	for i := range events {
+		if err := f.store.AddToDeadLetterQueue(context.Background(), &events[i], errStr, attempts); err != nil {
+			logger.Logger.Error().Err(err).Str("event_type", events[i].EventType).Msg("failed to add event to dead letter queue")
 		}
+	}
+
+	logger.Logger.Warn().Err(lastErr).Int("event_count", len(events)).Msg("failed to deliver observability events, added to DLQ")
+	f.checkDeadLetterThreshold(context.Background())
+}
 
-		logger.Logger.Warn().Err(lastErr).Int("event_count", len(events)).Msg("failed to deliver observability events, added to DLQ")
+// CheckDeadLetterThreshold re-evaluates the dead letter alert after entries were removed
+// from the queue outside the forwarder, e.g. cleared or deleted through the API.
+func (f *observabilityForwarder) CheckDeadLetterThreshold(ctx context.Context) {
+	f.checkDeadLetterThreshold(ctx)
+}
+
+// checkDeadLetterThreshold re-reads the dead letter queue size and raises or re-arms the
+// threshold alert accordingly. It runs only when the queue is written to or deleted from.
+func (f *observabilityForwarder) checkDeadLetterThreshold(ctx context.Context) {
+	if f.cfg.DeadLetterAlertThreshold <= 0 || f.store == nil {
+		return
 	}
+	count, err := f.store.GetDeadLetterQueueCount(ctx)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Msg("failed to read dead letter queue size for alerting")
+		return
+	}
+	f.observeDeadLetterCount(count)
+}
+
+// observeDeadLetterCount is edge-triggered: the alert fires only on the transition above
+// the threshold, not for every entry added while the queue stays above it.
+func (f *observabilityForwarder) observeDeadLetterCount(count int64) {
+	threshold := f.cfg.DeadLetterAlertThreshold
+	if threshold <= 0 {
+		return
+	}
+	if count <= threshold {
+		f.deadLetterAlerting.Store(false)
+		return
+	}
+	if !f.deadLetterAlerting.CompareAndSwap(false, true) {
+		return
+	}
+
+	logger.Logger.Error().
+		Int64("dead_letter_count", count).
+		Int64("threshold", threshold).
+		Msg("observability dead letter queue exceeded alert threshold")
+	events.GlobalSystemEventBus.Publish(events.SystemEvent{
+		Type:      events.DeadLetterThresholdExceeded,
+		Timestamp: time.Now().UTC(),
+		Data: map[string]interface{}{
+			"dead_letter_count": count,
+			"threshold":         threshold,
+			"source_instance":   f.cfg.InstanceID,
+		},
+	})
 }
 
 // doSend performs the actual HTTP request.
 func (f *observabilityForwarder) doSend(cfg *types.ObservabilityWebhookConfig, body []byte) error {
-	ctx, cancel := context.WithTimeout(f.ctx, f.cfg.HTTPTimeout)
+	_, err := f.doSendWithContext(f.ctx, cfg, body)
+	return err
+}
+
+// doSendWithContext posts body to the webhook and returns the response status code.
+// When MaxConcurrentDeliveries is set it first waits for a free delivery slot.
+func (f *observabilityForwarder) doSendWithContext(parent context.Context, cfg *types.ObservabilityWebhookConfig, body []byte) (int, error) {
+	if !f.acquireDeliverySlot(parent) {
+		return 0, parent.Err()
+	}
+	defer f.releaseDeliverySlot()
+	return f.postWebhook(parent, cfg, body)
+}
+
+// postWebhook posts body to the webhook and returns the response status code. Callers
+// are responsible for holding a delivery slot.
+func (f *observabilityForwarder) postWebhook(parent context.Context, cfg *types.ObservabilityWebhookConfig, body []byte) (int, error) {
+	f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+
+	ctx, cancel := context.WithTimeout(parent, f.cfg.HTTPTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("build request: %w", err)
+		return 0, fmt.Errorf("build request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "AgentField-Observability/1.0")
+	req.Header.Set("User-Agent", f.cfg.UserAgent)
 
 	// Custom headers
 	for key, value := range cfg.Headers {
@@ -592,18 +1557,84 @@ func (f *observabilityForwarder) doSend(cfg *types.ObservabilityWebhookConfig, b
 
 	resp, err := f.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("http request: %w", err)
+		return 0, fmt.Errorf("http request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		// Keep what the receiver said so it reaches LastError and the dead letter queue.
+		if snippet := readResponseSnippet(resp.Body, f.cfg.ResponseBodyLimit); snippet != "" {
+			return resp.StatusCode, fmt.Errorf("non-2xx response: %d: %s", resp.StatusCode, snippet)
+		}
+		return resp.StatusCode, fmt.Errorf("non-2xx response: %d", resp.StatusCode)
+	}
+
 	// Read response body (limited)
 	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, int64(f.cfg.ResponseBodyLimit)))
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		return fmt.Errorf("non-2xx response: %d", resp.StatusCode)
+	return resp.StatusCode, nil
+}
+
+// readResponseSnippet reads at most limit bytes of body for error reporting, marking the
+// snippet when the body was longer.
+func readResponseSnippet(body io.Reader, limit int) string {
+	buf, _ := io.ReadAll(io.LimitReader(body, int64(limit)+1))
+	truncated := len(buf) > limit
+	if truncated {
+		buf = buf[:limit]
+	}
+	snippet := strings.TrimSpace(strings.ToValidUTF8(string(buf), ""))
+	if truncated && snippet != "" {
+		snippet += "...(truncated)"
+	}
+	return snippet
+}
+
+// withResolvedWebhookURL returns cfg with any URL placeholders substituted for events,
+// copying the config only when the URL actually changes.
+func withResolvedWebhookURL(cfg *types.ObservabilityWebhookConfig, events []types.ObservabilityEvent) *types.ObservabilityWebhookConfig {
+	resolved := ResolveObservabilityWebhookURL(cfg.URL, events)
+	if resolved == cfg.URL {
+		return cfg
 	}
+	copied := *cfg
+	copied.URL = resolved
+	return &copied
+}
 
-	return nil
+// ResolveObservabilityWebhookURL substitutes the {event_source} and {event_type}
+// placeholders in rawURL when every event in the batch shares that value. If any
+// placeholder cannot be resolved uniformly the literal URL is returned unchanged.
+func ResolveObservabilityWebhookURL(rawURL string, events []types.ObservabilityEvent) string {
+	if !strings.Contains(rawURL, "{") || len(events) == 0 {
+		return rawURL
+	}
+
+	placeholders := []struct {
+		token string
+		value func(types.ObservabilityEvent) string
+	}{
+		{ObservabilityURLPlaceholderEventSource, func(e types.ObservabilityEvent) string { return e.EventSource }},
+		{ObservabilityURLPlaceholderEventType, func(e types.ObservabilityEvent) string { return e.EventType }},
+	}
+
+	resolved := rawURL
+	for _, placeholder := range placeholders {
+		if !strings.Contains(resolved, placeholder.token) {
+			continue
+		}
+		value := placeholder.value(events[0])
+		for _, event := range events[1:] {
+			if placeholder.value(event) != value {
+				return rawURL
+			}
+		}
+		if value == "" {
+			return rawURL
+		}
+		resolved = strings.ReplaceAll(resolved, placeholder.token, url.PathEscape(value))
+	}
+	return resolved
 }
 
 // computeBackoff calculates exponential backoff duration.
@@ -618,6 +1649,16 @@ func (f *observabilityForwarder) computeBackoff(attempt int) time.Duration {
 	return backoff
 }
 
+// retryDelay returns the backoff for the given attempt with up to 20% jitter subtracted,
+// so concurrent workers spread their retries without exceeding MaxRetryBackoff.
+func (f *observabilityForwarder) retryDelay(attempt int) time.Duration {
+	backoff := f.computeBackoff(attempt)
+	if spread := int64(backoff / 5); spread > 0 {
+		backoff -= time.Duration(rand.Int63n(spread + 1))
+	}
+	return backoff
+}
+
 // Event transformers
 
 func (f *observabilityForwarder) transformExecutionEvent(e events.ExecutionEvent) types.ObservabilityEvent {
@@ -632,10 +1673,11 @@ func (f *observabilityForwarder) transformExecutionEvent(e events.ExecutionEvent
 	}
 
 	return types.ObservabilityEvent{
-		EventType:   string(e.Type),
-		EventSource: "execution",
-		Timestamp:   e.Timestamp.Format(time.RFC3339),
-		Data:        data,
+		EventType:      string(e.Type),
+		EventSource:    "execution",
+		SourceInstance: f.cfg.InstanceID,
+		Timestamp:      e.Timestamp.Format(time.RFC3339),
+		Data:           data,
 	}
 }
 
@@ -661,10 +1703,11 @@ func (f *observabilityForwarder) transformNodeEvent(e events.NodeEvent) types.Ob
 	}
 
 	return types.ObservabilityEvent{
-		EventType:   string(e.Type),
-		EventSource: "node",
-		Timestamp:   e.Timestamp.Format(time.RFC3339),
-		Data:        data,
+		EventType:      string(e.Type),
+		EventSource:    "node",
+		SourceInstance: f.cfg.InstanceID,
+		Timestamp:      e.Timestamp.Format(time.RFC3339),
+		Data:           data,
 	}
 }
 
@@ -679,10 +1722,47 @@ func (f *observabilityForwarder) transformReasonerEvent(e events.ReasonerEvent)
 	}
 
 	return types.ObservabilityEvent{
-		EventType:   string(e.Type),
-		EventSource: "reasoner",
-		Timestamp:   e.Timestamp.Format(time.RFC3339),
-		Data:        data,
+		EventType:      string(e.Type),
+		EventSource:    "reasoner",
+		SourceInstance: f.cfg.InstanceID,
+		Timestamp:      e.Timestamp.Format(time.RFC3339),
+		Data:           data,
+	}
+}
+
+func (f *observabilityForwarder) transformCustomEvent(e events.CustomEvent) types.ObservabilityEvent {
+	data := map[string]interface{}{
+		"agent_node_id": e.AgentNodeID,
+	}
+	if e.ExecutionID != "" {
+		data["execution_id"] = e.ExecutionID
+	}
+	if e.WorkflowID != "" {
+		data["workflow_id"] = e.WorkflowID
+	}
+	if e.RunID != "" {
+		data["run_id"] = e.RunID
+	}
+	if e.Data != nil {
+		data["payload"] = e.Data
+	}
+
+	return types.ObservabilityEvent{
+		EventType:      e.Type,
+		EventSource:    "agent",
+		SourceInstance: f.cfg.InstanceID,
+		Timestamp:      e.Timestamp.Format(time.RFC3339),
+		Data:           data,
+	}
+}
+
+func (f *observabilityForwarder) transformSystemEvent(e events.SystemEvent) types.ObservabilityEvent {
+	return types.ObservabilityEvent{
+		EventType:      string(e.Type),
+		EventSource:    "control_plane",
+		SourceInstance: f.cfg.InstanceID,
+		Timestamp:      e.Timestamp.Format(time.RFC3339),
+		Data:           e.Data,
 	}
 }
 
@@ -3,9 +3,12 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -69,6 +72,24 @@ func (m *mockObservabilityStore) GetDeadLetterQueueCount(ctx context.Context) (i
 	return int64(len(m.dlqEntries)), nil
 }
 
+func (m *mockObservabilityStore) GetDeadLetterQueueAgeBuckets(ctx context.Context, now time.Time) (types.ObservabilityDeadLetterAgeBuckets, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var buckets types.ObservabilityDeadLetterAgeBuckets
+	for _, entry := range m.dlqEntries {
+		switch age := now.Sub(entry.CreatedAt); {
+		case age < time.Hour:
+			buckets.UnderOneHour++
+		case age < 24*time.Hour:
+			buckets.OneHourToOneDay++
+		default:
+			buckets.OverOneDay++
+		}
+	}
+	return buckets, nil
+}
+
 func (m *mockObservabilityStore) GetDeadLetterQueue(ctx context.Context, limit, offset int) ([]types.ObservabilityDeadLetterEntry, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -85,7 +106,7 @@ func (m *mockObservabilityStore) GetDeadLetterQueue(ctx context.Context, limit,
 	return m.dlqEntries[offset:end], nil
 }
 
-func (m *mockObservabilityStore) DeleteFromDeadLetterQueue(ctx context.Context, ids []int64) error {
+func (m *mockObservabilityStore) DeleteFromDeadLetterQueue(ctx context.Context, ids []int64) (int64, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -100,8 +121,9 @@ func (m *mockObservabilityStore) DeleteFromDeadLetterQueue(ctx context.Context,
 			newEntries = append(newEntries, entry)
 		}
 	}
+	deleted := int64(len(m.dlqEntries) - len(newEntries))
 	m.dlqEntries = newEntries
-	return nil
+	return deleted, nil
 }
 
 func (m *mockObservabilityStore) ClearDeadLetterQueue(ctx context.Context) error {
@@ -126,6 +148,8 @@ func TestNormalizeObservabilityConfig(t *testing.T) {
 		require.Equal(t, 2, normalized.WorkerCount)
 		require.Equal(t, 1000, normalized.QueueSize)
 		require.Equal(t, 16*1024, normalized.ResponseBodyLimit)
+		require.Equal(t, 1.0, normalized.SampleRate)
+		require.Equal(t, ObservabilityOrderNone, normalized.OrderBy)
 	})
 
 	t.Run("preserves custom values", func(t *testing.T) {
@@ -191,6 +215,47 @@ func TestObservabilityForwarder_StartStop(t *testing.T) {
 }
 
 // Test forwarder requires store
+func TestObservabilityForwarder_Healthy(t *testing.T) {
+	store := newMockObservabilityStore()
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{WorkerCount: 2}).(*observabilityForwarder)
+	require.False(t, forwarder.Healthy(), "not healthy before Start")
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	require.True(t, forwarder.Healthy())
+
+	status := forwarder.GetStatus()
+	require.True(t, status.Healthy)
+	require.Len(t, status.WorkerLastProcessedAt, 2)
+
+	require.NoError(t, forwarder.Stop(ctx))
+	require.False(t, forwarder.Healthy())
+	require.Zero(t, forwarder.runningWorkers.Load())
+	require.Zero(t, forwarder.activeSubscriptions.Load())
+}
+
+func TestObservabilityForwarder_UnhealthyWhenQueueSaturated(t *testing.T) {
+	forwarder := NewObservabilityForwarder(newMockObservabilityStore(), ObservabilityForwarderConfig{
+		WorkerCount: 1,
+		QueueSize:   1,
+	}).(*observabilityForwarder)
+
+	// Simulate a running forwarder whose only worker stopped draining a full queue.
+	forwarder.ctx, forwarder.cancel = context.WithCancel(context.Background())
+	defer forwarder.cancel()
+	forwarder.eventQueue = make(chan types.ObservabilityEvent, 1)
+	forwarder.workerLastProcessed = make([]atomic.Int64, 1)
+	forwarder.runningWorkers.Store(1)
+	forwarder.activeSubscriptions.Store(observabilitySubscriptionCount)
+
+	forwarder.workerLastProcessed[0].Store(time.Now().UnixNano())
+	forwarder.eventQueue <- types.ObservabilityEvent{EventType: "execution_created"}
+	require.True(t, forwarder.Healthy(), "a freshly full queue is not yet saturated")
+
+	forwarder.workerLastProcessed[0].Store(time.Now().Add(-2 * observabilitySaturationWindow).UnixNano())
+	require.False(t, forwarder.Healthy())
+}
+
 func TestObservabilityForwarder_RequiresStore(t *testing.T) {
 	cfg := ObservabilityForwarderConfig{}
 	forwarder := NewObservabilityForwarder(nil, cfg)
@@ -241,6 +306,61 @@ func TestObservabilityForwarder_ReloadConfig(t *testing.T) {
 }
 
 // Test status reporting
+// flakyConfigStore fails the first failures calls to GetObservabilityWebhook.
+type flakyConfigStore struct {
+	*mockObservabilityStore
+	failures atomic.Int32
+	calls    atomic.Int32
+}
+
+func (s *flakyConfigStore) GetObservabilityWebhook(ctx context.Context) (*types.ObservabilityWebhookConfig, error) {
+	s.calls.Add(1)
+	if s.failures.Add(-1) >= 0 {
+		return nil, fmt.Errorf("database is locked")
+	}
+	return s.mockObservabilityStore.GetObservabilityWebhook(ctx)
+}
+
+func TestObservabilityForwarder_StartRetriesInitialConfigLoad(t *testing.T) {
+	newStore := func(failures int32) *flakyConfigStore {
+		store := &flakyConfigStore{mockObservabilityStore: newMockObservabilityStore()}
+		store.failures.Store(failures)
+		store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+			ID:      "global",
+			URL:     "https://example.com/webhook",
+			Enabled: true,
+		})
+		return store
+	}
+	cfg := ObservabilityForwarderConfig{
+		RetryBackoff:    5 * time.Millisecond,
+		MaxRetryBackoff: 20 * time.Millisecond,
+	}
+	ctx := context.Background()
+
+	t.Run("recovers within the initial attempts", func(t *testing.T) {
+		store := newStore(observabilityInitialConfigAttempts - 1)
+		forwarder := NewObservabilityForwarder(store, cfg)
+		require.NoError(t, forwarder.Start(ctx))
+		defer forwarder.Stop(ctx)
+
+		require.True(t, forwarder.GetStatus().Enabled)
+		require.Equal(t, int32(observabilityInitialConfigAttempts), store.calls.Load())
+	})
+
+	t.Run("falls back to background reloads", func(t *testing.T) {
+		store := newStore(observabilityInitialConfigAttempts + 2)
+		forwarder := NewObservabilityForwarder(store, cfg)
+		require.NoError(t, forwarder.Start(ctx))
+		defer forwarder.Stop(ctx)
+
+		require.False(t, forwarder.GetStatus().Enabled)
+		require.Eventually(t, func() bool {
+			return forwarder.GetStatus().Enabled
+		}, 2*time.Second, 10*time.Millisecond)
+	})
+}
+
 func TestObservabilityForwarder_GetStatus(t *testing.T) {
 	store := newMockObservabilityStore()
 	cfg := ObservabilityForwarderConfig{
@@ -271,6 +391,32 @@ func TestObservabilityForwarder_GetStatus(t *testing.T) {
 	require.Equal(t, int64(0), status.DeadLetterCount)
 }
 
+// Test that status reports dead letter queue entries bucketed by age
+func TestObservabilityForwarder_GetStatusDeadLetterAgeBuckets(t *testing.T) {
+	store := newMockObservabilityStore()
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{})
+
+	require.Nil(t, forwarder.GetStatus().DeadLetterAgeBuckets)
+
+	now := time.Now().UTC()
+	for i, age := range []time.Duration{time.Minute, 3 * time.Hour, 48 * time.Hour, 96 * time.Hour} {
+		store.dlqEntries = append(store.dlqEntries, types.ObservabilityDeadLetterEntry{
+			ID:        int64(i + 1),
+			EventType: "execution_failed",
+			CreatedAt: now.Add(-age),
+		})
+	}
+
+	status := forwarder.GetStatus()
+	require.Equal(t, int64(4), status.DeadLetterCount)
+	require.NotNil(t, status.DeadLetterAgeBuckets)
+	require.Equal(t, types.ObservabilityDeadLetterAgeBuckets{
+		UnderOneHour:    1,
+		OneHourToOneDay: 1,
+		OverOneDay:      2,
+	}, *status.DeadLetterAgeBuckets)
+}
+
 // Test event transformation - execution events
 func TestObservabilityForwarder_TransformExecutionEvent(t *testing.T) {
 	store := newMockObservabilityStore()
@@ -363,6 +509,32 @@ func TestObservabilityForwarder_TransformReasonerEvent(t *testing.T) {
 	require.Equal(t, reasonerEvent.Data, data["payload"])
 }
 
+func TestObservabilityForwarder_TransformCustomEvent(t *testing.T) {
+	store := newMockObservabilityStore()
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{}).(*observabilityForwarder)
+
+	customEvent := events.CustomEvent{
+		Type:        "approval_requested",
+		AgentNodeID: "node-456",
+		ExecutionID: "exec-123",
+		Timestamp:   time.Now(),
+		Data:        map[string]interface{}{"amount": 10},
+	}
+
+	obsEvent := forwarder.transformCustomEvent(customEvent)
+
+	require.Equal(t, "approval_requested", obsEvent.EventType)
+	require.Equal(t, "agent", obsEvent.EventSource)
+	require.NotEmpty(t, obsEvent.Timestamp)
+
+	data, ok := obsEvent.Data.(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "node-456", data["agent_node_id"])
+	require.Equal(t, "exec-123", data["execution_id"])
+	require.NotContains(t, data, "workflow_id")
+	require.Equal(t, customEvent.Data, data["payload"])
+}
+
 // Test backoff computation
 func TestObservabilityForwarder_ComputeBackoff(t *testing.T) {
 	store := newMockObservabilityStore()
@@ -501,6 +673,178 @@ func TestObservabilityForwarder_WebhookDelivery(t *testing.T) {
 	require.Greater(t, status.EventsForwarded, int64(0))
 }
 
+func TestObservabilityForwarder_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	cfg := ObservabilityForwarderConfig{
+		BatchSize:    4,
+		BatchTimeout: 10 * time.Second,
+		WorkerCount:  1,
+	}
+
+	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	enqueue := func(source string) {
+		forwarder.enqueueEvent(types.ObservabilityEvent{
+			EventType:   source + "_event",
+			EventSource: source,
+			Timestamp:   time.Now().Format(time.RFC3339),
+		})
+	}
+	for _, source := range []string{"execution", "execution", "node", "agent"} {
+		enqueue(source)
+	}
+
+	require.Eventually(t, func() bool {
+		return forwarder.Metrics().BatchesDelivered == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// Half of the next batch is collected but not yet sent.
+	enqueue("reasoner")
+	enqueue("reasoner")
+	require.Eventually(t, func() bool {
+		return forwarder.Metrics().BatchFillRatio == 0.5
+	}, 2*time.Second, 10*time.Millisecond)
+
+	metrics := forwarder.Metrics()
+	require.Equal(t, map[string]int64{"execution": 2, "node": 1, "agent": 1, "reasoner": 2}, metrics.EnqueuedBySource)
+	require.Equal(t, map[string]int64{"execution": 2, "node": 1, "agent": 1}, metrics.ForwardedBySource)
+	require.GreaterOrEqual(t, metrics.AverageDeliveryLatencyMs, float64(10))
+
+	// GetStatus is unaffected.
+	require.Equal(t, int64(4), forwarder.GetStatus().EventsForwarded)
+}
+
+// Test that delivered events and batches carry the configured instance ID
+func TestObservabilityForwarder_SourceInstance(t *testing.T) {
+	received := make(chan types.ObservabilityEventBatch, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch types.ObservabilityEventBatch
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		received <- batch
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{
+		BatchSize:    2,
+		BatchTimeout: 100 * time.Millisecond,
+		WorkerCount:  1,
+		InstanceID:   "cp-replica-a",
+	}).(*observabilityForwarder)
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	forwarder.enqueueEvent(forwarder.transformNodeEvent(events.NodeEvent{
+		Type:      events.NodeOnline,
+		NodeID:    "node-1",
+		Timestamp: time.Now(),
+	}))
+	forwarder.enqueueEvent(types.ObservabilityEvent{
+		EventType:   "execution_completed",
+		EventSource: "execution",
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Data:        map[string]interface{}{"execution_id": "exec-1"},
+	})
+
+	select {
+	case batch := <-received:
+		require.Equal(t, "cp-replica-a", batch.SourceInstance)
+		require.Len(t, batch.Events, 2)
+		for _, event := range batch.Events {
+			require.Equal(t, "cp-replica-a", event.SourceInstance)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for webhook delivery")
+	}
+
+	defaulted := NewObservabilityForwarder(store, ObservabilityForwarderConfig{}).(*observabilityForwarder)
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	require.Equal(t, hostname, defaulted.cfg.InstanceID)
+}
+
+// Test that templated webhook URLs are substituted per batch
+func TestObservabilityForwarder_TemplatedURL(t *testing.T) {
+	paths := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL + "/ingest/{event_source}/{event_type}",
+		Enabled: true,
+	})
+
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{
+		BatchSize:    2,
+		BatchTimeout: time.Hour,
+		WorkerCount:  1,
+	}).(*observabilityForwarder)
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	for i := 0; i < 2; i++ {
+		forwarder.enqueueEvent(types.ObservabilityEvent{
+			EventType:   "execution_completed",
+			EventSource: "execution",
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Data:        map[string]interface{}{"execution_id": fmt.Sprintf("exec-%d", i)},
+		})
+	}
+
+	select {
+	case path := <-paths:
+		require.Equal(t, "/ingest/execution/execution_completed", path)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for webhook delivery")
+	}
+}
+
+func TestResolveObservabilityWebhookURL(t *testing.T) {
+	nodeOnline := types.ObservabilityEvent{EventSource: "node", EventType: "node_online"}
+	nodeOffline := types.ObservabilityEvent{EventSource: "node", EventType: "node_offline"}
+
+	require.Equal(t, "https://example.com/hook",
+		ResolveObservabilityWebhookURL("https://example.com/hook", []types.ObservabilityEvent{nodeOnline}))
+	require.Equal(t, "https://example.com/node/node_online",
+		ResolveObservabilityWebhookURL("https://example.com/{event_source}/{event_type}", []types.ObservabilityEvent{nodeOnline, nodeOnline}))
+	require.Equal(t, "https://example.com/node",
+		ResolveObservabilityWebhookURL("https://example.com/{event_source}", []types.ObservabilityEvent{nodeOnline, nodeOffline}))
+	require.Equal(t, "https://example.com/{event_source}/{event_type}",
+		ResolveObservabilityWebhookURL("https://example.com/{event_source}/{event_type}", []types.ObservabilityEvent{nodeOnline, nodeOffline}))
+}
+
 // Test webhook delivery with HMAC signature
 func TestObservabilityForwarder_WebhookWithSignature(t *testing.T) {
 	var (
@@ -624,6 +968,165 @@ func TestObservabilityForwarder_WebhookWithCustomHeaders(t *testing.T) {
 	require.Equal(t, "Bearer token123", ah)
 }
 
+// Test that MaxConcurrentDeliveries bounds in-flight webhook requests
+func TestObservabilityForwarder_MaxConcurrentDeliveries(t *testing.T) {
+	var (
+		current     int32
+		maxObserved int32
+		delivered   int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			prev := atomic.LoadInt32(&maxObserved)
+			if n <= prev || atomic.CompareAndSwapInt32(&maxObserved, prev, n) {
+				break
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		atomic.AddInt32(&delivered, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{
+		BatchSize:               1,
+		BatchTimeout:            50 * time.Millisecond,
+		WorkerCount:             4,
+		MaxConcurrentDeliveries: 2,
+	}).(*observabilityForwarder)
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	const total = 8
+	for i := 0; i < total; i++ {
+		forwarder.enqueueEvent(types.ObservabilityEvent{
+			EventType:   "execution_completed",
+			EventSource: "execution",
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Data:        map[string]interface{}{"execution_id": fmt.Sprintf("exec-%d", i)},
+		})
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&delivered) < total && time.Now().Before(deadline) {
+		require.LessOrEqual(t, forwarder.GetStatus().InFlightDeliveries, 2)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	require.Equal(t, int32(total), atomic.LoadInt32(&delivered))
+	require.Equal(t, int32(2), atomic.LoadInt32(&maxObserved))
+	require.Eventually(t, func() bool {
+		return forwarder.GetStatus().InFlightDeliveries == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+// Test that a worker keeps batching while its earlier batch is still being delivered
+func TestObservabilityForwarder_WorkerHandsOffDeliveries(t *testing.T) {
+	var (
+		current     int32
+		maxObserved int32
+		delivered   int32
+	)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			prev := atomic.LoadInt32(&maxObserved)
+			if n <= prev || atomic.CompareAndSwapInt32(&maxObserved, prev, n) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&current, -1)
+		atomic.AddInt32(&delivered, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{
+		BatchSize:               1,
+		BatchTimeout:            50 * time.Millisecond,
+		WorkerCount:             1,
+		MaxConcurrentDeliveries: 2,
+	}).(*observabilityForwarder)
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	for i := 0; i < 3; i++ {
+		forwarder.enqueueEvent(types.ObservabilityEvent{
+			EventType:   "execution_completed",
+			EventSource: "execution",
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Data:        map[string]interface{}{"execution_id": fmt.Sprintf("exec-%d", i)},
+		})
+	}
+
+	// A single worker has two deliveries in flight and waits on the third.
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&current) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(2), atomic.LoadInt32(&maxObserved))
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&delivered) == 3
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// Test configurable User-Agent and header override
+func TestObservabilityForwarder_UserAgent(t *testing.T) {
+	userAgents := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents <- r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{UserAgent: "Acme-Ingest/2.0"})
+	require.True(t, forwarder.TestWebhook(context.Background()).Success)
+	require.Equal(t, "Acme-Ingest/2.0", <-userAgents)
+
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Headers: map[string]string{"User-Agent": "Header-Override/3.0"},
+		Enabled: true,
+	})
+	require.True(t, forwarder.TestWebhook(context.Background()).Success)
+	require.Equal(t, "Header-Override/3.0", <-userAgents)
+
+	defaulted := NewObservabilityForwarder(store, ObservabilityForwarderConfig{}).(*observabilityForwarder)
+	require.Equal(t, DefaultObservabilityUserAgent, defaulted.cfg.UserAgent)
+}
+
 // Test DLQ on delivery failure
 func TestObservabilityForwarder_DeadLetterQueueOnFailure(t *testing.T) {
 	failureCount := int32(0)
@@ -686,13 +1189,10 @@ func TestObservabilityForwarder_DeadLetterQueueOnFailure(t *testing.T) {
 	require.NotNil(t, status.LastError)
 }
 
-// Test redrive functionality
-func TestObservabilityForwarder_Redrive(t *testing.T) {
-	successCount := int32(0)
-
+func TestObservabilityForwarder_DeadLetterRecordsResponseBody(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		atomic.AddInt32(&successCount, 1)
-		w.WriteHeader(http.StatusOK)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"field event_type is required"} ` + strings.Repeat("x", 100)))
 	}))
 	defer server.Close()
 
@@ -703,20 +1203,294 @@ func TestObservabilityForwarder_Redrive(t *testing.T) {
 		Enabled: true,
 	})
 
-	// Pre-populate DLQ with entries
-	for i := 0; i < 3; i++ {
-		event := &types.ObservabilityEvent{
-			EventType:   "test_event",
-			EventSource: "test",
-			Timestamp:   time.Now().Format(time.RFC3339),
-			Data:        map[string]interface{}{"id": i},
-		}
-		store.AddToDeadLetterQueue(context.Background(), event, "previous failure", 3)
+	cfg := ObservabilityForwarderConfig{
+		BatchSize:         1,
+		BatchTimeout:      50 * time.Millisecond,
+		WorkerCount:       1,
+		MaxAttempts:       1,
+		RetryBackoff:      10 * time.Millisecond,
+		MaxRetryBackoff:   50 * time.Millisecond,
+		ResponseBodyLimit: 48,
 	}
 
-	// Verify DLQ has entries
-	count, _ := store.GetDeadLetterQueueCount(context.Background())
-	require.Equal(t, int64(3), count)
+	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	forwarder.enqueueEvent(types.ObservabilityEvent{
+		EventType:   "execution_created",
+		EventSource: "execution",
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Data:        map[string]interface{}{"execution_id": "exec-body"},
+	})
+
+	require.Eventually(t, func() bool {
+		count, _ := store.GetDeadLetterQueueCount(ctx)
+		return count == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	entries, err := store.GetDeadLetterQueue(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Contains(t, entries[0].ErrorMessage, `non-2xx response: 400: {"error":"field event_type is required"}`)
+	require.Contains(t, entries[0].ErrorMessage, "...(truncated)")
+	require.NotContains(t, entries[0].ErrorMessage, strings.Repeat("x", 20))
+
+	status := forwarder.GetStatus()
+	require.NotNil(t, status.LastError)
+	require.Contains(t, *status.LastError, "field event_type is required")
+}
+
+func TestObservabilityForwarder_PauseResume(t *testing.T) {
+	var delivered int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch types.ObservabilityEventBatch
+		_ = json.NewDecoder(r.Body).Decode(&batch)
+		atomic.AddInt32(&delivered, int32(batch.EventCount))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	cfg := ObservabilityForwarderConfig{
+		BatchSize:    1,
+		BatchTimeout: 20 * time.Millisecond,
+		WorkerCount:  1,
+		QueueSize:    2,
+	}
+
+	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	forwarder.Pause()
+	require.True(t, forwarder.GetStatus().Paused)
+
+	enqueue := func(id string) {
+		forwarder.enqueueEvent(types.ObservabilityEvent{
+			EventType:   "execution_created",
+			EventSource: "execution",
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Data:        map[string]interface{}{"execution_id": id},
+		})
+	}
+
+	// The worker picks up the first event and holds it; the next two fill the queue.
+	enqueue("exec-1")
+	require.Eventually(t, func() bool {
+		return forwarder.GetStatus().QueueDepth == 0
+	}, time.Second, 5*time.Millisecond)
+	enqueue("exec-2")
+	enqueue("exec-3")
+	// With the queue full, further events go to the dead letter queue instead of being lost.
+	enqueue("exec-4")
+
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int32(0), atomic.LoadInt32(&delivered), "nothing is delivered while paused")
+	status := forwarder.GetStatus()
+	require.Equal(t, 2, status.QueueDepth)
+	require.Equal(t, int64(1), status.DeadLetterCount)
+	require.True(t, status.Healthy)
+
+	forwarder.Resume()
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&delivered) == 3
+	}, 2*time.Second, 10*time.Millisecond)
+
+	status = forwarder.GetStatus()
+	require.False(t, status.Paused)
+	require.Equal(t, int64(3), status.EventsForwarded)
+
+	entries, err := store.GetDeadLetterQueue(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Contains(t, entries[0].ErrorMessage, "paused")
+}
+
+func TestObservabilityForwarder_DeadLetterAlertThreshold(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{
+		WorkerCount:              1,
+		MaxAttempts:              1,
+		DeadLetterAlertThreshold: 2,
+	}).(*observabilityForwarder)
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	subscriberID := "test-dlq-alert-" + t.Name()
+	alerts := events.GlobalSystemEventBus.Subscribe(subscriberID)
+	defer events.GlobalSystemEventBus.Unsubscribe(subscriberID)
+
+	fail := func(n int) {
+		for i := 0; i < n; i++ {
+			forwarder.sendBatch([]types.ObservabilityEvent{{
+				EventType:   "execution_failed",
+				EventSource: "execution",
+				Timestamp:   time.Now().Format(time.RFC3339),
+				Data:        map[string]interface{}{"execution_id": fmt.Sprintf("exec-%d", i)},
+			}})
+		}
+	}
+	drain := func() []events.SystemEvent {
+		var received []events.SystemEvent
+		for {
+			select {
+			case event := <-alerts:
+				if event.Type == events.DeadLetterThresholdExceeded {
+					received = append(received, event)
+				}
+			case <-time.After(50 * time.Millisecond):
+				return received
+			}
+		}
+	}
+
+	// Staying at the threshold does not alert.
+	fail(2)
+	require.Empty(t, drain())
+
+	// Crossing it alerts once, no matter how many more entries follow.
+	fail(4)
+	received := drain()
+	require.Len(t, received, 1)
+	data, ok := received[0].Data.(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, int64(3), data["dead_letter_count"])
+	require.Equal(t, int64(2), data["threshold"])
+
+	// Dropping back below re-arms the alert for the next crossing.
+	require.NoError(t, store.ClearDeadLetterQueue(ctx))
+	forwarder.CheckDeadLetterThreshold(ctx)
+	fail(3)
+	require.Len(t, drain(), 1)
+}
+
+func TestObservabilityForwarder_GetStatusDoesNotRaiseDeadLetterAlert(t *testing.T) {
+	store := newMockObservabilityStore()
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{
+		DeadLetterAlertThreshold: 2,
+	}).(*observabilityForwarder)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.AddToDeadLetterQueue(ctx, &types.ObservabilityEvent{EventType: "execution_failed"}, "boom", 1))
+	}
+
+	subscriberID := "test-dlq-status-" + t.Name()
+	alerts := events.GlobalSystemEventBus.Subscribe(subscriberID)
+	defer events.GlobalSystemEventBus.Unsubscribe(subscriberID)
+
+	status := forwarder.GetStatus()
+	require.Equal(t, int64(3), status.DeadLetterCount)
+	require.False(t, forwarder.deadLetterAlerting.Load())
+	select {
+	case event := <-alerts:
+		t.Fatalf("unexpected system event from status poll: %s", event.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestObservabilityForwarder_ForwardsSystemEvents(t *testing.T) {
+	received := make(chan types.ObservabilityEventBatch, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch types.ObservabilityEventBatch
+		if err := json.NewDecoder(r.Body).Decode(&batch); err == nil {
+			received <- batch
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{
+		BatchSize:    1,
+		BatchTimeout: 50 * time.Millisecond,
+		WorkerCount:  1,
+	}).(*observabilityForwarder)
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	// Wait for forwarder to be fully started
+	time.Sleep(100 * time.Millisecond)
+
+	events.GlobalSystemEventBus.Publish(events.SystemEvent{
+		Type:      events.DeadLetterThresholdExceeded,
+		Timestamp: time.Now().UTC(),
+		Data:      map[string]interface{}{"dead_letter_count": 3, "threshold": 2},
+	})
+
+	select {
+	case batch := <-received:
+		require.Len(t, batch.Events, 1)
+		require.Equal(t, string(events.DeadLetterThresholdExceeded), batch.Events[0].EventType)
+		require.Equal(t, "control_plane", batch.Events[0].EventSource)
+	case <-time.After(2 * time.Second):
+		t.Fatal("system event was not forwarded")
+	}
+}
+
+// Test redrive functionality
+func TestObservabilityForwarder_Redrive(t *testing.T) {
+	successCount := int32(0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&successCount, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	// Pre-populate DLQ with entries
+	for i := 0; i < 3; i++ {
+		event := &types.ObservabilityEvent{
+			EventType:   "test_event",
+			EventSource: "test",
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Data:        map[string]interface{}{"id": i},
+		}
+		store.AddToDeadLetterQueue(context.Background(), event, "previous failure", 3)
+	}
+
+	// Verify DLQ has entries
+	count, _ := store.GetDeadLetterQueueCount(context.Background())
+	require.Equal(t, int64(3), count)
 
 	cfg := ObservabilityForwarderConfig{
 		MaxAttempts:  2,
@@ -746,6 +1520,77 @@ func TestObservabilityForwarder_Redrive(t *testing.T) {
 	require.Equal(t, int32(3), atomic.LoadInt32(&successCount))
 }
 
+// Test selective redrive of specific DLQ entries
+func TestObservabilityForwarder_RedriveEntries(t *testing.T) {
+	var mu sync.Mutex
+	var redrivenIDs []float64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch types.ObservabilityEventBatch
+		if err := json.NewDecoder(r.Body).Decode(&batch); err == nil {
+			mu.Lock()
+			for _, event := range batch.Events {
+				if data, ok := event.Data.(map[string]interface{}); ok {
+					redrivenIDs = append(redrivenIDs, data["id"].(float64))
+				}
+			}
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	// Pre-populate DLQ with entries (IDs 1-5)
+	for i := 0; i < 5; i++ {
+		event := &types.ObservabilityEvent{
+			EventType:   "test_event",
+			EventSource: "test",
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Data:        map[string]interface{}{"id": i},
+		}
+		store.AddToDeadLetterQueue(context.Background(), event, "previous failure", 3)
+	}
+
+	cfg := ObservabilityForwarderConfig{
+		MaxAttempts:  2,
+		RetryBackoff: 10 * time.Millisecond,
+	}
+
+	forwarder := NewObservabilityForwarder(store, cfg)
+
+	ctx := context.Background()
+	err := forwarder.Start(ctx)
+	require.NoError(t, err)
+	defer forwarder.Stop(ctx)
+
+	// Redrive a subset plus one unknown ID
+	response := forwarder.RedriveEntries(ctx, []int64{2, 4, 99})
+
+	require.False(t, response.Success)
+	require.Equal(t, 2, response.Processed)
+	require.Equal(t, 1, response.Failed)
+	require.Contains(t, response.Message, "1 not found")
+
+	mu.Lock()
+	require.ElementsMatch(t, []float64{1, 3}, redrivenIDs)
+	mu.Unlock()
+
+	// Only the redriven entries are removed
+	entries, _ := store.GetDeadLetterQueue(ctx, 10, 0)
+	remaining := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		remaining = append(remaining, entry.ID)
+	}
+	require.Equal(t, []int64{1, 3, 5}, remaining)
+}
+
 // Test redrive with webhook not configured
 func TestObservabilityForwarder_RedriveNotConfigured(t *testing.T) {
 	store := newMockObservabilityStore()
@@ -765,18 +1610,454 @@ func TestObservabilityForwarder_RedriveNotConfigured(t *testing.T) {
 	require.Contains(t, response.Message, "not configured")
 }
 
+func TestObservabilityForwarder_RedriveWhilePaused(t *testing.T) {
+	requests := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	ctx := context.Background()
+	require.NoError(t, store.AddToDeadLetterQueue(ctx, &types.ObservabilityEvent{
+		EventType:   "execution_failed",
+		EventSource: "execution",
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}, "previous failure", 3))
+	entries, err := store.GetDeadLetterQueue(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{})
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	forwarder.Pause()
+
+	response := forwarder.Redrive(ctx)
+	require.False(t, response.Success)
+	require.Contains(t, response.Message, "paused")
+
+	response = forwarder.RedriveEntries(ctx, []int64{entries[0].ID})
+	require.False(t, response.Success)
+	require.Contains(t, response.Message, "paused")
+
+	require.Equal(t, int32(0), atomic.LoadInt32(&requests))
+	count, err := store.GetDeadLetterQueueCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
 // Test redrive with partial failures
 func TestObservabilityForwarder_RedrivePartialFailure(t *testing.T) {
 	requestCount := int32(0)
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		count := atomic.AddInt32(&requestCount, 1)
-		// Fail every other request (after all retries)
-		if count%3 == 0 {
-			w.WriteHeader(http.StatusInternalServerError)
-		} else {
-			w.WriteHeader(http.StatusOK)
+		count := atomic.AddInt32(&requestCount, 1)
+		// Fail every other request (after all retries)
+		if count%3 == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	// Add entries to DLQ
+	for i := 0; i < 3; i++ {
+		event := &types.ObservabilityEvent{
+			EventType:   "test_event",
+			EventSource: "test",
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Data:        map[string]interface{}{"id": i},
+		}
+		store.AddToDeadLetterQueue(context.Background(), event, "previous failure", 3)
+	}
+
+	cfg := ObservabilityForwarderConfig{
+		MaxAttempts:  1, // Single attempt per entry
+		RetryBackoff: 10 * time.Millisecond,
+	}
+
+	forwarder := NewObservabilityForwarder(store, cfg)
+
+	ctx := context.Background()
+	err := forwarder.Start(ctx)
+	require.NoError(t, err)
+	defer forwarder.Stop(ctx)
+
+	response := forwarder.Redrive(ctx)
+
+	// Some should succeed, some should fail
+	require.Equal(t, 3, response.Processed+response.Failed)
+}
+
+// Test heartbeat event filtering - node events
+func TestObservabilityForwarder_FiltersNodeHeartbeats(t *testing.T) {
+	for _, forwardHeartbeats := range []bool{false, true} {
+		t.Run(fmt.Sprintf("forward_heartbeats=%v", forwardHeartbeats), func(t *testing.T) {
+			var receivedEvents []types.ObservabilityEvent
+			var mu sync.Mutex
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				var batch types.ObservabilityEventBatch
+				json.Unmarshal(body, &batch)
+
+				mu.Lock()
+				receivedEvents = append(receivedEvents, batch.Events...)
+				mu.Unlock()
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			store := newMockObservabilityStore()
+			store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+				ID:      "global",
+				URL:     server.URL,
+				Enabled: true,
+			})
+
+			cfg := ObservabilityForwarderConfig{
+				BatchSize:         10,
+				BatchTimeout:      200 * time.Millisecond,
+				WorkerCount:       1,
+				ForwardHeartbeats: forwardHeartbeats,
+			}
+
+			forwarder := NewObservabilityForwarder(store, cfg)
+
+			ctx := context.Background()
+			err := forwarder.Start(ctx)
+			require.NoError(t, err)
+			defer forwarder.Stop(ctx)
+
+			// Wait for forwarder to be fully started
+			time.Sleep(100 * time.Millisecond)
+
+			// Publish a mix of events including heartbeats
+			events.PublishNodeOnline("node-1", nil)
+			events.PublishNodeHeartbeat()
+			events.PublishNodeOffline("node-1", nil)
+			events.PublishNodeHeartbeat()
+			events.PublishNodeRegistered("node-2", nil)
+
+			// Wait for batch
+			time.Sleep(500 * time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			require.NotEmpty(t, receivedEvents)
+
+			heartbeats := 0
+			for _, event := range receivedEvents {
+				if event.EventType == "node_heartbeat" {
+					heartbeats++
+				}
+			}
+
+			if forwardHeartbeats {
+				require.GreaterOrEqual(t, heartbeats, 2, "heartbeat events should be delivered when enabled")
+			} else {
+				require.Zero(t, heartbeats, "heartbeat events should be filtered")
+			}
+		})
+	}
+}
+
+// Test heartbeat event filtering - reasoner events
+func TestObservabilityForwarder_FiltersReasonerHeartbeats(t *testing.T) {
+	var receivedEvents []types.ObservabilityEvent
+	var mu sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var batch types.ObservabilityEventBatch
+		json.Unmarshal(body, &batch)
+
+		mu.Lock()
+		receivedEvents = append(receivedEvents, batch.Events...)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	cfg := ObservabilityForwarderConfig{
+		BatchSize:    10,
+		BatchTimeout: 200 * time.Millisecond,
+		WorkerCount:  1,
+	}
+
+	forwarder := NewObservabilityForwarder(store, cfg)
+
+	ctx := context.Background()
+	err := forwarder.Start(ctx)
+	require.NoError(t, err)
+	defer forwarder.Stop(ctx)
+
+	// Publish a mix of events including heartbeats
+	events.PublishReasonerOnline("reasoner-1", "node-1", nil)
+	events.PublishHeartbeat() // Should be filtered
+	events.PublishReasonerOffline("reasoner-1", "node-1", nil)
+	events.PublishHeartbeat() // Should be filtered
+
+	// Wait for batch
+	time.Sleep(500 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Verify no heartbeat events were delivered
+	for _, event := range receivedEvents {
+		require.NotEqual(t, "heartbeat", event.EventType, "heartbeat events should be filtered")
+	}
+}
+
+// Test events not enqueued when webhook disabled
+func TestObservabilityForwarder_NoEnqueueWhenDisabled(t *testing.T) {
+	store := newMockObservabilityStore()
+	// No webhook configured = disabled
+
+	cfg := ObservabilityForwarderConfig{
+		BatchSize:    5,
+		BatchTimeout: 100 * time.Millisecond,
+		WorkerCount:  1,
+	}
+
+	forwarder := NewObservabilityForwarder(store, cfg)
+
+	ctx := context.Background()
+	err := forwarder.Start(ctx)
+	require.NoError(t, err)
+	defer forwarder.Stop(ctx)
+
+	// Publish events
+	events.PublishExecutionCompleted("exec-disabled-1", "wf-1", "agent-1", nil)
+	events.PublishExecutionCompleted("exec-disabled-2", "wf-1", "agent-1", nil)
+
+	// Wait
+	time.Sleep(300 * time.Millisecond)
+
+	// Queue should be empty since webhook is disabled
+	status := forwarder.GetStatus()
+	require.Equal(t, 0, status.QueueDepth)
+	require.Equal(t, int64(0), status.EventsForwarded)
+}
+
+// Test batching behavior
+func TestObservabilityForwarder_BatchingBySize(t *testing.T) {
+	var batchSizes []int
+	var mu sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var batch types.ObservabilityEventBatch
+		json.Unmarshal(body, &batch)
+
+		mu.Lock()
+		batchSizes = append(batchSizes, batch.EventCount)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	cfg := ObservabilityForwarderConfig{
+		BatchSize:    3,                     // Send every 3 events
+		BatchTimeout: 10 * time.Second,      // Long timeout to ensure size-based batching
+		WorkerCount:  1,
+	}
+
+	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
+
+	ctx := context.Background()
+	err := forwarder.Start(ctx)
+	require.NoError(t, err)
+	defer forwarder.Stop(ctx)
+
+	// Wait for forwarder to be fully started
+	time.Sleep(100 * time.Millisecond)
+
+	// Send exactly 6 events (should create 2 batches of 3)
+	for i := 0; i < 6; i++ {
+		forwarder.enqueueEvent(types.ObservabilityEvent{
+			EventType:   "execution_created",
+			EventSource: "execution",
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Data:        map[string]interface{}{"index": i},
+		})
+	}
+
+	// Wait for batches
+	time.Sleep(500 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Should have received batches of size 3
+	require.GreaterOrEqual(t, len(batchSizes), 2)
+	for _, size := range batchSizes {
+		require.LessOrEqual(t, size, 3, "batch size should not exceed configured limit")
+	}
+}
+
+// Test batching by timeout
+func TestObservabilityForwarder_BatchingByTimeout(t *testing.T) {
+	var receivedBatches int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&receivedBatches, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	cfg := ObservabilityForwarderConfig{
+		BatchSize:    100,                    // Large batch size
+		BatchTimeout: 100 * time.Millisecond, // Short timeout
+		WorkerCount:  1,
+	}
+
+	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
+
+	ctx := context.Background()
+	err := forwarder.Start(ctx)
+	require.NoError(t, err)
+	defer forwarder.Stop(ctx)
+
+	// Wait for forwarder to be fully started
+	time.Sleep(100 * time.Millisecond)
+
+	// Send just 1 event (won't fill batch)
+	forwarder.enqueueEvent(types.ObservabilityEvent{
+		EventType:   "execution_created",
+		EventSource: "execution",
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Data:        map[string]interface{}{"execution_id": "exec-timeout-1"},
+	})
+
+	// Wait for timeout-based flush
+	time.Sleep(300 * time.Millisecond)
+
+	// Should have received a batch despite not reaching batch size
+	require.GreaterOrEqual(t, atomic.LoadInt32(&receivedBatches), int32(1), "should send batch on timeout")
+}
+
+// Test deterministic sampling by entity ID
+func TestObservabilityForwarder_DropsExpiredEvents(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch types.ObservabilityEventBatch
+		json.NewDecoder(r.Body).Decode(&batch)
+
+		mu.Lock()
+		for _, event := range batch.Events {
+			data := event.Data.(map[string]interface{})
+			received = append(received, data["execution_id"].(string))
+		}
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{
+		BatchSize:    10,
+		BatchTimeout: 50 * time.Millisecond,
+		WorkerCount:  1,
+		MaxEventAge:  time.Minute,
+	}).(*observabilityForwarder)
+
+	ctx := context.Background()
+	require.NoError(t, forwarder.Start(ctx))
+	defer forwarder.Stop(ctx)
+
+	forwarder.enqueueEvent(types.ObservabilityEvent{
+		EventType:   "execution_completed",
+		EventSource: "execution",
+		Timestamp:   time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+		Data:        map[string]interface{}{"execution_id": "exec-stale"},
+	})
+	forwarder.enqueueEvent(types.ObservabilityEvent{
+		EventType:   "execution_completed",
+		EventSource: "execution",
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		Data:        map[string]interface{}{"execution_id": "exec-fresh"},
+	})
+
+	require.Eventually(t, func() bool {
+		status := forwarder.GetStatus()
+		return status.EventsForwarded == 1 && status.EventsExpired == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"exec-fresh"}, received)
+}
+
+func TestObservabilityForwarder_Sampling(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var batch types.ObservabilityEventBatch
+		json.Unmarshal(body, &batch)
+
+		mu.Lock()
+		for _, event := range batch.Events {
+			data := event.Data.(map[string]interface{})
+			received[data["execution_id"].(string)]++
 		}
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
 	}))
 	defer server.Close()
 
@@ -787,39 +2068,58 @@ func TestObservabilityForwarder_RedrivePartialFailure(t *testing.T) {
 		Enabled: true,
 	})
 
-	// Add entries to DLQ
-	for i := 0; i < 3; i++ {
-		event := &types.ObservabilityEvent{
-			EventType:   "test_event",
-			EventSource: "test",
-			Timestamp:   time.Now().Format(time.RFC3339),
-			Data:        map[string]interface{}{"id": i},
-		}
-		store.AddToDeadLetterQueue(context.Background(), event, "previous failure", 3)
-	}
-
 	cfg := ObservabilityForwarderConfig{
-		MaxAttempts:  1, // Single attempt per entry
-		RetryBackoff: 10 * time.Millisecond,
+		BatchSize:    50,
+		BatchTimeout: 50 * time.Millisecond,
+		WorkerCount:  1,
+		QueueSize:    2000,
+		SampleRate:   0.5,
 	}
 
-	forwarder := NewObservabilityForwarder(store, cfg)
+	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
 
 	ctx := context.Background()
-	err := forwarder.Start(ctx)
-	require.NoError(t, err)
+	require.NoError(t, forwarder.Start(ctx))
 	defer forwarder.Stop(ctx)
 
-	response := forwarder.Redrive(ctx)
+	const executions = 400
+	for i := 0; i < executions; i++ {
+		for _, eventType := range []string{"execution_started", "execution_completed"} {
+			forwarder.enqueueEvent(types.ObservabilityEvent{
+				EventType:   eventType,
+				EventSource: "execution",
+				Timestamp:   time.Now().Format(time.RFC3339),
+				Data:        map[string]interface{}{"execution_id": fmt.Sprintf("exec-sample-%d", i)},
+			})
+		}
+	}
 
-	// Some should succeed, some should fail
-	require.Equal(t, 3, response.Processed+response.Failed)
+	require.Eventually(t, func() bool {
+		status := forwarder.GetStatus()
+		return status.EventsForwarded+status.EventsSampled == 2*executions
+	}, 5*time.Second, 20*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Both events for a kept execution are forwarded together.
+	for id, count := range received {
+		require.Equal(t, 2, count, "execution %s should keep all of its events", id)
+	}
+
+	kept := len(received)
+	require.InDelta(t, executions/2, kept, executions*0.15)
+
+	status := forwarder.GetStatus()
+	require.Equal(t, int64(2*(executions-kept)), status.EventsSampled)
 }
 
-// Test heartbeat event filtering - node events
-func TestObservabilityForwarder_FiltersNodeHeartbeats(t *testing.T) {
-	var receivedEvents []types.ObservabilityEvent
+// Test byte-size based batching
+func TestObservabilityForwarder_BatchingByBytes(t *testing.T) {
 	var mu sync.Mutex
+	var bodySizes []int
+	var batchCounts []int
+	received := make(map[float64]bool)
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, _ := io.ReadAll(r.Body)
@@ -827,7 +2127,12 @@ func TestObservabilityForwarder_FiltersNodeHeartbeats(t *testing.T) {
 		json.Unmarshal(body, &batch)
 
 		mu.Lock()
-		receivedEvents = append(receivedEvents, batch.Events...)
+		bodySizes = append(bodySizes, len(body))
+		batchCounts = append(batchCounts, batch.EventCount)
+		for _, event := range batch.Events {
+			data := event.Data.(map[string]interface{})
+			received[data["index"].(float64)] = true
+		}
 		mu.Unlock()
 
 		w.WriteHeader(http.StatusOK)
@@ -841,42 +2146,57 @@ func TestObservabilityForwarder_FiltersNodeHeartbeats(t *testing.T) {
 		Enabled: true,
 	})
 
+	const maxBytes = 3 * 1024
 	cfg := ObservabilityForwarderConfig{
-		BatchSize:    10,
-		BatchTimeout: 200 * time.Millisecond,
-		WorkerCount:  1,
+		BatchSize:     100,
+		BatchTimeout:  200 * time.Millisecond,
+		WorkerCount:   1,
+		MaxBatchBytes: maxBytes,
 	}
 
-	forwarder := NewObservabilityForwarder(store, cfg)
+	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
 
 	ctx := context.Background()
-	err := forwarder.Start(ctx)
-	require.NoError(t, err)
+	require.NoError(t, forwarder.Start(ctx))
 	defer forwarder.Stop(ctx)
 
-	// Publish a mix of events including heartbeats
-	events.PublishNodeOnline("node-1", nil)
-	events.PublishNodeHeartbeat() // Should be filtered
-	events.PublishNodeOffline("node-1", nil)
-	events.PublishNodeHeartbeat() // Should be filtered
-	events.PublishNodeRegistered("node-2", nil)
+	const total = 10
+	const oversizedIndex = 4
+	for i := 0; i < total; i++ {
+		payload := strings.Repeat("x", 1000)
+		if i == oversizedIndex {
+			payload = strings.Repeat("y", 2*maxBytes)
+		}
+		forwarder.enqueueEvent(types.ObservabilityEvent{
+			EventType:   "execution_completed",
+			EventSource: "execution",
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Data:        map[string]interface{}{"index": i, "payload": payload},
+		})
+	}
 
-	// Wait for batch
-	time.Sleep(500 * time.Millisecond)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == total
+	}, 5*time.Second, 20*time.Millisecond)
 
 	mu.Lock()
 	defer mu.Unlock()
 
-	// Verify no heartbeat events were delivered
-	for _, event := range receivedEvents {
-		require.NotEqual(t, "node_heartbeat", event.EventType, "heartbeat events should be filtered")
+	require.Greater(t, len(bodySizes), 1, "large events should be split across batches")
+	for i, size := range bodySizes {
+		if size > maxBytes {
+			require.Equal(t, 1, batchCounts[i], "only a single oversized event may exceed the byte cap")
+		}
 	}
 }
 
-// Test heartbeat event filtering - reasoner events
-func TestObservabilityForwarder_FiltersReasonerHeartbeats(t *testing.T) {
-	var receivedEvents []types.ObservabilityEvent
+// Test that the byte cap accounts for the full batch envelope, including a long instance ID
+func TestObservabilityForwarder_BatchingByBytesWithInstanceID(t *testing.T) {
 	var mu sync.Mutex
+	var bodySizes []int
+	received := 0
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, _ := io.ReadAll(r.Body)
@@ -884,7 +2204,8 @@ func TestObservabilityForwarder_FiltersReasonerHeartbeats(t *testing.T) {
 		json.Unmarshal(body, &batch)
 
 		mu.Lock()
-		receivedEvents = append(receivedEvents, batch.Events...)
+		bodySizes = append(bodySizes, len(body))
+		received += batch.EventCount
 		mu.Unlock()
 
 		w.WriteHeader(http.StatusOK)
@@ -898,80 +2219,119 @@ func TestObservabilityForwarder_FiltersReasonerHeartbeats(t *testing.T) {
 		Enabled: true,
 	})
 
+	const maxBytes = 1024
 	cfg := ObservabilityForwarderConfig{
-		BatchSize:    10,
-		BatchTimeout: 200 * time.Millisecond,
-		WorkerCount:  1,
+		BatchSize:     100,
+		BatchTimeout:  200 * time.Millisecond,
+		WorkerCount:   1,
+		MaxBatchBytes: maxBytes,
+		InstanceID:    "control-plane-" + strings.Repeat("a", 60),
 	}
 
-	forwarder := NewObservabilityForwarder(store, cfg)
+	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
 
 	ctx := context.Background()
-	err := forwarder.Start(ctx)
-	require.NoError(t, err)
+	require.NoError(t, forwarder.Start(ctx))
 	defer forwarder.Stop(ctx)
 
-	// Publish a mix of events including heartbeats
-	events.PublishReasonerOnline("reasoner-1", "node-1", nil)
-	events.PublishHeartbeat() // Should be filtered
-	events.PublishReasonerOffline("reasoner-1", "node-1", nil)
-	events.PublishHeartbeat() // Should be filtered
+	const total = 20
+	for i := 0; i < total; i++ {
+		forwarder.enqueueEvent(types.ObservabilityEvent{
+			EventType:   "execution_completed",
+			EventSource: "execution",
+			Timestamp:   time.Now().Format(time.RFC3339),
+			Data:        map[string]interface{}{"index": i, "payload": strings.Repeat("x", 150)},
+		})
+	}
 
-	// Wait for batch
-	time.Sleep(500 * time.Millisecond)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return received == total
+	}, 5*time.Second, 20*time.Millisecond)
 
 	mu.Lock()
 	defer mu.Unlock()
 
-	// Verify no heartbeat events were delivered
-	for _, event := range receivedEvents {
-		require.NotEqual(t, "heartbeat", event.EventType, "heartbeat events should be filtered")
+	require.Greater(t, len(bodySizes), 1)
+	for _, size := range bodySizes {
+		require.LessOrEqual(t, size, maxBytes)
 	}
 }
 
-// Test events not enqueued when webhook disabled
-func TestObservabilityForwarder_NoEnqueueWhenDisabled(t *testing.T) {
-	store := newMockObservabilityStore()
-	// No webhook configured = disabled
-
-	cfg := ObservabilityForwarderConfig{
-		BatchSize:    5,
-		BatchTimeout: 100 * time.Millisecond,
-		WorkerCount:  1,
-	}
+// Test that the shutdown drain splits batches by MaxBatchBytes as well as BatchSize
+func TestObservabilityForwarder_DrainRespectsMaxBatchBytes(t *testing.T) {
+	var mu sync.Mutex
+	var bodySizes []int
 
-	forwarder := NewObservabilityForwarder(store, cfg)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodySizes = append(bodySizes, len(body))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
 
-	ctx := context.Background()
-	err := forwarder.Start(ctx)
-	require.NoError(t, err)
-	defer forwarder.Stop(ctx)
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
 
-	// Publish events
-	events.PublishExecutionCompleted("exec-disabled-1", "wf-1", "agent-1", nil)
-	events.PublishExecutionCompleted("exec-disabled-2", "wf-1", "agent-1", nil)
+	const maxBytes = 1024
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{
+		BatchSize:     100,
+		WorkerCount:   1,
+		MaxBatchBytes: maxBytes,
+	}).(*observabilityForwarder)
+	require.NoError(t, forwarder.ReloadConfig(context.Background()))
+
+	queue := make(chan types.ObservabilityEvent, 20)
+	for i := 0; i < cap(queue); i++ {
+		queue <- types.ObservabilityEvent{
+			EventType:   "execution_completed",
+			EventSource: "execution",
+			Timestamp:   time.Now().UTC().Format(time.RFC3339),
+			Data:        map[string]interface{}{"index": i, "payload": strings.Repeat("x", 150)},
+		}
+	}
 
-	// Wait
-	time.Sleep(300 * time.Millisecond)
+	forwarder.drainQueue(queue, nil, 0)
 
-	// Queue should be empty since webhook is disabled
-	status := forwarder.GetStatus()
-	require.Equal(t, 0, status.QueueDepth)
-	require.Equal(t, int64(0), status.EventsForwarded)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Greater(t, len(bodySizes), 1)
+	for _, size := range bodySizes {
+		require.LessOrEqual(t, size, maxBytes)
+	}
 }
 
-// Test batching behavior
-func TestObservabilityForwarder_BatchingBySize(t *testing.T) {
-	var batchSizes []int
+// Test per-entity ordering across multiple workers
+func TestObservabilityForwarder_OrderByExecution(t *testing.T) {
 	var mu sync.Mutex
+	received := make(map[string][]int)
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, _ := io.ReadAll(r.Body)
 		var batch types.ObservabilityEventBatch
 		json.Unmarshal(body, &batch)
 
+		// Slow down some deliveries so unpartitioned workers would interleave
+		if len(batch.Events) > 0 {
+			data := batch.Events[0].Data.(map[string]interface{})
+			if int(data["seq"].(float64))%3 == 0 {
+				time.Sleep(20 * time.Millisecond)
+			}
+		}
+
 		mu.Lock()
-		batchSizes = append(batchSizes, batch.EventCount)
+		for _, event := range batch.Events {
+			data := event.Data.(map[string]interface{})
+			id := data["execution_id"].(string)
+			received[id] = append(received[id], int(data["seq"].(float64)))
+		}
 		mu.Unlock()
 
 		w.WriteHeader(http.StatusOK)
@@ -986,9 +2346,10 @@ func TestObservabilityForwarder_BatchingBySize(t *testing.T) {
 	})
 
 	cfg := ObservabilityForwarderConfig{
-		BatchSize:    3,                     // Send every 3 events
-		BatchTimeout: 10 * time.Second,      // Long timeout to ensure size-based batching
-		WorkerCount:  1,
+		BatchSize:    1,
+		BatchTimeout: 50 * time.Millisecond,
+		WorkerCount:  4,
+		OrderBy:      ObservabilityOrderExecution,
 	}
 
 	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
@@ -1001,35 +2362,165 @@ func TestObservabilityForwarder_BatchingBySize(t *testing.T) {
 	// Wait for forwarder to be fully started
 	time.Sleep(100 * time.Millisecond)
 
-	// Send exactly 6 events (should create 2 batches of 3)
-	for i := 0; i < 6; i++ {
+	const perExecution = 15
+	executions := []string{"exec-a", "exec-b", "exec-c"}
+	for seq := 0; seq < perExecution; seq++ {
+		for _, id := range executions {
+			forwarder.enqueueEvent(types.ObservabilityEvent{
+				EventType:   "execution_updated",
+				EventSource: "execution",
+				Timestamp:   time.Now().Format(time.RFC3339),
+				Data:        map[string]interface{}{"execution_id": id, "seq": seq},
+			})
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, id := range executions {
+			if len(received[id]) < perExecution {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 20*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, id := range executions {
+		for i, seq := range received[id] {
+			require.Equal(t, i, seq, "events for %s delivered out of order", id)
+		}
+	}
+}
+
+// Test that DeliveryDeadline bounds retries and routes the batch to the DLQ
+func TestObservabilityForwarder_DeliveryDeadline(t *testing.T) {
+	requestCount := int32(0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	cfg := ObservabilityForwarderConfig{
+		BatchSize:        1,
+		BatchTimeout:     50 * time.Millisecond,
+		WorkerCount:      1,
+		MaxAttempts:      10,
+		RetryBackoff:     200 * time.Millisecond,
+		MaxRetryBackoff:  time.Second,
+		DeliveryDeadline: 250 * time.Millisecond,
+	}
+
+	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
+
+	ctx := context.Background()
+	err := forwarder.Start(ctx)
+	require.NoError(t, err)
+	defer forwarder.Stop(ctx)
+
+	// Wait for forwarder to be fully started
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	forwarder.enqueueEvent(types.ObservabilityEvent{
+		EventType:   "execution_failed",
+		EventSource: "execution",
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Data:        map[string]interface{}{"execution_id": "exec-deadline"},
+	})
+
+	// Without the deadline, 10 attempts with backoff would take several seconds
+	require.Eventually(t, func() bool {
+		count, _ := store.GetDeadLetterQueueCount(ctx)
+		return count == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Less(t, time.Since(start), time.Second)
+	require.Less(t, atomic.LoadInt32(&requestCount), int32(10))
+
+	entries, err := store.GetDeadLetterQueue(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Contains(t, entries[0].ErrorMessage, "delivery deadline")
+}
+
+func TestObservabilityForwarder_StopDrainsToDeadLetterQueueWithinDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	store := newMockObservabilityStore()
+	store.SetWebhookConfig(&types.ObservabilityWebhookConfig{
+		ID:      "global",
+		URL:     server.URL,
+		Enabled: true,
+	})
+
+	forwarder := NewObservabilityForwarder(store, ObservabilityForwarderConfig{
+		BatchSize:    100,
+		BatchTimeout: time.Minute,
+		WorkerCount:  1,
+		MaxAttempts:  5,
+		HTTPTimeout:  30 * time.Second,
+	}).(*observabilityForwarder)
+
+	require.NoError(t, forwarder.Start(context.Background()))
+
+	for i := 0; i < 3; i++ {
 		forwarder.enqueueEvent(types.ObservabilityEvent{
-			EventType:   "execution_created",
+			EventType:   "execution_completed",
 			EventSource: "execution",
-			Timestamp:   time.Now().Format(time.RFC3339),
-			Data:        map[string]interface{}{"index": i},
+			Timestamp:   time.Now().UTC().Format(time.RFC3339),
+			Data:        map[string]interface{}{"execution_id": fmt.Sprintf("exec-%d", i)},
 		})
 	}
 
-	// Wait for batches
-	time.Sleep(500 * time.Millisecond)
+	stopCtx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_ = forwarder.Stop(stopCtx)
+	require.Less(t, time.Since(start), time.Second)
 
-	mu.Lock()
-	defer mu.Unlock()
+	require.Eventually(t, func() bool {
+		count, _ := store.GetDeadLetterQueueCount(context.Background())
+		return count == 3
+	}, time.Second, 10*time.Millisecond)
 
-	// Should have received batches of size 3
-	require.GreaterOrEqual(t, len(batchSizes), 2)
-	for _, size := range batchSizes {
-		require.LessOrEqual(t, size, 3, "batch size should not exceed configured limit")
+	entries, err := store.GetDeadLetterQueue(context.Background(), 10, 0)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.Contains(t, entry.ErrorMessage, "forwarder stopping")
 	}
 }
 
-// Test batching by timeout
-func TestObservabilityForwarder_BatchingByTimeout(t *testing.T) {
-	var receivedBatches int32
+// Test that status reports retry health while deliveries are failing
+func TestObservabilityForwarder_StatusReportsRetryHealth(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		atomic.AddInt32(&receivedBatches, 1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 	}))
 	defer server.Close()
@@ -1042,9 +2533,12 @@ func TestObservabilityForwarder_BatchingByTimeout(t *testing.T) {
 	})
 
 	cfg := ObservabilityForwarderConfig{
-		BatchSize:    100,                    // Large batch size
-		BatchTimeout: 100 * time.Millisecond, // Short timeout
-		WorkerCount:  1,
+		BatchSize:       1,
+		BatchTimeout:    50 * time.Millisecond,
+		WorkerCount:     1,
+		MaxAttempts:     3,
+		RetryBackoff:    300 * time.Millisecond,
+		MaxRetryBackoff: time.Second,
 	}
 
 	forwarder := NewObservabilityForwarder(store, cfg).(*observabilityForwarder)
@@ -1057,17 +2551,30 @@ func TestObservabilityForwarder_BatchingByTimeout(t *testing.T) {
 	// Wait for forwarder to be fully started
 	time.Sleep(100 * time.Millisecond)
 
-	// Send just 1 event (won't fill batch)
 	forwarder.enqueueEvent(types.ObservabilityEvent{
-		EventType:   "execution_created",
+		EventType:   "execution_failed",
 		EventSource: "execution",
 		Timestamp:   time.Now().Format(time.RFC3339),
-		Data:        map[string]interface{}{"execution_id": "exec-timeout-1"},
+		Data:        map[string]interface{}{"execution_id": "exec-retry"},
 	})
 
-	// Wait for timeout-based flush
-	time.Sleep(300 * time.Millisecond)
-
-	// Should have received a batch despite not reaching batch size
-	require.GreaterOrEqual(t, atomic.LoadInt32(&receivedBatches), int32(1), "should send batch on timeout")
+	// First failure schedules a retry in the future
+	var status types.ObservabilityForwarderStatus
+	require.Eventually(t, func() bool {
+		status = forwarder.GetStatus()
+		return status.ConsecutiveFailures == 1 && status.NextRetryAt != nil
+	}, time.Second, 10*time.Millisecond)
+	require.True(t, status.NextRetryAt.After(time.Now()), "next retry should be in the future")
+
+	// The count keeps growing across retries
+	require.Eventually(t, func() bool {
+		return forwarder.GetStatus().ConsecutiveFailures >= 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// The final retry succeeds, which resets retry health
+	failing.Store(false)
+	require.Eventually(t, func() bool {
+		status = forwarder.GetStatus()
+		return status.ConsecutiveFailures == 0 && status.NextRetryAt == nil && status.EventsForwarded == 1
+	}, 2*time.Second, 10*time.Millisecond)
 }
@@ -14,7 +14,24 @@ import (
 	"strings"
 )
 
-const payloadURIPrefix = "payload://"
+const (
+	payloadURIPrefix = "payload://"
+
+	// payloadChecksumSuffix names the sidecar file holding a payload's hex SHA-256.
+	payloadChecksumSuffix = ".sha256"
+)
+
+// PayloadIntegrityError reports a stored payload whose contents no longer match
+// the checksum recorded when it was written.
+type PayloadIntegrityError struct {
+	URI      string
+	Expected string
+	Actual   string
+}
+
+func (e *PayloadIntegrityError) Error() string {
+	return fmt.Sprintf("payload %s failed integrity check: expected sha256 %s, got %s", e.URI, e.Expected, e.Actual)
+}
 
 // PayloadRecord captures metadata about a stored payload blob.
 type PayloadRecord struct {
@@ -77,8 +94,13 @@ func (s *FilePayloadStore) SaveFromReader(ctx context.Context, r io.Reader) (*Pa
 		return nil, fmt.Errorf("close payload temp file: %w", err)
 	}
 
+	checksum := hex.EncodeToString(hasher.Sum(nil))
 	finalPath := filepath.Join(s.baseDir, id)
+	if err = os.WriteFile(finalPath+payloadChecksumSuffix, []byte(checksum), 0o600); err != nil {
+		return nil, fmt.Errorf("write payload checksum: %w", err)
+	}
 	if err = os.Rename(tmpFile.Name(), finalPath); err != nil {
+		_ = os.Remove(finalPath + payloadChecksumSuffix)
 		return nil, fmt.Errorf("finalize payload file: %w", err)
 	}
 
@@ -90,7 +112,7 @@ func (s *FilePayloadStore) SaveFromReader(ctx context.Context, r io.Reader) (*Pa
 	record := &PayloadRecord{
 		URI:    payloadURIPrefix + id,
 		Size:   info.Size(),
-		SHA256: hex.EncodeToString(hasher.Sum(nil)),
+		SHA256: checksum,
 	}
 	return record, nil
 }
@@ -100,7 +122,9 @@ func (s *FilePayloadStore) SaveBytes(ctx context.Context, data []byte) (*Payload
 	return s.SaveFromReader(ctx, bytes.NewReader(data))
 }
 
-// Open returns a reader for the payload at the given URI.
+// Open returns a reader for the payload at the given URI. The contents are checked
+// against the checksum stored at write time and a *PayloadIntegrityError is returned
+// on mismatch. Payloads written without a checksum are returned unverified.
 func (s *FilePayloadStore) Open(ctx context.Context, uri string) (io.ReadCloser, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -113,10 +137,39 @@ func (s *FilePayloadStore) Open(ctx context.Context, uri string) (io.ReadCloser,
 	if err != nil {
 		return nil, fmt.Errorf("open payload: %w", err)
 	}
+	if err := verifyPayloadChecksum(ctx, uri, path, file); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
 	return file, nil
 }
 
-// Remove deletes a payload from disk. It is safe to call on missing URIs.
+// verifyPayloadChecksum hashes file against its sidecar checksum and rewinds it.
+func verifyPayloadChecksum(ctx context.Context, uri, path string, file *os.File) error {
+	expected, err := os.ReadFile(path + payloadChecksumSuffix)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read payload checksum: %w", err)
+	}
+
+	hasher := sha256.New()
+	if err := copyWithContext(ctx, hasher, file); err != nil {
+		return fmt.Errorf("hash payload: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("rewind payload: %w", err)
+	}
+
+	want := strings.TrimSpace(string(expected))
+	if got := hex.EncodeToString(hasher.Sum(nil)); got != want {
+		return &PayloadIntegrityError{URI: uri, Expected: want, Actual: got}
+	}
+	return nil
+}
+
+// Remove deletes a payload and its checksum from disk. It is safe to call on missing URIs.
 func (s *FilePayloadStore) Remove(ctx context.Context, uri string) error {
 	if err := ctx.Err(); err != nil {
 		return err
@@ -128,6 +181,9 @@ func (s *FilePayloadStore) Remove(ctx context.Context, uri string) error {
 	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("remove payload: %w", err)
 	}
+	if err := os.Remove(path + payloadChecksumSuffix); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove payload checksum: %w", err)
+	}
 	return nil
 }
 
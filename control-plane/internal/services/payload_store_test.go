@@ -6,6 +6,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -90,3 +92,44 @@ func TestCopyWithContextCancels(t *testing.T) {
 	err := copyWithContext(ctx, io.Discard, pr)
 	require.ErrorIs(t, err, context.Canceled)
 }
+
+func TestFilePayloadStoreDetectsCorruption(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	baseDir := t.TempDir()
+	store := NewFilePayloadStore(baseDir)
+
+	record, err := store.SaveBytes(ctx, []byte(`{"result":"ok"}`))
+	require.NoError(t, err)
+
+	path := filepath.Join(baseDir, strings.TrimPrefix(record.URI, payloadURIPrefix))
+	require.NoError(t, os.WriteFile(path, []byte(`{"result":"no"}`), 0o600))
+
+	_, err = store.Open(ctx, record.URI)
+	var integrityErr *PayloadIntegrityError
+	require.ErrorAs(t, err, &integrityErr)
+	require.Equal(t, record.URI, integrityErr.URI)
+	require.Equal(t, record.SHA256, integrityErr.Expected)
+	require.NotEqual(t, record.SHA256, integrityErr.Actual)
+
+	require.NoError(t, store.Remove(ctx, record.URI))
+	_, err = os.Stat(path + payloadChecksumSuffix)
+	require.True(t, os.IsNotExist(err), "checksum sidecar is removed with the payload")
+}
+
+func TestFilePayloadStoreOpensPayloadWithoutChecksum(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	baseDir := t.TempDir()
+	store := NewFilePayloadStore(baseDir)
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "legacy"), []byte("old"), 0o600))
+
+	rc, err := store.Open(ctx, payloadURIPrefix+"legacy")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.Equal(t, []byte("old"), data)
+	require.NoError(t, rc.Close())
+}
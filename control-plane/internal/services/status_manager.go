@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Agent-Field/agentfield/control-plane/internal/core/interfaces"
@@ -15,11 +17,26 @@ import (
 
 // StatusManagerConfig holds configuration for the status manager
 type StatusManagerConfig struct {
-	ReconcileInterval time.Duration // How often to reconcile status
-	StatusCacheTTL    time.Duration // How long to cache status
-	MaxTransitionTime time.Duration // Max time for state transitions
+	ReconcileInterval  time.Duration // How often to reconcile status
+	ReconcileBatchSize int           // Max agents loaded per reconciliation query
+	StatusCacheTTL     time.Duration // How long to cache status
+	MaxTransitionTime  time.Duration // Max time for state transitions
+	HeartbeatTimeout   time.Duration // How old a heartbeat may be before an active agent is marked offline
+	// MaxWatchdogReconciles caps how many heartbeat-lapse reconciles run at once, so a
+	// fleet of nodes going silent together does not flood storage.
+	MaxWatchdogReconciles int
 }
 
+const (
+	// defaultHeartbeatTimeout is how old a heartbeat may be before reconciliation
+	// marks an active agent offline.
+	defaultHeartbeatTimeout = 30 * time.Second
+
+	defaultReconcileBatchSize = 200
+
+	defaultMaxWatchdogReconciles = 8
+)
+
 // StatusManager provides a single source of truth for agent status
 // It reconciles between different status sources and manages status persistence
 type StatusManager struct {
@@ -36,11 +53,69 @@ type StatusManager struct {
 	activeTransitions map[string]*types.StateTransition
 	transitionMutex   sync.RWMutex
 
+	// Heartbeat watchdog: one pending timer per node, re-armed by each heartbeat
+	heartbeatWatchdogs map[string]*time.Timer
+	watchdogMutex      sync.Mutex
+	running            atomic.Bool
+	// watchdogSlots bounds concurrent watchdog reconciles across all nodes
+	watchdogSlots chan struct{}
+
 	// Control channels
 	stopCh chan struct{}
 
 	// Event handlers
 	eventHandlers []StatusEventHandler
+
+	// HealthScorer computes health scores for live checks and reconciliation.
+	// When nil, live checks use defaultHealthScore and reconciliation leaves scores as is.
+	HealthScorer HealthScorer
+
+	// TransitionValidator decides whether a state transition is allowed.
+	// When nil, defaultTransitionValidator is used.
+	TransitionValidator TransitionValidator
+}
+
+// TransitionValidator reports whether an agent may move from one state to another.
+type TransitionValidator func(from, to types.AgentState) bool
+
+// HealthScoreInput carries the signals available when scoring an agent's health.
+type HealthScoreInput struct {
+	NodeID string
+	// Healthy reports whether the agent was determined to be up.
+	Healthy bool
+	// Response is the live health check response; nil if the check failed or was not performed.
+	Response *interfaces.AgentStatusResponse
+	// MCPStatus is the last known MCP status, if any.
+	MCPStatus *types.MCPStatusInfo
+	// LastHeartbeat is the agent's last recorded heartbeat; zero if unknown.
+	LastHeartbeat time.Time
+}
+
+// HealthScorer computes a 0-100 health score for an agent.
+type HealthScorer func(input HealthScoreInput) int
+
+// defaultHealthScore scores healthy agents 85 and everything else 0.
+func defaultHealthScore(input HealthScoreInput) int {
+	if input.Healthy {
+		return 85
+	}
+	return 0
+}
+
+// healthScore applies the configured scorer, clamping the result to 0-100.
+func (sm *StatusManager) healthScore(input HealthScoreInput) int {
+	scorer := sm.HealthScorer
+	if scorer == nil {
+		scorer = defaultHealthScore
+	}
+	score := scorer(input)
+	if score < 0 {
+		return 0
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
 }
 
 // cachedAgentStatus represents a cached status with timestamp
@@ -85,28 +160,40 @@ func NewStatusManager(storage storage.StorageProvider, config StatusManagerConfi
 	if config.ReconcileInterval == 0 {
 		config.ReconcileInterval = 30 * time.Second
 	}
+	if config.ReconcileBatchSize <= 0 {
+		config.ReconcileBatchSize = defaultReconcileBatchSize
+	}
 	if config.StatusCacheTTL == 0 {
 		config.StatusCacheTTL = 5 * time.Minute
 	}
 	if config.MaxTransitionTime == 0 {
 		config.MaxTransitionTime = 2 * time.Minute
 	}
+	if config.HeartbeatTimeout <= 0 {
+		config.HeartbeatTimeout = defaultHeartbeatTimeout
+	}
+	if config.MaxWatchdogReconciles <= 0 {
+		config.MaxWatchdogReconciles = defaultMaxWatchdogReconciles
+	}
 
 	return &StatusManager{
-		storage:           storage,
-		config:            config,
-		uiService:         uiService,
-		agentClient:       agentClient,
-		statusCache:       make(map[string]*cachedAgentStatus),
-		activeTransitions: make(map[string]*types.StateTransition),
-		stopCh:            make(chan struct{}),
-		eventHandlers:     make([]StatusEventHandler, 0),
+		storage:            storage,
+		config:             config,
+		uiService:          uiService,
+		agentClient:        agentClient,
+		statusCache:        make(map[string]*cachedAgentStatus),
+		activeTransitions:  make(map[string]*types.StateTransition),
+		heartbeatWatchdogs: make(map[string]*time.Timer),
+		watchdogSlots:      make(chan struct{}, config.MaxWatchdogReconciles),
+		stopCh:             make(chan struct{}),
+		eventHandlers:      make([]StatusEventHandler, 0),
 	}
 }
 
 // Start begins the status manager background processes
 func (sm *StatusManager) Start() {
 	logger.Logger.Debug().Msg("🔄 Starting status manager")
+	sm.running.Store(true)
 
 	// Start reconciliation loop
 	go sm.reconcileLoop()
@@ -118,7 +205,15 @@ func (sm *StatusManager) Start() {
 // Stop gracefully shuts down the status manager
 func (sm *StatusManager) Stop() {
 	logger.Logger.Debug().Msg("🔄 Stopping status manager")
+	sm.running.Store(false)
 	close(sm.stopCh)
+
+	sm.watchdogMutex.Lock()
+	for nodeID, timer := range sm.heartbeatWatchdogs {
+		timer.Stop()
+		delete(sm.heartbeatWatchdogs, nodeID)
+	}
+	sm.watchdogMutex.Unlock()
 }
 
 // GetAgentStatus retrieves the current unified status for an agent using live health checks
@@ -171,13 +266,34 @@ func (sm *StatusManager) GetAgentStatus(ctx context.Context, nodeID string) (*ty
 			healthCheckSuccessful = true
 		}
 
+		// Score the result; custom scorers also get the cached MCP status and last heartbeat
+		scoreInput := HealthScoreInput{
+			NodeID:  nodeID,
+			Healthy: healthCheckSuccessful && agentStatusResp.Status == "running",
+		}
+		if healthCheckSuccessful {
+			scoreInput.Response = agentStatusResp
+		}
+		if sm.HealthScorer != nil {
+			sm.cacheMutex.RLock()
+			if cached, exists := sm.statusCache[nodeID]; exists && cached.Status != nil && cached.Status.MCPStatus != nil {
+				mcpCopy := *cached.Status.MCPStatus
+				scoreInput.MCPStatus = &mcpCopy
+			}
+			sm.cacheMutex.RUnlock()
+			if agent, err := sm.storage.GetAgent(ctx, nodeID); err == nil && agent != nil {
+				scoreInput.LastHeartbeat = agent.LastHeartbeat
+			}
+		}
+		healthScore := sm.healthScore(scoreInput)
+
 		// Create status based on health check result
 		now := time.Now()
-		if healthCheckSuccessful && agentStatusResp.Status == "running" {
+		if scoreInput.Healthy {
 			// Agent is active and running
 			status = &types.AgentStatus{
 				State:           types.AgentStateActive,
-				HealthScore:     85, // Good health from live verification
+				HealthScore:     healthScore,
 				LastSeen:        now,
 				LifecycleStatus: types.AgentStatusReady,
 				HealthStatus:    types.HealthStatusActive,
@@ -189,7 +305,7 @@ func (sm *StatusManager) GetAgentStatus(ctx context.Context, nodeID string) (*ty
 			// Agent is inactive or not responding
 			status = &types.AgentStatus{
 				State:           types.AgentStateInactive,
-				HealthScore:     0, // No health
+				HealthScore:     healthScore,
 				LastSeen:        now,
 				LifecycleStatus: types.AgentStatusOffline,
 				HealthStatus:    types.HealthStatusInactive,
@@ -230,7 +346,7 @@ func (sm *StatusManager) GetAgentStatus(ctx context.Context, nodeID string) (*ty
 	if sm.uiService != nil {
 		// Get the agent for event emission
 		if agent, err := sm.storage.GetAgent(ctx, nodeID); err == nil {
-			sm.uiService.OnNodeStatusChanged(agent)
+			sm.uiService.OnNodeUnifiedStatusChanged(agent, status)
 		}
 	}
 
@@ -278,6 +394,30 @@ func (sm *StatusManager) GetAgentStatusSnapshot(ctx context.Context, nodeID stri
 	return cloneAgentStatus(status), nil
 }
 
+// GetAllAgentStatusSnapshots returns the snapshot status of every registered agent keyed by
+// node ID. Like GetAgentStatusSnapshot it never performs live health checks.
+func (sm *StatusManager) GetAllAgentStatusSnapshots(ctx context.Context) (map[string]*types.AgentStatus, error) {
+	agents, err := sm.storage.ListAgents(ctx, types.AgentFilters{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+
+	statuses := make(map[string]*types.AgentStatus, len(agents))
+	for _, agent := range agents {
+		if agent == nil {
+			continue
+		}
+		status, err := sm.GetAgentStatusSnapshot(ctx, agent.ID, agent)
+		if err != nil {
+			logger.Logger.Error().Err(err).Str("node_id", agent.ID).Msg("Failed to get status snapshot for node")
+			continue
+		}
+		statuses[agent.ID] = status
+	}
+
+	return statuses, nil
+}
+
 // UpdateAgentStatus updates the agent status with reconciliation
 func (sm *StatusManager) UpdateAgentStatus(ctx context.Context, nodeID string, update *types.AgentStatusUpdate) error {
 	// Get current status using snapshot (no live health check) to preserve the true "old" state
@@ -383,6 +523,7 @@ func (sm *StatusManager) UpdateFromHeartbeat(ctx context.Context, nodeID string,
 
 	// Update from heartbeat
 	currentStatus.UpdateFromHeartbeat(lifecycleStatus, mcpStatus)
+	sm.armHeartbeatWatchdog(nodeID, sm.heartbeatWatchdogDelay())
 
 	// Persist changes
 	update := &types.AgentStatusUpdate{
@@ -415,6 +556,69 @@ func (sm *StatusManager) RefreshAgentStatus(ctx context.Context, nodeID string)
 	return nil
 }
 
+// maxConcurrentStatusRefresh bounds live checks during RefreshAllAgentStatuses.
+const maxConcurrentStatusRefresh = 5
+
+// RefreshAllAgentStatuses clears the status cache and live-checks every registered agent,
+// publishing a refresh event for each. It returns how many agents were refreshed along
+// with any per-agent failures joined into a single error.
+func (sm *StatusManager) RefreshAllAgentStatuses(ctx context.Context) (int, error) {
+	agents, err := sm.storage.ListAgents(ctx, types.AgentFilters{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list agents: %w", err)
+	}
+
+	// Clear cache so every agent gets a live check
+	sm.cacheMutex.Lock()
+	sm.statusCache = make(map[string]*cachedAgentStatus)
+	sm.cacheMutex.Unlock()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		refreshed int
+		errs      []error
+	)
+	sem := make(chan struct{}, maxConcurrentStatusRefresh)
+
+	for _, agent := range agents {
+		if agent == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			err := sm.RefreshAgentStatus(ctx, nodeID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("node %s: %w", nodeID, err))
+				return
+			}
+			refreshed++
+		}(agent.ID)
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	logger.Logger.Debug().Int("refreshed", refreshed).Int("failed", len(errs)).Msg("🔄 Refreshed all agent statuses")
+	return refreshed, errors.Join(errs...)
+}
+
 // AddEventHandler adds a status event handler
 func (sm *StatusManager) AddEventHandler(handler StatusEventHandler) {
 	sm.eventHandlers = append(sm.eventHandlers, handler)
@@ -448,8 +652,17 @@ func (sm *StatusManager) handleStateTransition(nodeID string, status *types.Agen
 	return nil
 }
 
-// isValidTransition checks if a state transition is valid
+// isValidTransition checks if a state transition is valid, consulting the custom
+// TransitionValidator when one is set
 func (sm *StatusManager) isValidTransition(from, to types.AgentState) bool {
+	if sm.TransitionValidator != nil {
+		return sm.TransitionValidator(from, to)
+	}
+	return defaultTransitionValidator(from, to)
+}
+
+// defaultTransitionValidator enforces the built-in agent state graph
+func defaultTransitionValidator(from, to types.AgentState) bool {
 	validTransitions := map[types.AgentState][]types.AgentState{
 		types.AgentStateInactive: {types.AgentStateStarting, types.AgentStateActive},
 		types.AgentStateStarting: {types.AgentStateActive, types.AgentStateInactive},
@@ -555,7 +768,7 @@ func (sm *StatusManager) broadcastStatusEvents(nodeID string, oldStatus, newStat
 
 	// Notify UI service for SSE broadcasting (this goes through deduplication)
 	if sm.uiService != nil {
-		sm.uiService.OnNodeStatusChanged(agent)
+		sm.uiService.OnNodeUnifiedStatusChanged(agent, newStatus)
 	}
 }
 
@@ -574,29 +787,66 @@ func (sm *StatusManager) reconcileLoop() {
 	}
 }
 
-// performReconciliation reconciles status for all agents
+// performReconciliation reconciles status for all agents that may need it. Only active
+// agents qualify, so rather than scanning every agent it pages through those whose
+// heartbeat went stale and those still marked offline, in bounded batches.
 func (sm *StatusManager) performReconciliation() {
 	ctx := context.Background()
 
-	// Get all agents
-	agents, err := sm.storage.ListAgents(ctx, types.AgentFilters{})
-	if err != nil {
-		logger.Logger.Error().Err(err).Msg("❌ Failed to list agents for reconciliation")
-		return
+	active := types.HealthStatusActive
+	offline := types.AgentStatusOffline
+	staleBefore := time.Now().Add(-sm.config.HeartbeatTimeout)
+	candidates := []types.AgentFilters{
+		{HealthStatus: &active, HeartbeatBefore: &staleBefore},
+		{HealthStatus: &active, LifecycleStatus: &offline},
 	}
 
-	logger.Logger.Debug().Int("agent_count", len(agents)).Msg("🔄 Starting status reconciliation")
+	checked := 0
+	for _, filters := range candidates {
+		n, err := sm.reconcileInBatches(ctx, filters)
+		checked += n
+		if err != nil {
+			logger.Logger.Error().Err(err).Msg("❌ Failed to list agents for reconciliation")
+			return
+		}
+	}
 
-	for _, agent := range agents {
-		// Check if status needs reconciliation
-		if sm.needsReconciliation(agent) {
-			if err := sm.reconcileAgentStatus(ctx, agent); err != nil {
-				logger.Logger.Error().
-					Err(err).
-					Str("node_id", agent.ID).
-					Msg("❌ Failed to reconcile agent status")
+	logger.Logger.Debug().Int("agent_count", checked).Msg("🔄 Completed status reconciliation")
+}
+
+// reconcileInBatches reconciles the agents matching filters, loading at most
+// ReconcileBatchSize of them at a time. It returns how many agents were checked.
+func (sm *StatusManager) reconcileInBatches(ctx context.Context, filters types.AgentFilters) (int, error) {
+	filters.Limit = sm.config.ReconcileBatchSize
+	checked := 0
+	for {
+		agents, err := sm.storage.ListAgents(ctx, filters)
+		if err != nil {
+			return checked, err
+		}
+		checked += len(agents)
+
+		for _, agent := range agents {
+			// Check if status needs reconciliation
+			if sm.needsReconciliation(agent) {
+				if err := sm.reconcileAgentStatus(ctx, agent); err != nil {
+					logger.Logger.Error().
+						Err(err).
+						Str("node_id", agent.ID).
+						Msg("❌ Failed to reconcile agent status")
+				}
 			}
 		}
+
+		if len(agents) < filters.Limit {
+			return checked, nil
+		}
+		last := agents[len(agents)-1].ID
+		if last <= filters.AfterID {
+			// The cursor did not advance; stop rather than loop on the same page.
+			return checked, nil
+		}
+		filters.AfterID = last
 	}
 }
 
@@ -604,7 +854,7 @@ func (sm *StatusManager) performReconciliation() {
 func (sm *StatusManager) needsReconciliation(agent *types.AgentNode) bool {
 	// Check if last heartbeat is too old
 	timeSinceHeartbeat := time.Since(agent.LastHeartbeat)
-	if timeSinceHeartbeat > 30*time.Second && agent.HealthStatus == types.HealthStatusActive {
+	if timeSinceHeartbeat > sm.config.HeartbeatTimeout && agent.HealthStatus == types.HealthStatusActive {
 		return true
 	}
 
@@ -624,7 +874,7 @@ func (sm *StatusManager) reconcileAgentStatus(ctx context.Context, agent *types.
 	var newHealthStatus types.HealthStatus
 	var newLifecycleStatus types.AgentLifecycleStatus
 
-	if timeSinceHeartbeat > 30*time.Second {
+	if timeSinceHeartbeat > sm.config.HeartbeatTimeout {
 		newHealthStatus = types.HealthStatusInactive
 		newLifecycleStatus = types.AgentStatusOffline
 	} else {
@@ -655,12 +905,96 @@ func (sm *StatusManager) reconcileAgentStatus(ctx context.Context, agent *types.
 			update.LifecycleStatus = &newLifecycleStatus
 		}
 
+		// Only a configured scorer rescores here; otherwise the score is left as it was.
+		if sm.HealthScorer != nil {
+			score := sm.healthScore(HealthScoreInput{
+				NodeID:        agent.ID,
+				Healthy:       newHealthStatus == types.HealthStatusActive,
+				LastHeartbeat: agent.LastHeartbeat,
+			})
+			update.HealthScore = &score
+		}
+
 		return sm.UpdateAgentStatus(ctx, agent.ID, update)
 	}
 
 	return nil
 }
 
+// heartbeatWatchdogDelay is how long after a heartbeat the watchdog checks for a lapse:
+// the heartbeat timeout plus a tenth of it, so a slightly late heartbeat is not
+// mistaken for a missing one.
+func (sm *StatusManager) heartbeatWatchdogDelay() time.Duration {
+	return sm.config.HeartbeatTimeout + sm.config.HeartbeatTimeout/10
+}
+
+// armHeartbeatWatchdog schedules a reconcile of nodeID after delay, replacing any pending
+// one so that a burst of heartbeats leaves a single check behind. It is a no-op unless the
+// manager has been started.
+func (sm *StatusManager) armHeartbeatWatchdog(nodeID string, delay time.Duration) {
+	if !sm.running.Load() {
+		return
+	}
+
+	sm.watchdogMutex.Lock()
+	defer sm.watchdogMutex.Unlock()
+
+	if timer, exists := sm.heartbeatWatchdogs[nodeID]; exists {
+		timer.Reset(delay)
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		sm.checkHeartbeatLapse(nodeID, timer)
+	})
+	sm.heartbeatWatchdogs[nodeID] = timer
+}
+
+// checkHeartbeatLapse reconciles nodeID once its expected heartbeat has been missed,
+// rather than leaving it active until the next periodic reconciliation. If a heartbeat
+// arrived through another path in the meantime, the watchdog is re-armed for it instead.
+// At most MaxWatchdogReconciles checks run at once; the rest wait for a free slot.
+func (sm *StatusManager) checkHeartbeatLapse(nodeID string, timer *time.Timer) {
+	sm.watchdogMutex.Lock()
+	if sm.heartbeatWatchdogs[nodeID] != timer {
+		// Replaced or stopped after firing; the current timer owns the check
+		sm.watchdogMutex.Unlock()
+		return
+	}
+	delete(sm.heartbeatWatchdogs, nodeID)
+	sm.watchdogMutex.Unlock()
+
+	if !sm.running.Load() {
+		return
+	}
+
+	select {
+	case sm.watchdogSlots <- struct{}{}:
+		defer func() { <-sm.watchdogSlots }()
+	case <-sm.stopCh:
+		return
+	}
+
+	ctx := context.Background()
+	agent, err := sm.storage.GetAgent(ctx, nodeID)
+	if err != nil || agent == nil {
+		return
+	}
+	if agent.HealthStatus != types.HealthStatusActive {
+		return
+	}
+
+	if remaining := sm.heartbeatWatchdogDelay() - time.Since(agent.LastHeartbeat); remaining > 0 {
+		sm.armHeartbeatWatchdog(nodeID, remaining)
+		return
+	}
+
+	logger.Logger.Debug().Str("node_id", nodeID).Time("last_heartbeat", agent.LastHeartbeat).Msg("💔 Heartbeat lapsed, reconciling agent status")
+	if err := sm.reconcileAgentStatus(ctx, agent); err != nil {
+		logger.Logger.Error().Err(err).Str("node_id", nodeID).Msg("❌ Failed to reconcile agent after heartbeat lapse")
+	}
+}
+
 // transitionTimeoutLoop checks for stuck transitions
 func (sm *StatusManager) transitionTimeoutLoop() {
 	ticker := time.NewTicker(30 * time.Second)
@@ -683,14 +1017,17 @@ func (sm *StatusManager) checkTransitionTimeouts() {
 
 	now := time.Now()
 	for nodeID, transition := range sm.activeTransitions {
-		if now.Sub(transition.StartedAt) > sm.config.MaxTransitionTime {
+		elapsed := now.Sub(transition.StartedAt)
+		if elapsed > sm.config.MaxTransitionTime {
 			logger.Logger.Warn().
 				Str("node_id", nodeID).
 				Str("from", string(transition.From)).
 				Str("to", string(transition.To)).
-				Dur("duration", now.Sub(transition.StartedAt)).
+				Dur("duration", elapsed).
 				Msg("🔄 Transition timeout, forcing completion")
 
+			events.PublishNodeTransitionTimedOut(nodeID, string(transition.From), string(transition.To), elapsed)
+
 			// Force complete the transition
 			ctx := context.Background()
 			if status, err := sm.GetAgentStatus(ctx, nodeID); err == nil {
@@ -18,16 +18,21 @@ import (
 )
 
 type fakeAgentClient struct {
+	mu             sync.Mutex
 	statusResponse *interfaces.AgentStatusResponse
 	err            error
 	calls          int
 }
 
 func (f *fakeAgentClient) setError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.err = err
 }
 
 func (f *fakeAgentClient) GetAgentStatus(ctx context.Context, nodeID string) (*interfaces.AgentStatusResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.calls++
 	if f.err != nil {
 		err := f.err
@@ -155,6 +160,206 @@ func TestStatusManagerAllowsInactiveToActiveTransition(t *testing.T) {
 	require.Equal(t, types.AgentStateActive, status.State)
 }
 
+func TestStatusManagerCustomTransitionValidator(t *testing.T) {
+	provider, ctx := setupStatusManagerStorage(t)
+	registerTestAgent(t, provider, ctx, "node-rolling")
+
+	sm := NewStatusManager(provider, StatusManagerConfig{}, nil, nil)
+
+	toActive := &types.AgentStatusUpdate{
+		State:  ptrAgentState(types.AgentStateActive),
+		Source: types.StatusSourceHeartbeat,
+		Reason: "heartbeat indicates agent active",
+	}
+	require.NoError(t, sm.UpdateAgentStatus(ctx, "node-rolling", toActive))
+
+	toStarting := &types.AgentStatusUpdate{
+		State:  ptrAgentState(types.AgentStateStarting),
+		Source: types.StatusSourceManual,
+		Reason: "rolling restart",
+	}
+
+	// Active -> Starting is rejected by the built-in graph
+	require.Error(t, sm.UpdateAgentStatus(ctx, "node-rolling", toStarting))
+
+	sm.TransitionValidator = func(from, to types.AgentState) bool { return true }
+	require.NoError(t, sm.UpdateAgentStatus(ctx, "node-rolling", toStarting))
+
+	sm.cacheMutex.RLock()
+	cached, ok := sm.statusCache["node-rolling"]
+	sm.cacheMutex.RUnlock()
+	require.True(t, ok)
+	require.Equal(t, types.AgentStateStarting, cached.Status.State)
+}
+
+func TestStatusManagerCustomHealthScorer(t *testing.T) {
+	provider, ctx := setupStatusManagerStorage(t)
+	registerTestAgent(t, provider, ctx, "node-live")
+	registerTestAgent(t, provider, ctx, "node-stale")
+	require.NoError(t, provider.UpdateAgentHealth(ctx, "node-stale", types.HealthStatusActive))
+
+	fakeClient := &fakeAgentClient{statusResponse: &interfaces.AgentStatusResponse{Status: "running", UptimeSeconds: 30}}
+	sm := NewStatusManager(provider, StatusManagerConfig{}, nil, fakeClient)
+
+	var inputs []HealthScoreInput
+	sm.HealthScorer = func(input HealthScoreInput) int {
+		inputs = append(inputs, input)
+		if !input.Healthy {
+			return 7
+		}
+		if input.Response != nil && input.Response.UptimeSeconds < 60 {
+			return 42 // recently restarted
+		}
+		return 100
+	}
+
+	// Live-check path
+	status, err := sm.GetAgentStatus(ctx, "node-live")
+	require.NoError(t, err)
+	require.Equal(t, types.AgentStateActive, status.State)
+	require.Equal(t, 42, status.HealthScore)
+	require.Len(t, inputs, 1)
+	require.Equal(t, "node-live", inputs[0].NodeID)
+	require.False(t, inputs[0].LastHeartbeat.IsZero())
+
+	// Reconciliation path: stale heartbeat on an active agent
+	sm.performReconciliation()
+
+	snapshot, err := sm.GetAgentStatusSnapshot(ctx, "node-stale", nil)
+	require.NoError(t, err)
+	require.Equal(t, types.AgentStateInactive, snapshot.State)
+	require.Equal(t, 7, snapshot.HealthScore)
+}
+
+func TestStatusManagerReconcileWithoutScorerKeepsHealthScore(t *testing.T) {
+	provider, ctx := setupStatusManagerStorage(t)
+	registerTestAgent(t, provider, ctx, "node-stale")
+	require.NoError(t, provider.UpdateAgentHealth(ctx, "node-stale", types.HealthStatusActive))
+
+	sm := NewStatusManager(provider, StatusManagerConfig{}, nil, nil)
+	score := 60
+	require.NoError(t, sm.UpdateAgentStatus(ctx, "node-stale", &types.AgentStatusUpdate{
+		HealthScore: &score,
+		Source:      types.StatusSourceManual,
+		Reason:      "seed score",
+	}))
+	before, err := sm.GetAgentStatusSnapshot(ctx, "node-stale", nil)
+	require.NoError(t, err)
+	require.Equal(t, 60, before.HealthScore)
+
+	sm.performReconciliation()
+
+	after, err := sm.GetAgentStatusSnapshot(ctx, "node-stale", nil)
+	require.NoError(t, err)
+	require.Equal(t, types.AgentStateInactive, after.State)
+	require.Equal(t, before.HealthScore, after.HealthScore)
+}
+
+func TestStatusManagerDefaultHealthScore(t *testing.T) {
+	require.Equal(t, 85, defaultHealthScore(HealthScoreInput{Healthy: true}))
+	require.Equal(t, 0, defaultHealthScore(HealthScoreInput{Healthy: false}))
+
+	sm := &StatusManager{HealthScorer: func(HealthScoreInput) int { return 150 }}
+	require.Equal(t, 100, sm.healthScore(HealthScoreInput{}))
+}
+
+func TestStatusManagerRefreshAllAgentStatuses(t *testing.T) {
+	provider, ctx := setupStatusManagerStorage(t)
+	nodeIDs := []string{"fleet-1", "fleet-2", "fleet-3", "fleet-4", "fleet-5", "fleet-6"}
+	for _, nodeID := range nodeIDs {
+		registerTestAgent(t, provider, ctx, nodeID)
+	}
+
+	fakeClient := &fakeAgentClient{statusResponse: &interfaces.AgentStatusResponse{Status: "running"}}
+	sm := NewStatusManager(provider, StatusManagerConfig{}, nil, fakeClient)
+
+	// Prime the cache with fresh inactive entries that would normally be served as-is
+	sm.cacheMutex.Lock()
+	for _, nodeID := range nodeIDs {
+		sm.statusCache[nodeID] = &cachedAgentStatus{
+			Status:    &types.AgentStatus{State: types.AgentStateInactive, Source: types.StatusSourceReconcile},
+			Timestamp: time.Now(),
+		}
+	}
+	sm.cacheMutex.Unlock()
+
+	subscriberID := "test-refresh-all-subscriber"
+	eventCh := events.GlobalNodeEventBus.Subscribe(subscriberID)
+	defer events.GlobalNodeEventBus.Unsubscribe(subscriberID)
+
+	refreshed, err := sm.RefreshAllAgentStatuses(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(nodeIDs), refreshed)
+	require.Equal(t, len(nodeIDs), fakeClient.calls)
+
+	sm.cacheMutex.RLock()
+	for _, nodeID := range nodeIDs {
+		cached, ok := sm.statusCache[nodeID]
+		require.True(t, ok, "expected cache entry for %s", nodeID)
+		require.Equal(t, types.AgentStateActive, cached.Status.State)
+		require.Equal(t, types.StatusSourceHealthCheck, cached.Status.Source)
+	}
+	sm.cacheMutex.RUnlock()
+
+	refreshedNodes := make(map[string]bool)
+	timeout := time.After(2 * time.Second)
+	for len(refreshedNodes) < len(nodeIDs) {
+		select {
+		case event := <-eventCh:
+			if event.Type == events.NodeStatusRefreshed {
+				refreshedNodes[event.NodeID] = true
+			}
+		case <-timeout:
+			t.Fatalf("expected refresh events for all nodes, got %v", refreshedNodes)
+		}
+	}
+}
+
+func TestStatusManagerPublishesTransitionTimeoutEvent(t *testing.T) {
+	provider, ctx := setupStatusManagerStorage(t)
+	registerTestAgent(t, provider, ctx, "node-stuck")
+
+	sm := NewStatusManager(provider, StatusManagerConfig{MaxTransitionTime: time.Second}, nil, nil)
+
+	sm.transitionMutex.Lock()
+	sm.activeTransitions["node-stuck"] = &types.StateTransition{
+		From:      types.AgentStateStarting,
+		To:        types.AgentStateActive,
+		StartedAt: time.Now().Add(-5 * time.Second),
+	}
+	sm.transitionMutex.Unlock()
+
+	subscriberID := "test-transition-timeout-subscriber"
+	eventCh := events.GlobalNodeEventBus.Subscribe(subscriberID)
+	defer events.GlobalNodeEventBus.Unsubscribe(subscriberID)
+
+	sm.checkTransitionTimeouts()
+
+	sm.transitionMutex.Lock()
+	_, stillActive := sm.activeTransitions["node-stuck"]
+	sm.transitionMutex.Unlock()
+	require.False(t, stillActive, "timed out transition should be cleared")
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case event := <-eventCh:
+			if event.Type != events.NodeTransitionTimedOut {
+				continue
+			}
+			require.Equal(t, "node-stuck", event.NodeID)
+			data, ok := event.Data.(map[string]interface{})
+			require.True(t, ok)
+			require.Equal(t, string(types.AgentStateStarting), data["from_state"])
+			require.Equal(t, string(types.AgentStateActive), data["to_state"])
+			require.GreaterOrEqual(t, data["elapsed_ms"].(int64), int64(5000))
+			return
+		case <-timeout:
+			t.Fatal("expected NodeTransitionTimedOut event")
+		}
+	}
+}
+
 func TestStatusManagerSnapshotUsesStorage(t *testing.T) {
 	provider, ctx := setupStatusManagerStorage(t)
 	registerTestAgent(t, provider, ctx, "node-snapshot")
@@ -487,3 +692,162 @@ func (h *testStatusEventHandler) OnStatusChanged(nodeID string, oldStatus, newSt
 		h.onStatusChanged(nodeID, oldStatus, newStatus)
 	}
 }
+
+// pagingRecorderStorage records the size of every agent page served to reconciliation.
+type pagingRecorderStorage struct {
+	storage.StorageProvider
+	mu    sync.Mutex
+	pages []int
+}
+
+func (s *pagingRecorderStorage) ListAgents(ctx context.Context, filters types.AgentFilters) ([]*types.AgentNode, error) {
+	agents, err := s.StorageProvider.ListAgents(ctx, filters)
+	s.mu.Lock()
+	s.pages = append(s.pages, len(agents))
+	s.mu.Unlock()
+	return agents, err
+}
+
+func TestStatusManagerReconcilesStaleAgentsInBatches(t *testing.T) {
+	provider, ctx := setupStatusManagerStorage(t)
+
+	register := func(nodeID string, lastHeartbeat time.Time) {
+		require.NoError(t, provider.RegisterAgent(ctx, &types.AgentNode{
+			ID:              nodeID,
+			TeamID:          "team",
+			BaseURL:         "http://localhost",
+			Version:         "1.0.0",
+			HealthStatus:    types.HealthStatusActive,
+			LifecycleStatus: types.AgentStatusReady,
+			LastHeartbeat:   lastHeartbeat,
+			Reasoners:       []types.ReasonerDefinition{},
+			Skills:          []types.SkillDefinition{},
+		}))
+	}
+	stale := []string{"stale-1", "stale-2", "stale-3", "stale-4", "stale-5", "stale-6", "stale-7"}
+	for _, nodeID := range stale {
+		register(nodeID, time.Now().Add(-5*time.Minute))
+	}
+	register("fresh-1", time.Now())
+	register("fresh-2", time.Now())
+
+	recorder := &pagingRecorderStorage{StorageProvider: provider}
+	sm := NewStatusManager(recorder, StatusManagerConfig{ReconcileBatchSize: 3}, nil, nil)
+
+	sm.performReconciliation()
+
+	for _, nodeID := range stale {
+		agent, err := provider.GetAgent(ctx, nodeID)
+		require.NoError(t, err)
+		require.Equal(t, types.HealthStatusInactive, agent.HealthStatus, nodeID)
+		require.Equal(t, types.AgentStatusOffline, agent.LifecycleStatus, nodeID)
+	}
+	for _, nodeID := range []string{"fresh-1", "fresh-2"} {
+		agent, err := provider.GetAgent(ctx, nodeID)
+		require.NoError(t, err)
+		require.Equal(t, types.HealthStatusActive, agent.HealthStatus, nodeID)
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	total := 0
+	for _, size := range recorder.pages {
+		require.LessOrEqual(t, size, 3, "reconciliation must not load more than one batch at a time")
+		total += size
+	}
+	require.Equal(t, len(stale), total, "only stale agents are scanned, each exactly once")
+}
+
+func TestStatusManagerHeartbeatWatchdogMarksLapsedAgentInactive(t *testing.T) {
+	provider, ctx := setupStatusManagerStorage(t)
+	require.NoError(t, provider.RegisterAgent(ctx, &types.AgentNode{
+		ID:              "node-1",
+		TeamID:          "team",
+		BaseURL:         "http://localhost",
+		Version:         "1.0.0",
+		HealthStatus:    types.HealthStatusActive,
+		LifecycleStatus: types.AgentStatusReady,
+		LastHeartbeat:   time.Now(),
+		Reasoners:       []types.ReasonerDefinition{},
+		Skills:          []types.SkillDefinition{},
+	}))
+
+	sm := NewStatusManager(provider, StatusManagerConfig{
+		ReconcileInterval: time.Hour,
+		HeartbeatTimeout:  200 * time.Millisecond,
+	}, nil, nil)
+	sm.Start()
+	defer sm.Stop()
+
+	// A burst of heartbeats leaves a single pending check
+	ready := types.AgentStatusReady
+	for i := 0; i < 5; i++ {
+		require.NoError(t, sm.UpdateFromHeartbeat(ctx, "node-1", &ready, nil))
+	}
+	sm.watchdogMutex.Lock()
+	require.Len(t, sm.heartbeatWatchdogs, 1)
+	sm.watchdogMutex.Unlock()
+
+	agent, err := provider.GetAgent(ctx, "node-1")
+	require.NoError(t, err)
+	require.Equal(t, types.HealthStatusActive, agent.HealthStatus)
+
+	// Heartbeats stop; the agent goes offline long before the next periodic reconcile
+	require.Eventually(t, func() bool {
+		agent, err := provider.GetAgent(ctx, "node-1")
+		return err == nil && agent.HealthStatus == types.HealthStatusInactive
+	}, 3*time.Second, 20*time.Millisecond)
+
+	agent, err = provider.GetAgent(ctx, "node-1")
+	require.NoError(t, err)
+	require.Equal(t, types.AgentStatusOffline, agent.LifecycleStatus)
+
+	sm.watchdogMutex.Lock()
+	require.Empty(t, sm.heartbeatWatchdogs)
+	sm.watchdogMutex.Unlock()
+}
+
+func TestStatusManagerHeartbeatWatchdogBoundsConcurrentReconciles(t *testing.T) {
+	provider, ctx := setupStatusManagerStorage(t)
+	require.NoError(t, provider.RegisterAgent(ctx, &types.AgentNode{
+		ID:              "node-1",
+		TeamID:          "team",
+		BaseURL:         "http://localhost",
+		Version:         "1.0.0",
+		HealthStatus:    types.HealthStatusActive,
+		LifecycleStatus: types.AgentStatusReady,
+		LastHeartbeat:   time.Now(),
+		Reasoners:       []types.ReasonerDefinition{},
+		Skills:          []types.SkillDefinition{},
+	}))
+
+	sm := NewStatusManager(provider, StatusManagerConfig{
+		ReconcileInterval:     time.Hour,
+		HeartbeatTimeout:      100 * time.Millisecond,
+		MaxWatchdogReconciles: 1,
+	}, nil, nil)
+	sm.Start()
+	defer sm.Stop()
+
+	// Occupy the only slot so the lapse check has to wait for it
+	sm.watchdogSlots <- struct{}{}
+
+	ready := types.AgentStatusReady
+	require.NoError(t, sm.UpdateFromHeartbeat(ctx, "node-1", &ready, nil))
+	require.Eventually(t, func() bool {
+		sm.watchdogMutex.Lock()
+		defer sm.watchdogMutex.Unlock()
+		return len(sm.heartbeatWatchdogs) == 0
+	}, 3*time.Second, 10*time.Millisecond, "watchdog fires")
+
+	time.Sleep(100 * time.Millisecond)
+	agent, err := provider.GetAgent(ctx, "node-1")
+	require.NoError(t, err)
+	require.Equal(t, types.HealthStatusActive, agent.HealthStatus, "no reconcile runs without a free slot")
+
+	<-sm.watchdogSlots
+	require.Eventually(t, func() bool {
+		agent, err := provider.GetAgent(ctx, "node-1")
+		return err == nil && agent.HealthStatus == types.HealthStatusInactive
+	}, 3*time.Second, 20*time.Millisecond)
+}
@@ -22,6 +22,9 @@ type NodeEvent struct {
 	Timestamp time.Time   `json:"timestamp"`
 }
 
+// eventDedupWindow is how long an identical event for the same node is suppressed.
+const eventDedupWindow = time.Second
+
 // UIService provides data optimized for the UI and manages SSE clients.
 type UIService struct {
 	storage       storage.StorageProvider
@@ -68,6 +71,8 @@ type AgentNodeSummaryForUI struct {
 	ReasonerCount   int                        `json:"reasoner_count"`
 	SkillCount      int                        `json:"skill_count"`
 	LastHeartbeat   time.Time                  `json:"last_heartbeat"`
+	// State is the unified agent state, set when the event comes from the status manager.
+	State types.AgentState `json:"state,omitempty"`
 
 	// New MCP fields
 	MCPSummary *domain.MCPSummaryForUI `json:"mcp_summary,omitempty"`
@@ -276,21 +281,25 @@ func (s *UIService) DeregisterClient(clientChan chan NodeEvent) {
 
 // BroadcastEvent sends an event to all registered SSE clients with deduplication.
 func (s *UIService) BroadcastEvent(eventType string, node interface{}) {
+	s.broadcast(eventType, node)
+}
+
+// broadcast sends an event to all registered SSE clients and reports whether it was
+// sent, i.e. not dropped as a duplicate.
+func (s *UIService) broadcast(eventType string, node interface{}) bool {
 	event := NodeEvent{
 		Type:      eventType,
 		Node:      node,
 		Timestamp: time.Now(),
 	}
 
-	// Check for event deduplication
-	if s.isDuplicateEvent(event) {
+	// Check for and record the event in one step so concurrent identical events,
+	// such as a reconcile racing a heartbeat, cannot both get through.
+	if !s.claimEvent(event) {
 		logger.Logger.Debug().Msgf("🔄 Skipping duplicate event: %s", eventType)
-		return
+		return false
 	}
 
-	// Cache the event for deduplication
-	s.cacheEvent(event)
-
 	// Broadcast to all clients with improved error handling
 	var failedClients []chan NodeEvent
 	clientCount := 0
@@ -319,6 +328,7 @@ func (s *UIService) BroadcastEvent(eventType string, node interface{}) {
 	}
 
 	logger.Logger.Debug().Msgf("📡 Broadcasted %s event to %d clients (%d failed)", eventType, clientCount-len(failedClients), len(failedClients))
+	return true
 }
 
 // countClients returns the number of active SSE clients.
@@ -349,8 +359,24 @@ func (s *UIService) OnAgentRegistered(node *types.AgentNode) {
 }
 
 // OnNodeStatusChanged is a callback for when an agent's status (health or lifecycle) changes.
-// It sends a single, consolidated event to the frontend.
+// It sends a single, consolidated event to the frontend, deriving the state from the
+// node's health and lifecycle status.
 func (s *UIService) OnNodeStatusChanged(node *types.AgentNode) {
+	status := types.FromLegacyStatus(node.HealthStatus, node.LifecycleStatus, node.LastHeartbeat)
+	s.broadcastNodeStatus(node, status.State)
+}
+
+// OnNodeUnifiedStatusChanged is OnNodeStatusChanged for callers that know the unified
+// status; its state is included in the event and in deduplication.
+func (s *UIService) OnNodeUnifiedStatusChanged(node *types.AgentNode, status *types.AgentStatus) {
+	var state types.AgentState
+	if status != nil {
+		state = status.State
+	}
+	s.broadcastNodeStatus(node, state)
+}
+
+func (s *UIService) broadcastNodeStatus(node *types.AgentNode, state types.AgentState) {
 	summary := AgentNodeSummaryForUI{
 		ID:              node.ID,
 		TeamID:          node.TeamID,
@@ -360,8 +386,12 @@ func (s *UIService) OnNodeStatusChanged(node *types.AgentNode) {
 		ReasonerCount:   len(node.Reasoners),
 		SkillCount:      len(node.Skills),
 		LastHeartbeat:   node.LastHeartbeat,
+		State:           state,
+	}
+	if !s.broadcast("node_status_changed", summary) {
+		// Nothing changed for the UI, so the reasoner events would be duplicates too.
+		return
 	}
-	s.BroadcastEvent("node_status_changed", summary)
 
 	// CRITICAL FIX: Also broadcast reasoner-specific events for immediate UI updates
 	s.OnReasonerStatusChanged(node)
@@ -572,59 +602,46 @@ func (s *UIService) StopHeartbeat() {
 	logger.Logger.Debug().Msg("🫀 SSE heartbeat mechanism stopped")
 }
 
-// isDuplicateEvent checks if an event is a duplicate of the last cached event
-func (s *UIService) isDuplicateEvent(event NodeEvent) bool {
-	s.eventCacheMutex.RLock()
-	defer s.eventCacheMutex.RUnlock()
-
-	// Create a cache key based on event type and node data
+// claimEvent reports whether event should be broadcast and, if so, records it as the
+// latest event for its key. An event is dropped when the previous event for the same key
+// arrived within eventDedupWindow and, for status events, carried the same status.
+func (s *UIService) claimEvent(event NodeEvent) bool {
 	cacheKey := s.getEventCacheKey(event)
 	if cacheKey == "" {
-		return false // Can't determine, allow the event
+		return true // Can't determine, allow the event
 	}
 
-	lastEvent, exists := s.lastEventCache[cacheKey]
-	if !exists {
-		return false
-	}
+	s.eventCacheMutex.Lock()
+	defer s.eventCacheMutex.Unlock()
 
-	// Check if events are too close in time (within 1 second)
-	if time.Since(lastEvent.Timestamp) < 1*time.Second {
-		// For status events, also check if the actual status changed
-		if event.Type == "node_status_changed" || event.Type == "node_health_changed" {
-			return s.compareStatusEvents(lastEvent, event)
+	if lastEvent, exists := s.lastEventCache[cacheKey]; exists && event.Timestamp.Sub(lastEvent.Timestamp) < eventDedupWindow {
+		// For status events, only drop the event if the actual status is unchanged
+		if event.Type != "node_status_changed" && event.Type != "node_health_changed" {
+			return false
+		}
+		if s.compareStatusEvents(lastEvent, event) {
+			return false
 		}
-		return true
 	}
 
-	return false
-}
+	s.lastEventCache[cacheKey] = event
 
-// cacheEvent caches an event for deduplication
-func (s *UIService) cacheEvent(event NodeEvent) {
-	s.eventCacheMutex.Lock()
-	defer s.eventCacheMutex.Unlock()
-
-	cacheKey := s.getEventCacheKey(event)
-	if cacheKey != "" {
-		s.lastEventCache[cacheKey] = event
-
-		// Clean up old cache entries (keep only last 100)
-		if len(s.lastEventCache) > 100 {
-			// Remove oldest entries
-			oldestTime := time.Now()
-			oldestKey := ""
-			for key, cachedEvent := range s.lastEventCache {
-				if cachedEvent.Timestamp.Before(oldestTime) {
-					oldestTime = cachedEvent.Timestamp
-					oldestKey = key
-				}
-			}
-			if oldestKey != "" {
-				delete(s.lastEventCache, oldestKey)
+	// Clean up old cache entries (keep only last 100)
+	if len(s.lastEventCache) > 100 {
+		// Remove oldest entries
+		oldestTime := time.Now()
+		oldestKey := ""
+		for key, cachedEvent := range s.lastEventCache {
+			if cachedEvent.Timestamp.Before(oldestTime) {
+				oldestTime = cachedEvent.Timestamp
+				oldestKey = key
 			}
 		}
+		if oldestKey != "" {
+			delete(s.lastEventCache, oldestKey)
+		}
 	}
+	return true
 }
 
 // getEventCacheKey generates a cache key for an event
@@ -659,8 +676,9 @@ func (s *UIService) compareStatusEvents(lastEvent, newEvent NodeEvent) bool {
 		return false // Can't compare, allow the event
 	}
 
-	// Compare relevant status fields
-	return lastSummary.HealthStatus == newSummary.HealthStatus &&
+	// Compare the full (state, health, lifecycle) status
+	return lastSummary.State == newSummary.State &&
+		lastSummary.HealthStatus == newSummary.HealthStatus &&
 		lastSummary.LifecycleStatus == newSummary.LifecycleStatus
 }
 
@@ -687,6 +705,15 @@ func (s *UIService) GetNodeUnifiedStatus(ctx context.Context, nodeID string) (*t
 	return s.GetUnifiedNodeStatus(ctx, nodeID)
 }
 
+// GetNodeStatusSnapshot gets the best-known unified status for a node without a live health check
+func (s *UIService) GetNodeStatusSnapshot(ctx context.Context, nodeID string) (*types.AgentStatus, error) {
+	if s.statusManager == nil {
+		return nil, fmt.Errorf("status manager not available")
+	}
+
+	return s.statusManager.GetAgentStatusSnapshot(ctx, nodeID, nil)
+}
+
 // BulkNodeStatus gets unified status for multiple nodes
 func (s *UIService) BulkNodeStatus(ctx context.Context, nodeIDs []string) (map[string]*types.AgentStatus, error) {
 	if s.statusManager == nil {
@@ -706,6 +733,16 @@ func (s *UIService) BulkNodeStatus(ctx context.Context, nodeIDs []string) (map[s
 	return statuses, nil
 }
 
+// GetAllNodeStatusSnapshots gets the best-known unified status of every registered node
+// without live health checks
+func (s *UIService) GetAllNodeStatusSnapshots(ctx context.Context) (map[string]*types.AgentStatus, error) {
+	if s.statusManager == nil {
+		return nil, fmt.Errorf("status manager not available")
+	}
+
+	return s.statusManager.GetAllAgentStatusSnapshots(ctx)
+}
+
 // RefreshAllNodeStatus refreshes status for all registered nodes
 func (s *UIService) RefreshAllNodeStatus(ctx context.Context) (map[string]*types.AgentStatus, error) {
 	if s.statusManager == nil {
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// collectUIEvents drains client into a slice until the returned stop function is called.
+func collectUIEvents(client chan NodeEvent) func() []NodeEvent {
+	var (
+		mu       sync.Mutex
+		received []NodeEvent
+		done     = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		for event := range client {
+			mu.Lock()
+			received = append(received, event)
+			mu.Unlock()
+		}
+	}()
+	return func() []NodeEvent {
+		<-done
+		mu.Lock()
+		defer mu.Unlock()
+		return received
+	}
+}
+
+func countUIEvents(events []NodeEvent, eventType string) int {
+	count := 0
+	for _, event := range events {
+		if event.Type == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+func TestUIServiceDropsDuplicateStatusEvents(t *testing.T) {
+	svc := NewUIService(nil, nil, nil, nil)
+	defer svc.StopHeartbeat()
+
+	client := svc.RegisterClient()
+	wait := collectUIEvents(client)
+
+	node := &types.AgentNode{
+		ID:              "node-1",
+		HealthStatus:    types.HealthStatusActive,
+		LifecycleStatus: types.AgentStatusReady,
+		Reasoners:       []types.ReasonerDefinition{{ID: "summarize"}},
+	}
+	active := &types.AgentStatus{State: types.AgentStateActive}
+
+	// A reconcile and a heartbeat reporting the same change in quick succession.
+	svc.OnNodeUnifiedStatusChanged(node, active)
+	svc.OnNodeStatusChanged(node)
+
+	// A real change is still broadcast inside the window.
+	offline := *node
+	offline.HealthStatus = types.HealthStatusInactive
+	offline.LifecycleStatus = types.AgentStatusOffline
+	svc.OnNodeUnifiedStatusChanged(&offline, &types.AgentStatus{State: types.AgentStateInactive})
+
+	svc.DeregisterClient(client)
+	received := wait()
+
+	require.Equal(t, 2, countUIEvents(received, "node_status_changed"))
+	require.Equal(t, 2, countUIEvents(received, "reasoner_status_changed"), "reasoner events follow only broadcast status events")
+
+	first := received[0].Node.(AgentNodeSummaryForUI)
+	require.Equal(t, types.AgentStateActive, first.State)
+}
+
+func TestUIServiceStatusEventsDedupeOnlyWithinWindow(t *testing.T) {
+	svc := NewUIService(nil, nil, nil, nil)
+	defer svc.StopHeartbeat()
+
+	summary := AgentNodeSummaryForUI{ID: "node-1", HealthStatus: types.HealthStatusActive, State: types.AgentStateActive}
+	now := time.Now()
+
+	require.True(t, svc.claimEvent(NodeEvent{Type: "node_status_changed", Node: summary, Timestamp: now}))
+	require.False(t, svc.claimEvent(NodeEvent{Type: "node_status_changed", Node: summary, Timestamp: now.Add(500 * time.Millisecond)}))
+	require.True(t, svc.claimEvent(NodeEvent{Type: "node_status_changed", Node: summary, Timestamp: now.Add(eventDedupWindow + time.Millisecond)}))
+
+	starting := summary
+	starting.State = types.AgentStateStarting
+	require.True(t, svc.claimEvent(NodeEvent{Type: "node_status_changed", Node: starting, Timestamp: now.Add(eventDedupWindow + 2*time.Millisecond)}))
+
+	// An event without a state is not a duplicate of one with a state.
+	stateless := summary
+	stateless.State = ""
+	require.True(t, svc.claimEvent(NodeEvent{Type: "node_status_changed", Node: stateless, Timestamp: now.Add(eventDedupWindow + 3*time.Millisecond)}))
+	require.True(t, svc.claimEvent(NodeEvent{Type: "node_status_changed", Node: starting, Timestamp: now.Add(eventDedupWindow + 4*time.Millisecond)}))
+}
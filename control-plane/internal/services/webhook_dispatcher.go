@@ -47,9 +47,10 @@ type WebhookDispatcherConfig struct {
 }
 
 type webhookDispatcher struct {
-	store  WebhookStore
-	cfg    WebhookDispatcherConfig
-	client *http.Client
+	store    WebhookStore
+	payloads PayloadStore
+	cfg      WebhookDispatcherConfig
+	client   *http.Client
 
 	once   sync.Once
 	xctx   context.Context
@@ -63,11 +64,14 @@ type webhookJob struct {
 	ExecutionID string
 }
 
-func NewWebhookDispatcher(store WebhookStore, cfg WebhookDispatcherConfig) WebhookDispatcher {
+// NewWebhookDispatcher creates a dispatcher for execution webhooks. payloads is used to load
+// results that were offloaded from the execution record; it may be nil.
+func NewWebhookDispatcher(store WebhookStore, payloads PayloadStore, cfg WebhookDispatcherConfig) WebhookDispatcher {
 	normalized := normalizeWebhookConfig(cfg)
 	return &webhookDispatcher{
-		store: store,
-		cfg:   normalized,
+		store:    store,
+		payloads: payloads,
+		cfg:      normalized,
 		client: &http.Client{
 			Timeout: normalized.Timeout,
 		},
@@ -351,23 +355,24 @@ func (d *webhookDispatcher) process(job webhookJob) {
 		errorMessage = &errCopy
 	}
 
+	attemptCount := webhook.AttemptCount + 1
+	now := time.Now().UTC()
+
 	event := &types.ExecutionWebhookEvent{
-		ExecutionID:  webhook.ExecutionID,
-		EventType:    eventType,
-		Status:       statusLabel,
-		HTTPStatus:   httpStatus,
-		Payload:      body,
-		ResponseBody: responseBody,
-		ErrorMessage: errorMessage,
-		CreatedAt:    time.Now().UTC(),
+		ExecutionID:   webhook.ExecutionID,
+		EventType:     eventType,
+		Status:        statusLabel,
+		AttemptNumber: attemptCount,
+		HTTPStatus:    httpStatus,
+		Payload:       body,
+		ResponseBody:  responseBody,
+		ErrorMessage:  errorMessage,
+		CreatedAt:     now,
 	}
 	if err := d.store.StoreExecutionWebhookEvent(ctx, event); err != nil {
 		logger.Logger.Warn().Err(err).Str("execution_id", webhook.ExecutionID).Msg("failed to record webhook delivery attempt")
 	}
 
-	attemptCount := webhook.AttemptCount + 1
-	now := time.Now().UTC()
-
 	update := types.ExecutionWebhookStateUpdate{
 		AttemptCount:  attemptCount,
 		LastAttemptAt: &now,
@@ -410,7 +415,7 @@ func (d *webhookDispatcher) buildPayload(ctx context.Context, exec *types.Execut
 	}
 
 	if eventType == types.WebhookEventExecutionCompleted {
-		payload.Result = decodeExecutionPayload(exec.ResultPayload)
+		payload.Result = decodeExecutionPayload(d.resultPayload(ctx, exec))
 	} else {
 		payload.ErrorMessage = exec.ErrorMessage
 	}
@@ -418,6 +423,26 @@ func (d *webhookDispatcher) buildPayload(ctx context.Context, exec *types.Execut
 	return payload
 }
 
+// resultPayload returns the execution result, loading it from the payload store when it
+// was offloaded from the record.
+func (d *webhookDispatcher) resultPayload(ctx context.Context, exec *types.Execution) json.RawMessage {
+	if len(exec.ResultPayload) > 0 || exec.ResultURI == nil || d.payloads == nil {
+		return exec.ResultPayload
+	}
+	reader, err := d.payloads.Open(ctx, *exec.ResultURI)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Str("execution_id", exec.ExecutionID).Msg("failed to open offloaded result payload for webhook")
+		return nil
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Str("execution_id", exec.ExecutionID).Msg("failed to read offloaded result payload for webhook")
+		return nil
+	}
+	return json.RawMessage(data)
+}
+
 func (d *webhookDispatcher) resolveTargetType(ctx context.Context, exec *types.Execution) string {
 	agent, err := d.store.GetAgent(ctx, exec.NodeID)
 	if err != nil || agent == nil {
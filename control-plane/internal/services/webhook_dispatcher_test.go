@@ -117,7 +117,7 @@ func TestWebhookDispatcher_NewWebhookDispatcher(t *testing.T) {
 		ResponseBodyLimit: 8192,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	require.NotNil(t, dispatcher)
 }
 
@@ -126,7 +126,7 @@ func TestWebhookDispatcher_NormalizeWebhookConfig(t *testing.T) {
 
 	// Test with zero values (should use defaults)
 	cfg := WebhookDispatcherConfig{}
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	require.NotNil(t, dispatcher)
 
 	// Test with custom values
@@ -138,7 +138,7 @@ func TestWebhookDispatcher_NormalizeWebhookConfig(t *testing.T) {
 		WorkerCount:   4,
 		QueueSize:     200,
 	}
-	dispatcher2 := NewWebhookDispatcher(store, cfg2)
+	dispatcher2 := NewWebhookDispatcher(store, nil, cfg2)
 	require.NotNil(t, dispatcher2)
 }
 
@@ -151,7 +151,7 @@ func TestWebhookDispatcher_Start_Success(t *testing.T) {
 		QueueSize:    10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -171,7 +171,7 @@ func TestWebhookDispatcher_Start_NilStore(t *testing.T) {
 		QueueSize:   10,
 	}
 
-	dispatcher := NewWebhookDispatcher(nil, cfg)
+	dispatcher := NewWebhookDispatcher(nil, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -188,7 +188,7 @@ func TestWebhookDispatcher_Start_AlreadyStarted(t *testing.T) {
 		QueueSize:    10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -209,7 +209,7 @@ func TestWebhookDispatcher_Stop_NotStarted(t *testing.T) {
 	store := newMockWebhookStore()
 	cfg := WebhookDispatcherConfig{}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Stop(ctx)
@@ -225,7 +225,7 @@ func TestWebhookDispatcher_Notify_Success(t *testing.T) {
 		QueueSize:    10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -265,7 +265,7 @@ func TestWebhookDispatcher_Notify_EmptyExecutionID(t *testing.T) {
 		QueueSize:   10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -286,7 +286,7 @@ func TestWebhookDispatcher_Notify_NotStarted(t *testing.T) {
 	store := newMockWebhookStore()
 	cfg := WebhookDispatcherConfig{}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Notify(ctx, "exec-1")
@@ -303,7 +303,7 @@ func TestWebhookDispatcher_Notify_NoWebhook(t *testing.T) {
 		QueueSize:    10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -329,7 +329,7 @@ func TestWebhookDispatcher_Notify_AlreadyDelivered(t *testing.T) {
 		QueueSize:    10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -362,7 +362,7 @@ func TestWebhookDispatcher_Notify_AlreadyFailed(t *testing.T) {
 		QueueSize:    10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -410,7 +410,7 @@ func TestWebhookDispatcher_DispatchWebhook_Success(t *testing.T) {
 		QueueSize:    10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -468,7 +468,7 @@ func TestWebhookDispatcher_DispatchWebhook_WithSecret(t *testing.T) {
 		QueueSize:    10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -525,7 +525,7 @@ func TestWebhookDispatcher_DispatchWebhook_RetryOnFailure(t *testing.T) {
 		QueueSize:      10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -557,6 +557,55 @@ func TestWebhookDispatcher_DispatchWebhook_RetryOnFailure(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestWebhookDispatcher_RecordsAttemptHistory(t *testing.T) {
+	responses := []int{http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusOK}
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(responses[calls])
+		calls++
+	}))
+	defer server.Close()
+
+	store := newMockWebhookStore()
+	executionID := "exec-history-1"
+	store.executions[executionID] = &types.Execution{
+		ExecutionID: executionID,
+		Status:      "succeeded",
+		StartedAt:   time.Now(),
+	}
+	store.webhooks[executionID] = &types.ExecutionWebhook{
+		ExecutionID: executionID,
+		URL:         server.URL + "/webhook",
+		Status:      types.ExecutionWebhookStatusPending,
+	}
+
+	// Drive attempts directly so each retry is deterministic
+	d := NewWebhookDispatcher(store, nil, WebhookDispatcherConfig{MaxAttempts: 5}).(*webhookDispatcher)
+	d.xctx = context.Background()
+	for range responses {
+		d.process(webhookJob{ExecutionID: executionID})
+	}
+
+	require.Len(t, store.webhookEvents, len(responses))
+	for i, event := range store.webhookEvents {
+		require.Equal(t, i+1, event.AttemptNumber)
+		require.NotNil(t, event.HTTPStatus)
+		require.Equal(t, responses[i], *event.HTTPStatus)
+		require.False(t, event.CreatedAt.IsZero())
+		if i > 0 {
+			require.False(t, event.CreatedAt.Before(store.webhookEvents[i-1].CreatedAt))
+		}
+	}
+	for _, event := range store.webhookEvents[:2] {
+		require.Equal(t, "failed", event.Status)
+		require.NotNil(t, event.ErrorMessage)
+	}
+	require.Equal(t, "delivered", store.webhookEvents[2].Status)
+	require.Nil(t, store.webhookEvents[2].ErrorMessage)
+	require.Equal(t, types.ExecutionWebhookStatusDelivered, store.webhooks[executionID].Status)
+	require.Equal(t, 3, store.webhooks[executionID].AttemptCount)
+}
+
 func TestWebhookDispatcher_GenerateHMACSignature(t *testing.T) {
 	secret := "test-secret"
 	body := []byte(`{"test": "data"}`)
@@ -629,7 +678,7 @@ func TestWebhookDispatcher_MaxRetriesExceeded(t *testing.T) {
 		QueueSize:      10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -687,7 +736,7 @@ func TestWebhookDispatcher_TimeoutHandling(t *testing.T) {
 		QueueSize:   10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -737,7 +786,7 @@ func TestWebhookDispatcher_CustomHeaders(t *testing.T) {
 		QueueSize:   10,
 	}
 
-	dispatcher := NewWebhookDispatcher(store, cfg)
+	dispatcher := NewWebhookDispatcher(store, nil, cfg)
 	ctx := context.Background()
 
 	err := dispatcher.Start(ctx)
@@ -794,3 +843,19 @@ func timePtr(t time.Time) *time.Time {
 func int64Ptr(i int64) *int64 {
 	return &i
 }
+
+func TestWebhookDispatcher_BuildPayloadLoadsOffloadedResult(t *testing.T) {
+	payloads := NewFilePayloadStore(t.TempDir())
+	record, err := payloads.SaveBytes(context.Background(), []byte(`{"answer":42}`))
+	require.NoError(t, err)
+
+	dispatcher := NewWebhookDispatcher(newMockWebhookStore(), payloads, WebhookDispatcherConfig{}).(*webhookDispatcher)
+	exec := &types.Execution{
+		ExecutionID: "exec-offloaded",
+		Status:      "succeeded",
+		ResultURI:   &record.URI,
+	}
+
+	payload := dispatcher.buildPayload(context.Background(), exec, types.WebhookEventExecutionCompleted)
+	require.Equal(t, map[string]interface{}{"answer": float64(42)}, payload.Result)
+}
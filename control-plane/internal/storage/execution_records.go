@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,9 +14,26 @@ import (
 	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
 )
 
+// ErrExecutionExists is returned by CreateExecutionRecord when the execution ID is
+// already taken.
+var ErrExecutionExists = errors.New("execution already exists")
+
 // maxNodesForDepthCalc caps the number of executions for which we compute DAG depth to avoid heavy queries.
 const maxNodesForDepthCalc = 1000
 
+// insertExecutionQuery inserts a single row into the simplified executions schema.
+const insertExecutionQuery = `
+		INSERT INTO executions (
+			execution_id, run_id, parent_execution_id, replay_of,
+			agent_node_id, reasoner_id, node_id,
+			status, input_payload, result_payload, error_message, error_category,
+			input_uri, result_uri, input_size, result_size,
+			session_id, actor_id,
+			started_at, completed_at, duration_ms,
+			notes, tags,
+			created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
 // CreateExecutionRecord inserts a new execution row using the simplified schema.
 func (ls *LocalStorage) CreateExecutionRecord(ctx context.Context, exec *types.Execution) error {
 	if exec == nil {
@@ -23,41 +42,49 @@ func (ls *LocalStorage) CreateExecutionRecord(ctx context.Context, exec *types.E
 
 	db := ls.requireSQLDB()
 
-	now := time.Now().UTC()
+	args, err := executionInsertArgs(exec, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, insertExecutionQuery, args...); err != nil {
+		if isUniqueConstraintError(err) {
+			return fmt.Errorf("insert execution %s: %w", exec.ExecutionID, ErrExecutionExists)
+		}
+		return fmt.Errorf("insert execution: %w", err)
+	}
+
+	return nil
+}
+
+// executionInsertArgs stamps the creation timestamps on exec and returns the arguments for
+// insertExecutionQuery.
+func executionInsertArgs(exec *types.Execution, now time.Time) ([]interface{}, error) {
 	if exec.StartedAt.IsZero() {
 		exec.StartedAt = now
 	}
 	exec.CreatedAt = now
 	exec.UpdatedAt = now
 
-	insert := `
-		INSERT INTO executions (
-			execution_id, run_id, parent_execution_id,
-			agent_node_id, reasoner_id, node_id,
-			status, input_payload, result_payload, error_message,
-			input_uri, result_uri,
-			session_id, actor_id,
-			started_at, completed_at, duration_ms,
-			notes,
-			created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-
 	// Serialize notes to JSON
 	var notesJSON []byte
 	if len(exec.Notes) > 0 {
 		var err error
 		notesJSON, err = json.Marshal(exec.Notes)
 		if err != nil {
-			return fmt.Errorf("marshal notes: %w", err)
+			return nil, fmt.Errorf("marshal notes: %w", err)
 		}
 	}
+	tagsJSON, err := marshalExecutionTags(exec.Tags)
+	if err != nil {
+		return nil, err
+	}
 
-	_, err := db.ExecContext(
-		ctx,
-		insert,
+	return []interface{}{
 		exec.ExecutionID,
 		exec.RunID,
 		exec.ParentExecutionID,
+		exec.ReplayOf,
 		exec.AgentNodeID,
 		exec.ReasonerID,
 		exec.NodeID,
@@ -65,34 +92,33 @@ func (ls *LocalStorage) CreateExecutionRecord(ctx context.Context, exec *types.E
 		bytesOrNil(exec.InputPayload),
 		bytesOrNil(exec.ResultPayload),
 		exec.ErrorMessage,
+		exec.ErrorCategory,
 		exec.InputURI,
 		exec.ResultURI,
+		exec.InputSize,
+		exec.ResultSize,
 		exec.SessionID,
 		exec.ActorID,
 		exec.StartedAt,
 		exec.CompletedAt,
 		exec.DurationMS,
 		notesJSON,
+		tagsJSON,
 		exec.CreatedAt,
 		exec.UpdatedAt,
-	)
-	if err != nil {
-		return fmt.Errorf("insert execution: %w", err)
-	}
-
-	return nil
+	}, nil
 }
 
 // GetExecutionRecord fetches a single execution row by execution_id.
 func (ls *LocalStorage) GetExecutionRecord(ctx context.Context, executionID string) (*types.Execution, error) {
 	query := `
-		SELECT execution_id, run_id, parent_execution_id,
+		SELECT execution_id, run_id, parent_execution_id, replay_of,
 		       agent_node_id, reasoner_id, node_id,
-		       status, input_payload, result_payload, error_message,
-		       input_uri, result_uri,
+		       status, input_payload, result_payload, error_message, error_category,
+		       input_uri, result_uri, input_size, result_size,
 		       session_id, actor_id,
 		       started_at, completed_at, duration_ms,
-		       notes,
+		       notes, tags,
 		       created_at, updated_at
 		FROM executions
 	WHERE execution_id = ?`
@@ -123,13 +149,13 @@ func (ls *LocalStorage) UpdateExecutionRecord(ctx context.Context, executionID s
 	defer rollbackTx(tx, "UpdateExecutionRecord:"+executionID)
 
 	row := tx.QueryRowContext(ctx, `
-		SELECT execution_id, run_id, parent_execution_id,
+		SELECT execution_id, run_id, parent_execution_id, replay_of,
 		       agent_node_id, reasoner_id, node_id,
-		       status, input_payload, result_payload, error_message,
-		       input_uri, result_uri,
+		       status, input_payload, result_payload, error_message, error_category,
+		       input_uri, result_uri, input_size, result_size,
 		       session_id, actor_id,
 		       started_at, completed_at, duration_ms,
-		       notes,
+		       notes, tags,
 		       created_at, updated_at
 		FROM executions
 		WHERE execution_id = ?`, executionID)
@@ -160,11 +186,16 @@ func (ls *LocalStorage) UpdateExecutionRecord(ctx context.Context, executionID s
 			return nil, fmt.Errorf("marshal notes: %w", err)
 		}
 	}
+	tagsJSON, err := marshalExecutionTags(updated.Tags)
+	if err != nil {
+		return nil, err
+	}
 
 	update := `
 		UPDATE executions SET
 			run_id = ?,
 			parent_execution_id = ?,
+			replay_of = ?,
 			agent_node_id = ?,
 			reasoner_id = ?,
 			node_id = ?,
@@ -172,14 +203,18 @@ func (ls *LocalStorage) UpdateExecutionRecord(ctx context.Context, executionID s
 			input_payload = ?,
 			result_payload = ?,
 			error_message = ?,
+			error_category = ?,
 			input_uri = ?,
 			result_uri = ?,
+			input_size = ?,
+			result_size = ?,
 			session_id = ?,
 			actor_id = ?,
 			started_at = ?,
 			completed_at = ?,
 			duration_ms = ?,
 			notes = ?,
+			tags = ?,
 			updated_at = ?
 		WHERE execution_id = ?`
 
@@ -188,6 +223,7 @@ func (ls *LocalStorage) UpdateExecutionRecord(ctx context.Context, executionID s
 		update,
 		updated.RunID,
 		updated.ParentExecutionID,
+		updated.ReplayOf,
 		updated.AgentNodeID,
 		updated.ReasonerID,
 		updated.NodeID,
@@ -195,14 +231,18 @@ func (ls *LocalStorage) UpdateExecutionRecord(ctx context.Context, executionID s
 		bytesOrNil(updated.InputPayload),
 		bytesOrNil(updated.ResultPayload),
 		updated.ErrorMessage,
+		updated.ErrorCategory,
 		updated.InputURI,
 		updated.ResultURI,
+		updated.InputSize,
+		updated.ResultSize,
 		updated.SessionID,
 		updated.ActorID,
 		updated.StartedAt,
 		updated.CompletedAt,
 		updated.DurationMS,
 		notesJSON,
+		tagsJSON,
 		updated.UpdatedAt,
 		updated.ExecutionID,
 	)
@@ -218,8 +258,84 @@ func (ls *LocalStorage) UpdateExecutionRecord(ctx context.Context, executionID s
 	return updated, nil
 }
 
+// DeleteExecutionRecord removes an execution row along with its workflow execution mirror,
+// lifecycle events, and webhook state. Payload files referenced by the record are left to
+// the caller since they live outside the database.
+func (ls *LocalStorage) DeleteExecutionRecord(ctx context.Context, executionID string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context cancelled during delete execution record: %w", err)
+	}
+
+	db := ls.requireSQLDB()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollbackTx(tx, "DeleteExecutionRecord:"+executionID)
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM executions WHERE execution_id = ?`, executionID)
+	if err != nil {
+		return fmt.Errorf("delete execution: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected for execution deletion: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("execution with ID '%s' not found", executionID)
+	}
+
+	related := []string{
+		`DELETE FROM workflow_executions WHERE execution_id = ?`,
+		`DELETE FROM workflow_execution_events WHERE execution_id = ?`,
+		`DELETE FROM execution_webhook_events WHERE execution_id = ?`,
+		`DELETE FROM execution_webhooks WHERE execution_id = ?`,
+	}
+	for _, query := range related {
+		if _, err := tx.ExecContext(ctx, query, executionID); err != nil {
+			return fmt.Errorf("delete execution related rows: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit execution delete: %w", err)
+	}
+
+	return nil
+}
+
 // QueryExecutionRecords runs a filtered query returning all matching executions.
 func (ls *LocalStorage) QueryExecutionRecords(ctx context.Context, filter types.ExecutionFilter) ([]*types.Execution, error) {
+	query, args := buildExecutionRecordsQuery(filter, ls.mode)
+
+	db := ls.requireSQLDB()
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query executions: %w", err)
+	}
+	defer rows.Close()
+
+	var executions []*types.Execution
+	for rows.Next() {
+		exec, err := scanExecution(rows)
+		if err != nil {
+			return nil, err
+		}
+		executions = append(executions, exec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate executions: %w", err)
+	}
+
+	ls.populateWebhookRegistration(ctx, executions)
+
+	return executions, nil
+}
+
+// buildExecutionRecordsQuery renders the SELECT used by QueryExecutionRecords. Session and
+// actor filters combined with the default started_at ordering are served by the
+// idx_executions_session_started and idx_executions_actor_started indexes.
+func buildExecutionRecordsQuery(filter types.ExecutionFilter, mode string) (string, []interface{}) {
 	var (
 		where []string
 		args  []interface{}
@@ -265,16 +381,31 @@ func (ls *LocalStorage) QueryExecutionRecords(ctx context.Context, filter types.
 		where = append(where, "started_at <= ?")
 		args = append(args, filter.EndTime.UTC())
 	}
+	tagKeys := make([]string, 0, len(filter.Tags))
+	for key := range filter.Tags {
+		tagKeys = append(tagKeys, key)
+	}
+	sort.Strings(tagKeys)
+	// LIKE ignores case on SQLite but not on PostgreSQL, so tags are matched with each
+	// backend's case-sensitive substring search instead.
+	tagPredicate := "instr(tags, ?) > 0"
+	if mode == "postgres" {
+		tagPredicate = "strpos(tags, ?) > 0"
+	}
+	for _, key := range tagKeys {
+		where = append(where, tagPredicate)
+		args = append(args, executionTagFragment(key, filter.Tags[key]))
+	}
 
 	queryBuilder := strings.Builder{}
 	queryBuilder.WriteString(`
-		SELECT execution_id, run_id, parent_execution_id,
+		SELECT execution_id, run_id, parent_execution_id, replay_of,
 		       agent_node_id, reasoner_id, node_id,
-		       status, input_payload, result_payload, error_message,
-		       input_uri, result_uri,
+		       status, input_payload, result_payload, error_message, error_category,
+		       input_uri, result_uri, input_size, result_size,
 		       session_id, actor_id,
 		       started_at, completed_at, duration_ms,
-		       notes,
+		       notes, tags,
 		       created_at, updated_at
 		FROM executions`)
 
@@ -282,30 +413,7 @@ func (ls *LocalStorage) QueryExecutionRecords(ctx context.Context, filter types.
 		queryBuilder.WriteString(" WHERE ")
 		queryBuilder.WriteString(strings.Join(where, " AND "))
 	}
-	orderColumn := "started_at"
-	switch filter.SortBy {
-	case "status":
-		orderColumn = "status"
-	case "duration_ms":
-		orderColumn = "duration_ms"
-	case "agent_node_id":
-		orderColumn = "agent_node_id"
-	case "reasoner_id":
-		orderColumn = "reasoner_id"
-	case "execution_id":
-		orderColumn = "execution_id"
-	case "run_id":
-		orderColumn = "run_id"
-	case "created_at":
-		orderColumn = "created_at"
-	case "updated_at":
-		orderColumn = "updated_at"
-	}
-	orderDirection := "DESC"
-	if !filter.SortDescending {
-		orderDirection = "ASC"
-	}
-	queryBuilder.WriteString(" ORDER BY " + orderColumn + " " + orderDirection)
+	queryBuilder.WriteString(" ORDER BY " + executionOrderClause(filter))
 
 	if filter.Limit > 0 {
 		queryBuilder.WriteString(fmt.Sprintf(" LIMIT %d", filter.Limit))
@@ -314,28 +422,57 @@ func (ls *LocalStorage) QueryExecutionRecords(ctx context.Context, filter types.
 		queryBuilder.WriteString(fmt.Sprintf(" OFFSET %d", filter.Offset))
 	}
 
-	db := ls.requireSQLDB()
-	rows, err := db.QueryContext(ctx, queryBuilder.String(), args...)
-	if err != nil {
-		return nil, fmt.Errorf("query executions: %w", err)
+	return queryBuilder.String(), args
+}
+
+// executionOrderClause builds the ORDER BY terms for an execution query. Multi-key sorts are
+// applied in order with repeated columns dropped; otherwise SortBy/SortDescending is used.
+func executionOrderClause(filter types.ExecutionFilter) string {
+	keys := filter.SortKeys
+	if len(keys) == 0 {
+		keys = []types.ExecutionSortKey{{Field: filter.SortBy, Descending: filter.SortDescending}}
 	}
-	defer rows.Close()
 
-	var executions []*types.Execution
-	for rows.Next() {
-		exec, err := scanExecution(rows)
-		if err != nil {
-			return nil, err
+	terms := make([]string, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		column := executionOrderColumn(key.Field)
+		if _, dup := seen[column]; dup {
+			continue
 		}
-		executions = append(executions, exec)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate executions: %w", err)
-	}
+		seen[column] = struct{}{}
 
-	ls.populateWebhookRegistration(ctx, executions)
+		direction := "DESC"
+		if !key.Descending {
+			direction = "ASC"
+		}
+		terms = append(terms, column+" "+direction)
+	}
+	return strings.Join(terms, ", ")
+}
 
-	return executions, nil
+// executionOrderColumn restricts ORDER BY to vetted execution columns, defaulting to started_at.
+func executionOrderColumn(sortBy string) string {
+	switch sortBy {
+	case "status":
+		return "status"
+	case "duration_ms":
+		return "duration_ms"
+	case "agent_node_id":
+		return "agent_node_id"
+	case "reasoner_id":
+		return "reasoner_id"
+	case "execution_id":
+		return "execution_id"
+	case "run_id":
+		return "run_id"
+	case "created_at":
+		return "created_at"
+	case "updated_at":
+		return "updated_at"
+	default:
+		return "started_at"
+	}
 }
 
 // QueryRunSummaries returns aggregated statistics for workflow runs without fetching all execution records.
@@ -974,7 +1111,7 @@ func (ls *LocalStorage) MarkStaleExecutions(ctx context.Context, staleAfter time
 
 	updateStmt, err := tx.PrepareContext(ctx, `
 		UPDATE executions
-		SET status = ?, error_message = ?, completed_at = ?, duration_ms = ?, updated_at = ?
+		SET status = ?, error_message = ?, error_category = ?, completed_at = ?, duration_ms = ?, updated_at = ?
 		WHERE execution_id = ? AND status IN ('running', 'pending', 'queued')`)
 	if err != nil {
 		return 0, fmt.Errorf("prepare stale execution update: %w", err)
@@ -999,6 +1136,7 @@ func (ls *LocalStorage) MarkStaleExecutions(ctx context.Context, staleAfter time
 			ctx,
 			types.ExecutionStatusTimeout,
 			timeoutMessage,
+			types.ExecutionErrorCategoryTimeout,
 			now,
 			durationMS,
 			now,
@@ -1030,21 +1168,26 @@ func scanExecution(scanner interface {
 	var (
 		exec                         types.Execution
 		parentExecutionID, sessionID sql.NullString
+		replayOf                     sql.NullString
 		actorID                      sql.NullString
 		inputURI                     sql.NullString
 		resultURI                    sql.NullString
+		inputSize, resultSize        sql.NullInt64
 		inputPayload                 []byte
 		resultPayload                []byte
 		errorMessage                 sql.NullString
+		errorCategory                sql.NullString
 		completedAt                  sql.NullTime
 		durationMS                   sql.NullInt64
 		notesJSON                    []byte
+		tagsJSON                     []byte
 	)
 
 	err := scanner.Scan(
 		&exec.ExecutionID,
 		&exec.RunID,
 		&parentExecutionID,
+		&replayOf,
 		&exec.AgentNodeID,
 		&exec.ReasonerID,
 		&exec.NodeID,
@@ -1052,14 +1195,18 @@ func scanExecution(scanner interface {
 		&inputPayload,
 		&resultPayload,
 		&errorMessage,
+		&errorCategory,
 		&inputURI,
 		&resultURI,
+		&inputSize,
+		&resultSize,
 		&sessionID,
 		&actorID,
 		&exec.StartedAt,
 		&completedAt,
 		&durationMS,
 		&notesJSON,
+		&tagsJSON,
 		&exec.CreatedAt,
 		&exec.UpdatedAt,
 	)
@@ -1073,6 +1220,9 @@ func scanExecution(scanner interface {
 	if parentExecutionID.Valid {
 		exec.ParentExecutionID = &parentExecutionID.String
 	}
+	if replayOf.Valid {
+		exec.ReplayOf = &replayOf.String
+	}
 	if sessionID.Valid {
 		exec.SessionID = &sessionID.String
 	}
@@ -1086,12 +1236,23 @@ func scanExecution(scanner interface {
 	if errorMessage.Valid {
 		exec.ErrorMessage = &errorMessage.String
 	}
+	if errorCategory.Valid {
+		exec.ErrorCategory = &errorCategory.String
+	}
 	if inputURI.Valid {
 		exec.InputURI = &inputURI.String
 	}
 	if resultURI.Valid {
 		exec.ResultURI = &resultURI.String
 	}
+	if inputSize.Valid {
+		size := inputSize.Int64
+		exec.InputSize = &size
+	}
+	if resultSize.Valid {
+		size := resultSize.Int64
+		exec.ResultSize = &size
+	}
 	if completedAt.Valid {
 		t := completedAt.Time
 		exec.CompletedAt = &t
@@ -1105,10 +1266,37 @@ func scanExecution(scanner interface {
 			return nil, fmt.Errorf("unmarshal notes: %w", err)
 		}
 	}
+	if len(tagsJSON) > 0 {
+		if err := json.Unmarshal(tagsJSON, &exec.Tags); err != nil {
+			return nil, fmt.Errorf("unmarshal tags: %w", err)
+		}
+	}
 
 	return &exec, nil
 }
 
+// marshalExecutionTags encodes tags for the tags column; no tags are stored as NULL.
+// encoding/json writes map keys in sorted order without whitespace, which the tag
+// filter in buildExecutionRecordsQuery relies on.
+func marshalExecutionTags(tags map[string]string) ([]byte, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(tags)
+	if err != nil {
+		return nil, fmt.Errorf("marshal tags: %w", err)
+	}
+	return encoded, nil
+}
+
+// executionTagFragment returns the substring the stored tags JSON contains when it holds
+// key=value. Both halves are JSON-encoded exactly as marshalExecutionTags writes them.
+func executionTagFragment(key, value string) string {
+	encodedKey, _ := json.Marshal(key)
+	encodedValue, _ := json.Marshal(value)
+	return string(encodedKey) + ":" + string(encodedValue)
+}
+
 func (ls *LocalStorage) enrichExecutionWebhook(ctx context.Context, exec *types.Execution, includeEvents bool) {
 	if exec == nil {
 		return
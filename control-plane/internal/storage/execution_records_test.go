@@ -1,6 +1,9 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -58,6 +61,241 @@ func TestQueryRunSummariesParsesTextTimestamps(t *testing.T) {
 	require.Equal(t, summary.LatestStarted, base.Add(-1*time.Minute))
 }
 
+func TestQueryExecutionRecordsMultiKeySort(t *testing.T) {
+	ls, ctx := setupLocalStorage(t)
+
+	base := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	executions := []*types.Execution{
+		{ExecutionID: "exec-1", Status: string(types.ExecutionStatusSucceeded), StartedAt: base},
+		{ExecutionID: "exec-2", Status: string(types.ExecutionStatusFailed), StartedAt: base.Add(time.Minute)},
+		{ExecutionID: "exec-3", Status: string(types.ExecutionStatusSucceeded), StartedAt: base.Add(2 * time.Minute)},
+		{ExecutionID: "exec-4", Status: string(types.ExecutionStatusFailed), StartedAt: base.Add(3 * time.Minute)},
+	}
+	for _, exec := range executions {
+		exec.RunID = "run-sort"
+		exec.AgentNodeID = "agent-1"
+		exec.ReasonerID = "reasoner"
+		exec.NodeID = "agent-1"
+		require.NoError(t, ls.CreateExecutionRecord(ctx, exec))
+	}
+
+	results, err := ls.QueryExecutionRecords(ctx, types.ExecutionFilter{
+		SortKeys: []types.ExecutionSortKey{
+			{Field: "status", Descending: false},
+			{Field: "started_at", Descending: true},
+		},
+	})
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(results))
+	for _, exec := range results {
+		ids = append(ids, exec.ExecutionID)
+	}
+	require.Equal(t, []string{"exec-4", "exec-2", "exec-3", "exec-1"}, ids)
+
+	// Unknown fields fall back to a vetted column instead of reaching the SQL.
+	require.Equal(t, "status ASC, started_at DESC", executionOrderClause(types.ExecutionFilter{
+		SortKeys: []types.ExecutionSortKey{
+			{Field: "status"},
+			{Field: "started_at; DROP TABLE executions", Descending: true},
+			{Field: "started_at"},
+		},
+	}))
+	require.Equal(t, "duration_ms DESC", executionOrderClause(types.ExecutionFilter{SortBy: "duration_ms", SortDescending: true}))
+}
+
+func TestCreateExecutionRecordRejectsDuplicateID(t *testing.T) {
+	ls, ctx := setupLocalStorage(t)
+
+	exec := &types.Execution{
+		ExecutionID: "exec-dup",
+		RunID:       "run-dup",
+		AgentNodeID: "agent-1",
+		ReasonerID:  "reasoner",
+		NodeID:      "agent-1",
+		Status:      string(types.ExecutionStatusPending),
+	}
+	require.NoError(t, ls.CreateExecutionRecord(ctx, exec))
+
+	err := ls.CreateExecutionRecord(ctx, exec)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrExecutionExists), "unexpected error: %v", err)
+}
+
+func TestExecutionRecordPersistsPayloadSizes(t *testing.T) {
+	ls, ctx := setupLocalStorage(t)
+
+	inputSize := int64(2048)
+	require.NoError(t, ls.CreateExecutionRecord(ctx, &types.Execution{
+		ExecutionID: "exec-sizes",
+		RunID:       "run-sizes",
+		AgentNodeID: "agent-1",
+		ReasonerID:  "reasoner",
+		NodeID:      "agent-1",
+		Status:      string(types.ExecutionStatusRunning),
+		InputSize:   &inputSize,
+	}))
+
+	_, err := ls.UpdateExecutionRecord(ctx, "exec-sizes", func(current *types.Execution) (*types.Execution, error) {
+		resultSize := int64(4096)
+		current.ResultSize = &resultSize
+		return current, nil
+	})
+	require.NoError(t, err)
+
+	stored, err := ls.GetExecutionRecord(ctx, "exec-sizes")
+	require.NoError(t, err)
+	require.NotNil(t, stored.InputSize)
+	require.Equal(t, int64(2048), *stored.InputSize)
+	require.NotNil(t, stored.ResultSize)
+	require.Equal(t, int64(4096), *stored.ResultSize)
+}
+
+func TestExecutionRecordPersistsReplayOf(t *testing.T) {
+	ls, ctx := setupLocalStorage(t)
+
+	original := "exec-original"
+	require.NoError(t, ls.CreateExecutionRecord(ctx, &types.Execution{
+		ExecutionID: "exec-replay",
+		RunID:       "run-replay",
+		AgentNodeID: "agent-1",
+		ReasonerID:  "reasoner",
+		NodeID:      "agent-1",
+		Status:      string(types.ExecutionStatusRunning),
+		ReplayOf:    &original,
+	}))
+
+	// Updates keep the link.
+	_, err := ls.UpdateExecutionRecord(ctx, "exec-replay", func(current *types.Execution) (*types.Execution, error) {
+		current.Status = string(types.ExecutionStatusSucceeded)
+		return current, nil
+	})
+	require.NoError(t, err)
+
+	stored, err := ls.GetExecutionRecord(ctx, "exec-replay")
+	require.NoError(t, err)
+	require.NotNil(t, stored.ReplayOf)
+	require.Equal(t, original, *stored.ReplayOf)
+
+	listed, err := ls.QueryExecutionRecords(ctx, types.ExecutionFilter{RunID: stringPtr("run-replay")})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.NotNil(t, listed[0].ReplayOf)
+}
+
+func TestQueryExecutionRecordsBySessionUsesIndex(t *testing.T) {
+	ls, ctx := setupLocalStorage(t)
+
+	const (
+		sessions   = 10
+		perSession = 50
+	)
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	batch := make([]*types.Execution, 0, sessions*perSession)
+	for i := 0; i < sessions*perSession; i++ {
+		batch = append(batch, &types.Execution{
+			ExecutionID: fmt.Sprintf("exec-session-%03d", i),
+			RunID:       fmt.Sprintf("run-%d", i%7),
+			AgentNodeID: "agent-1",
+			ReasonerID:  "reasoner.a",
+			NodeID:      "agent-1",
+			Status:      string(types.ExecutionStatusSucceeded),
+			SessionID:   stringPtr(fmt.Sprintf("session-%d", i%sessions)),
+			ActorID:     stringPtr(fmt.Sprintf("actor-%d", i%3)),
+			StartedAt:   base.Add(time.Duration(i) * time.Second),
+		})
+	}
+	for _, exec := range batch {
+		require.NoError(t, ls.CreateExecutionRecord(ctx, exec))
+	}
+
+	filter := types.ExecutionFilter{SessionID: stringPtr("session-4"), SortDescending: true}
+	results, err := ls.QueryExecutionRecords(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, results, perSession)
+	for i, exec := range results {
+		require.NotNil(t, exec.SessionID)
+		require.Equal(t, "session-4", *exec.SessionID)
+		if i > 0 {
+			require.False(t, exec.StartedAt.After(results[i-1].StartedAt), "results should be ordered by started_at desc")
+		}
+	}
+
+	// The session lookup is backed by the composite session/started_at index rather than a
+	// table scan.
+	query, args := buildExecutionRecordsQuery(filter, ls.mode)
+	rows, err := ls.db.QueryContext(ctx, "EXPLAIN QUERY PLAN "+query, args...)
+	require.NoError(t, err)
+	defer rows.Close()
+	var plan []string
+	for rows.Next() {
+		var id, parent, notused int
+		var detail string
+		require.NoError(t, rows.Scan(&id, &parent, &notused, &detail))
+		plan = append(plan, detail)
+	}
+	require.NoError(t, rows.Err())
+	require.Contains(t, strings.Join(plan, "\n"), "idx_executions_session_started")
+}
+
+func TestQueryExecutionRecordsFiltersByTags(t *testing.T) {
+	ls, ctx := setupLocalStorage(t)
+
+	tagged := map[string]map[string]string{
+		"exec-staging-a": {"env": "staging", "experiment": "A"},
+		"exec-staging-b": {"env": "staging", "experiment": "B"},
+		"exec-prod":      {"env": "prod", "note": `50% "off"_sale`},
+		"exec-untagged":  nil,
+		"exec-lookalike": {"env": "staging2", "xenv": "staging"},
+		"exec-uppercase": {"env": "STAGING", "Experiment": "A"},
+	}
+	for id, tags := range tagged {
+		require.NoError(t, ls.CreateExecutionRecord(ctx, &types.Execution{
+			ExecutionID: id,
+			RunID:       "run-tags",
+			AgentNodeID: "agent-1",
+			ReasonerID:  "reasoner",
+			NodeID:      "agent-1",
+			Status:      string(types.ExecutionStatusSucceeded),
+			Tags:        tags,
+		}))
+	}
+
+	stored, err := ls.GetExecutionRecord(ctx, "exec-staging-a")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"env": "staging", "experiment": "A"}, stored.Tags)
+
+	untagged, err := ls.GetExecutionRecord(ctx, "exec-untagged")
+	require.NoError(t, err)
+	require.Nil(t, untagged.Tags)
+
+	updated, err := ls.UpdateExecutionRecord(ctx, "exec-staging-b", func(exec *types.Execution) (*types.Execution, error) {
+		exec.Status = string(types.ExecutionStatusFailed)
+		return exec, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "B", updated.Tags["experiment"], "updates keep existing tags")
+
+	ids := func(tags map[string]string) []string {
+		results, err := ls.QueryExecutionRecords(ctx, types.ExecutionFilter{Tags: tags, SortBy: "execution_id"})
+		require.NoError(t, err)
+		out := make([]string, 0, len(results))
+		for _, exec := range results {
+			out = append(out, exec.ExecutionID)
+		}
+		return out
+	}
+
+	require.ElementsMatch(t, []string{"exec-staging-a", "exec-staging-b"}, ids(map[string]string{"env": "staging"}))
+	require.Equal(t, []string{"exec-staging-b"}, ids(map[string]string{"env": "staging", "experiment": "B"}))
+	require.Equal(t, []string{"exec-prod"}, ids(map[string]string{"note": `50% "off"_sale`}))
+	require.Empty(t, ids(map[string]string{"note": "50"}))
+	// Matching is case-sensitive on every backend.
+	require.Equal(t, []string{"exec-uppercase"}, ids(map[string]string{"env": "STAGING"}))
+	require.Equal(t, []string{"exec-uppercase"}, ids(map[string]string{"Experiment": "A"}))
+	require.Len(t, ids(nil), len(tagged))
+}
+
 func pointerTime(t time.Time) *time.Time {
 	return &t
 }
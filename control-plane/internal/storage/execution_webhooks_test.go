@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -561,3 +562,32 @@ func TestExecutionWebhooks_ConcurrentMarkInFlight(t *testing.T) {
 	// Only one should succeed
 	assert.Equal(t, 1, successCount, "Only one goroutine should mark as in flight")
 }
+
+func TestExecutionWebhookEvents_AttemptHistory(t *testing.T) {
+	provider, ctx := setupTestStorage(t)
+
+	failure := "non-2xx response: 502"
+	badGateway, ok := http.StatusBadGateway, http.StatusOK
+	attempts := []*types.ExecutionWebhookEvent{
+		{ExecutionID: "exec-1", EventType: types.WebhookEventExecutionCompleted, Status: "failed", AttemptNumber: 1, HTTPStatus: &badGateway, ErrorMessage: &failure},
+		{ExecutionID: "exec-1", EventType: types.WebhookEventExecutionCompleted, Status: "delivered", AttemptNumber: 2, HTTPStatus: &ok},
+	}
+	for _, attempt := range attempts {
+		require.NoError(t, provider.StoreExecutionWebhookEvent(ctx, attempt))
+	}
+
+	events, err := provider.ListExecutionWebhookEvents(ctx, "exec-1")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, 1, events[0].AttemptNumber)
+	assert.Equal(t, "failed", events[0].Status)
+	require.NotNil(t, events[0].ErrorMessage)
+	assert.Equal(t, failure, *events[0].ErrorMessage)
+	assert.Equal(t, 2, events[1].AttemptNumber)
+	assert.Equal(t, "delivered", events[1].Status)
+
+	batch, err := provider.ListExecutionWebhookEventsBatch(ctx, []string{"exec-1"})
+	require.NoError(t, err)
+	require.Len(t, batch["exec-1"], 2)
+	assert.Equal(t, 2, batch["exec-1"][1].AttemptNumber)
+}
@@ -501,6 +501,19 @@ func (ls *LocalStorage) Initialize(ctx context.Context, config StorageConfig) er
 	}
 }
 
+// normalizeSQLiteJournalMode validates a configured journal mode, defaulting to WAL.
+func normalizeSQLiteJournalMode(mode string) (string, error) {
+	mode = strings.ToUpper(strings.TrimSpace(mode))
+	switch mode {
+	case "":
+		return "WAL", nil
+	case "WAL", "DELETE", "TRUNCATE", "PERSIST", "MEMORY", "OFF":
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported SQLite journal mode %q", mode)
+	}
+}
+
 func (ls *LocalStorage) initializeSQLite(ctx context.Context) error {
 	// Validate that the database path is absolute to prevent files being created in random directories
 	if ls.config.DatabasePath == "" {
@@ -526,13 +539,21 @@ func (ls *LocalStorage) initializeSQLite(ctx context.Context) error {
 
 	log.Printf("📁 Initializing SQLite database at: %s", dbPath)
 
-	busyTimeout := resolveEnvInt("AGENTFIELD_SQLITE_BUSY_TIMEOUT_MS", 60000)
+	busyTimeout := int(ls.config.BusyTimeout / time.Millisecond)
+	if busyTimeout <= 0 {
+		busyTimeout = resolveEnvInt("AGENTFIELD_SQLITE_BUSY_TIMEOUT_MS", 60000)
+	}
 	if busyTimeout <= 0 {
 		busyTimeout = 60000
 	}
 
-	dsn := fmt.Sprintf("%s?_journal_mode=WAL&_synchronous=NORMAL&_cache_size=10000&_foreign_keys=ON&_busy_timeout=%d&_wal_autocheckpoint=1000&_temp_store=MEMORY&_mmap_size=268435456",
-		dbPath, busyTimeout)
+	journalMode, err := normalizeSQLiteJournalMode(ls.config.JournalMode)
+	if err != nil {
+		return err
+	}
+
+	dsn := fmt.Sprintf("%s?_journal_mode=%s&_synchronous=NORMAL&_cache_size=10000&_foreign_keys=ON&_busy_timeout=%d&_wal_autocheckpoint=1000&_temp_store=MEMORY&_mmap_size=268435456",
+		dbPath, journalMode, busyTimeout)
 
 	db, err := sql.Open("sqlite3", dsn)
 	if err != nil {
@@ -541,7 +562,10 @@ func (ls *LocalStorage) initializeSQLite(ctx context.Context) error {
 
 	ls.db = newSQLDatabase(db, "local")
 
-	maxOpen := resolveEnvInt("AGENTFIELD_SQLITE_MAX_OPEN_CONNS", 1)
+	maxOpen := ls.config.MaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = resolveEnvInt("AGENTFIELD_SQLITE_MAX_OPEN_CONNS", 1)
+	}
 	if maxOpen <= 0 {
 		maxOpen = 1
 	}
@@ -753,6 +777,19 @@ func isPostgresDatabaseAlreadyExistsError(err error) bool {
 	return strings.Contains(err.Error(), "already exists")
 }
 
+func isUniqueConstraintError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505" // unique_violation
+	}
+
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
 func (ls *LocalStorage) ensurePostgresDatabaseExists(ctx context.Context, cfg PostgresStorageConfig) error {
 	dsn := strings.TrimSpace(cfg.DSN)
 	if dsn == "" {
@@ -1047,6 +1084,8 @@ func (ls *LocalStorage) ensurePostgresIndexes(ctx context.Context) error {
 		"CREATE INDEX IF NOT EXISTS idx_workflow_executions_parent_workflow_id ON workflow_executions(parent_workflow_id)",
 		"CREATE INDEX IF NOT EXISTS idx_workflow_executions_root_workflow_id ON workflow_executions(root_workflow_id)",
 		"CREATE INDEX IF NOT EXISTS idx_workflow_executions_status ON workflow_executions(status)",
+		"CREATE INDEX IF NOT EXISTS idx_executions_session_started ON executions(session_id, started_at)",
+		"CREATE INDEX IF NOT EXISTS idx_executions_actor_started ON executions(actor_id, started_at)",
 	}
 
 	for _, stmt := range indexStatements {
@@ -1133,6 +1172,8 @@ func (ls *LocalStorage) ensureSQLiteIndexes() error {
 		"CREATE INDEX IF NOT EXISTS idx_workflow_executions_session_status ON workflow_executions(session_id, status)",
 		"CREATE INDEX IF NOT EXISTS idx_workflow_executions_actor_status ON workflow_executions(actor_id, status)",
 		"CREATE INDEX IF NOT EXISTS idx_workflow_executions_workflow_status ON workflow_executions(workflow_id, status)",
+		"CREATE INDEX IF NOT EXISTS idx_executions_session_started ON executions(session_id, started_at)",
+		"CREATE INDEX IF NOT EXISTS idx_executions_actor_started ON executions(actor_id, started_at)",
 		"CREATE INDEX IF NOT EXISTS idx_workflow_runs_created_at ON workflow_runs(created_at)",
 		"CREATE INDEX IF NOT EXISTS idx_workflow_runs_updated_at ON workflow_runs(updated_at)",
 		"CREATE INDEX IF NOT EXISTS idx_workflow_steps_created_at ON workflow_steps(created_at)",
@@ -4151,6 +4192,9 @@ func (ls *LocalStorage) executeRegisterAgent(ctx context.Context, q DBTX, agent
 	return nil
 }
 
+// ErrAgentNotFound is returned by GetAgent when no agent node has the requested ID.
+var ErrAgentNotFound = errors.New("agent node not found")
+
 // GetAgent retrieves an agent node record from SQLite by ID.
 func (ls *LocalStorage) GetAgent(ctx context.Context, id string) (*types.AgentNode, error) {
 	// Check context cancellation early
@@ -4180,7 +4224,7 @@ func (ls *LocalStorage) GetAgent(ctx context.Context, id string) (*types.AgentNo
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("agent node with ID '%s' not found", id)
+			return nil, fmt.Errorf("agent node with ID '%s': %w", id, ErrAgentNotFound)
 		}
 		return nil, fmt.Errorf("failed to get agent node with ID '%s': %w", id, err)
 	}
@@ -4268,6 +4312,22 @@ func (ls *LocalStorage) ListAgents(ctx context.Context, filters types.AgentFilte
 		args = append(args, *filters.TeamID)
 	}
 
+	if filters.LifecycleStatus != nil {
+		conditions = append(conditions, "lifecycle_status = ?")
+		args = append(args, string(*filters.LifecycleStatus))
+	}
+
+	if filters.HeartbeatBefore != nil {
+		conditions = append(conditions, "last_heartbeat < ?")
+		args = append(args, filters.HeartbeatBefore.UTC())
+	}
+
+	paged := filters.Limit > 0 || filters.AfterID != ""
+	if filters.AfterID != "" {
+		conditions = append(conditions, "id > ?")
+		args = append(args, filters.AfterID)
+	}
+
 	// Add WHERE clause if there are conditions
 	if len(conditions) > 0 {
 		query += " WHERE " + conditions[0]
@@ -4276,7 +4336,16 @@ func (ls *LocalStorage) ListAgents(ctx context.Context, filters types.AgentFilte
 		}
 	}
 
-	query += " ORDER BY registered_at DESC"
+	if paged {
+		// Page by ID so a cursor stays valid while agents register or change status.
+		query += " ORDER BY id ASC"
+		if filters.Limit > 0 {
+			query += " LIMIT ?"
+			args = append(args, filters.Limit)
+		}
+	} else {
+		query += " ORDER BY registered_at DESC"
+	}
 
 	rows, err := ls.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -4546,26 +4615,59 @@ func (ls *LocalStorage) executeUpdateAgentLifecycleStatus(ctx context.Context, q
 	return nil
 }
 
-// SetConfig stores a configuration key-value pair in SQLite.
+// SetConfig stores a configuration key-value pair in SQLite. The value is stored as JSON,
+// so GetConfig returns it in its decoded form (maps, slices, float64, ...).
 func (ls *LocalStorage) SetConfig(ctx context.Context, key string, value interface{}) error {
 	// Fast-fail if context is already cancelled
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	// TODO: Implement configuration storage in SQLite
-	return fmt.Errorf("SetConfig not yet implemented for LocalStorage")
+	if key == "" {
+		return fmt.Errorf("config key is required")
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshal config %s: %w", key, err)
+	}
+
+	db := ls.requireSQLDB()
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO control_plane_config (config_key, config_value, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(config_key) DO UPDATE SET
+			config_value = excluded.config_value,
+			updated_at = excluded.updated_at
+	`, key, string(encoded), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("set config %s: %w", key, err)
+	}
+	return nil
 }
 
-// GetConfig retrieves a configuration value from SQLite by key.
+// GetConfig retrieves a configuration value from SQLite by key. A missing key yields
+// a nil value and no error.
 func (ls *LocalStorage) GetConfig(ctx context.Context, key string) (interface{}, error) {
 	// Fast-fail if context is already cancelled
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	// TODO: Implement configuration retrieval from SQLite
-	return nil, fmt.Errorf("GetConfig not yet implemented for LocalStorage")
+	db := ls.requireSQLDB()
+	var raw string
+	err := db.QueryRowContext(ctx, `SELECT config_value FROM control_plane_config WHERE config_key = ?`, key).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get config %s: %w", key, err)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, fmt.Errorf("decode config %s: %w", key, err)
+	}
+	return value, nil
 }
 
 // SubscribeToMemoryChanges implements the StorageProvider SubscribeToMemoryChanges method using local pub/sub.
@@ -6962,13 +7064,14 @@ func (ls *LocalStorage) StoreExecutionWebhookEvent(ctx context.Context, event *t
 
 	query := `
 		INSERT INTO execution_webhook_events (
-			execution_id, event_type, status, http_status, payload, response_body, error_message, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`
+			execution_id, event_type, status, attempt_number, http_status, payload, response_body, error_message, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`
 
 	_, err := ls.db.ExecContext(ctx, query,
 		event.ExecutionID,
 		event.EventType,
 		event.Status,
+		event.AttemptNumber,
 		event.HTTPStatus,
 		payload,
 		event.ResponseBody,
@@ -6980,7 +7083,7 @@ func (ls *LocalStorage) StoreExecutionWebhookEvent(ctx context.Context, event *t
 // ListExecutionWebhookEvents returns webhook attempts ordered by creation time.
 func (ls *LocalStorage) ListExecutionWebhookEvents(ctx context.Context, executionID string) ([]*types.ExecutionWebhookEvent, error) {
 	query := `
-		SELECT id, execution_id, event_type, status, http_status, payload, response_body, error_message, created_at
+		SELECT id, execution_id, event_type, status, attempt_number, http_status, payload, response_body, error_message, created_at
 		FROM execution_webhook_events
 		WHERE execution_id = ?
 		ORDER BY created_at ASC, id ASC`
@@ -7004,6 +7107,7 @@ func (ls *LocalStorage) ListExecutionWebhookEvents(ctx context.Context, executio
 			&evt.ExecutionID,
 			&evt.EventType,
 			&evt.Status,
+			&evt.AttemptNumber,
 			&status,
 			&payload,
 			&response,
@@ -7073,7 +7177,7 @@ func (ls *LocalStorage) ListExecutionWebhookEventsBatch(ctx context.Context, exe
 	}
 
 	query := fmt.Sprintf(`
-		SELECT execution_id, id, event_type, status, http_status, payload, response_body, error_message, created_at
+		SELECT execution_id, id, event_type, status, attempt_number, http_status, payload, response_body, error_message, created_at
 		FROM execution_webhook_events
 		WHERE execution_id IN (%s)
 		ORDER BY execution_id ASC, created_at ASC, id ASC`, strings.Join(placeholders, ","))
@@ -7095,6 +7199,7 @@ func (ls *LocalStorage) ListExecutionWebhookEventsBatch(ctx context.Context, exe
 			&evt.ID,
 			&evt.EventType,
 			&evt.Status,
+			&evt.AttemptNumber,
 			&status,
 			&payload,
 			&response,
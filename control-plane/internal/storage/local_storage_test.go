@@ -2,12 +2,15 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLocalStorageStoreWorkflowExecutionPersistsLifecycleFields(t *testing.T) {
@@ -95,3 +98,147 @@ func TestLocalStorageStoreWorkflowExecutionPersistsLifecycleFields(t *testing.T)
 		t.Fatalf("expected pending_children 0, got %d", stored.PendingChildren)
 	}
 }
+
+func TestLocalStorageConcurrentWritesWithWAL(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	cfg := StorageConfig{
+		Mode: "local",
+		Local: LocalStorageConfig{
+			DatabasePath: filepath.Join(tempDir, "agentfield.db"),
+			KVStorePath:  filepath.Join(tempDir, "agentfield.bolt"),
+			BusyTimeout:  10 * time.Second,
+			MaxOpenConns: 8,
+			JournalMode:  "wal",
+		},
+	}
+
+	ls := NewLocalStorage(LocalStorageConfig{})
+	if err := ls.Initialize(ctx, cfg); err != nil {
+		if strings.Contains(err.Error(), "no such module: fts5") {
+			t.Skip("sqlite3 compiled without FTS5; skipping concurrent write test")
+		}
+		t.Fatalf("initialize local storage: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = ls.Close(ctx)
+	})
+
+	var journalMode string
+	if err := ls.db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+		t.Fatalf("query journal mode: %v", err)
+	}
+	if !strings.EqualFold(journalMode, "wal") {
+		t.Fatalf("expected WAL journal mode, got %q", journalMode)
+	}
+
+	const writers = 64
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			event := &types.ObservabilityEvent{
+				EventType:   "execution_failed",
+				EventSource: "execution",
+				Timestamp:   time.Now().UTC().Format(time.RFC3339),
+				Data:        map[string]interface{}{"execution_id": fmt.Sprintf("exec-%d", i)},
+			}
+			errs <- ls.AddToDeadLetterQueue(ctx, event, "delivery failed", 1)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent write failed: %v", err)
+		}
+	}
+
+	count, err := ls.GetDeadLetterQueueCount(ctx)
+	if err != nil {
+		t.Fatalf("count dead letter queue: %v", err)
+	}
+	if count != writers {
+		t.Fatalf("expected %d dead letter entries, got %d", writers, count)
+	}
+}
+
+func TestNormalizeSQLiteJournalMode(t *testing.T) {
+	mode, err := normalizeSQLiteJournalMode("")
+	if err != nil || mode != "WAL" {
+		t.Fatalf("expected WAL default, got %q (%v)", mode, err)
+	}
+	mode, err = normalizeSQLiteJournalMode(" truncate ")
+	if err != nil || mode != "TRUNCATE" {
+		t.Fatalf("expected TRUNCATE, got %q (%v)", mode, err)
+	}
+	if _, err := normalizeSQLiteJournalMode("bogus"); err == nil {
+		t.Fatal("expected error for unsupported journal mode")
+	}
+}
+
+func TestLocalStorageConfigRoundTrip(t *testing.T) {
+	ls, ctx := setupObservabilityTestStorage(t)
+
+	value, err := ls.GetConfig(ctx, "execution.async_target_policy")
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	require.NoError(t, ls.SetConfig(ctx, "execution.async_target_policy", map[string]interface{}{
+		"deny": []string{"node-a.delete_all"},
+	}))
+	require.NoError(t, ls.SetConfig(ctx, "execution.async_target_policy", map[string]interface{}{
+		"allow": []string{"node-a.*"},
+	}))
+
+	value, err = ls.GetConfig(ctx, "execution.async_target_policy")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"allow": []interface{}{"node-a.*"}}, value)
+}
+
+func TestLocalStorageListAgentsPagesByID(t *testing.T) {
+	ls, ctx := setupObservabilityTestStorage(t)
+
+	now := time.Now().UTC()
+	for i, id := range []string{"node-c", "node-a", "node-e", "node-b", "node-d"} {
+		heartbeat := now
+		if i%2 == 0 {
+			heartbeat = now.Add(-time.Hour)
+		}
+		require.NoError(t, ls.RegisterAgent(ctx, &types.AgentNode{
+			ID:            id,
+			BaseURL:       "http://localhost",
+			HealthStatus:  types.HealthStatusActive,
+			LastHeartbeat: heartbeat,
+		}))
+	}
+
+	var seen []string
+	filters := types.AgentFilters{Limit: 2}
+	for {
+		page, err := ls.ListAgents(ctx, filters)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page), 2)
+		for _, agent := range page {
+			seen = append(seen, agent.ID)
+		}
+		if len(page) < filters.Limit {
+			break
+		}
+		filters.AfterID = page[len(page)-1].ID
+	}
+	require.Equal(t, []string{"node-a", "node-b", "node-c", "node-d", "node-e"}, seen)
+
+	cutoff := now.Add(-time.Minute)
+	stale, err := ls.ListAgents(ctx, types.AgentFilters{HeartbeatBefore: &cutoff, Limit: 10})
+	require.NoError(t, err)
+	staleIDs := make([]string, 0, len(stale))
+	for _, agent := range stale {
+		staleIDs = append(staleIDs, agent.ID)
+	}
+	require.Equal(t, []string{"node-c", "node-d", "node-e"}, staleIDs)
+}
@@ -45,6 +45,7 @@ func (ls *LocalStorage) autoMigrateSchema(ctx context.Context) error {
 		&ExecutionWebhookModel{},
 		&ObservabilityWebhookModel{},
 		&ObservabilityDeadLetterQueueModel{},
+		&ConfigEntryModel{},
 	}
 
 	if err := gormDB.WithContext(ctx).AutoMigrate(models...); err != nil {
@@ -7,6 +7,7 @@ type ExecutionRecordModel struct {
 	ExecutionID       string     `gorm:"column:execution_id;not null;uniqueIndex"`
 	RunID             string     `gorm:"column:run_id;not null;index"`
 	ParentExecutionID *string    `gorm:"column:parent_execution_id;index"`
+	ReplayOf          *string    `gorm:"column:replay_of;index"`
 	AgentNodeID       string     `gorm:"column:agent_node_id;not null;index"`
 	ReasonerID        string     `gorm:"column:reasoner_id;not null;index"`
 	NodeID            string     `gorm:"column:node_id;not null;index"`
@@ -14,14 +15,18 @@ type ExecutionRecordModel struct {
 	InputPayload      []byte     `gorm:"column:input_payload"`
 	ResultPayload     []byte     `gorm:"column:result_payload"`
 	ErrorMessage      *string    `gorm:"column:error_message"`
+	ErrorCategory     *string    `gorm:"column:error_category"`
 	InputURI          *string    `gorm:"column:input_uri"`
 	ResultURI         *string    `gorm:"column:result_uri"`
-	SessionID         *string    `gorm:"column:session_id;index"`
-	ActorID           *string    `gorm:"column:actor_id;index"`
-	StartedAt         time.Time  `gorm:"column:started_at;not null;index"`
+	InputSize         *int64     `gorm:"column:input_size"`
+	ResultSize        *int64     `gorm:"column:result_size"`
+	SessionID         *string    `gorm:"column:session_id;index;index:idx_executions_session_started,priority:1"`
+	ActorID           *string    `gorm:"column:actor_id;index;index:idx_executions_actor_started,priority:1"`
+	StartedAt         time.Time  `gorm:"column:started_at;not null;index;index:idx_executions_session_started,priority:2;index:idx_executions_actor_started,priority:2"`
 	CompletedAt       *time.Time `gorm:"column:completed_at"`
 	DurationMS        *int64     `gorm:"column:duration_ms"`
 	Notes             string     `gorm:"column:notes;default:'[]'"`
+	Tags              *string    `gorm:"column:tags"`
 	CreatedAt         time.Time  `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt         time.Time  `gorm:"column:updated_at;autoUpdateTime"`
 }
@@ -354,15 +359,16 @@ type SchemaMigrationModel struct {
 func (SchemaMigrationModel) TableName() string { return "schema_migrations" }
 
 type ExecutionWebhookEventModel struct {
-	ID           int64     `gorm:"column:id;primaryKey;autoIncrement"`
-	ExecutionID  string    `gorm:"column:execution_id;not null;index"`
-	EventType    string    `gorm:"column:event_type;not null"`
-	Status       string    `gorm:"column:status;not null"`
-	HTTPStatus   *int      `gorm:"column:http_status"`
-	Payload      *string   `gorm:"column:payload"`
-	ResponseBody *string   `gorm:"column:response_body"`
-	ErrorMessage *string   `gorm:"column:error_message"`
-	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+	ID            int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	ExecutionID   string    `gorm:"column:execution_id;not null;index"`
+	EventType     string    `gorm:"column:event_type;not null"`
+	Status        string    `gorm:"column:status;not null"`
+	AttemptNumber int       `gorm:"column:attempt_number;not null;default:0"`
+	HTTPStatus    *int      `gorm:"column:http_status"`
+	Payload       *string   `gorm:"column:payload"`
+	ResponseBody  *string   `gorm:"column:response_body"`
+	ErrorMessage  *string   `gorm:"column:error_message"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime"`
 }
 
 func (ExecutionWebhookEventModel) TableName() string { return "execution_webhook_events" }
@@ -410,3 +416,12 @@ type ObservabilityDeadLetterQueueModel struct {
 }
 
 func (ObservabilityDeadLetterQueueModel) TableName() string { return "observability_dead_letter_queue" }
+
+// ConfigEntryModel stores JSON-encoded control plane settings keyed by name.
+type ConfigEntryModel struct {
+	Key       string    `gorm:"column:config_key;primaryKey"`
+	Value     string    `gorm:"column:config_value;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (ConfigEntryModel) TableName() string { return "control_plane_config" }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Agent-Field/agentfield/control-plane/pkg/types"
@@ -161,6 +162,30 @@ func (ls *LocalStorage) GetDeadLetterQueueCount(ctx context.Context) (int64, err
 	return count, nil
 }
 
+// GetDeadLetterQueueAgeBuckets counts dead letter queue entries by age relative to now:
+// under one hour, one hour to one day, and over one day.
+func (ls *LocalStorage) GetDeadLetterQueueAgeBuckets(ctx context.Context, now time.Time) (types.ObservabilityDeadLetterAgeBuckets, error) {
+	db := ls.requireSQLDB()
+
+	hourAgo := now.UTC().Add(-time.Hour)
+	dayAgo := now.UTC().Add(-24 * time.Hour)
+
+	var buckets types.ObservabilityDeadLetterAgeBuckets
+	err := db.QueryRowContext(ctx, `
+		SELECT
+			COALESCE(SUM(CASE WHEN created_at > ? THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN created_at <= ? AND created_at > ? THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN created_at <= ? THEN 1 ELSE 0 END), 0)
+		FROM observability_dead_letter_queue`,
+		hourAgo, hourAgo, dayAgo, dayAgo,
+	).Scan(&buckets.UnderOneHour, &buckets.OneHourToOneDay, &buckets.OverOneDay)
+	if err != nil {
+		return buckets, fmt.Errorf("bucket dead letter queue by age: %w", err)
+	}
+
+	return buckets, nil
+}
+
 // GetDeadLetterQueue returns entries from the dead letter queue with pagination.
 func (ls *LocalStorage) GetDeadLetterQueue(ctx context.Context, limit, offset int) ([]types.ObservabilityDeadLetterEntry, error) {
 	db := ls.requireSQLDB()
@@ -182,6 +207,62 @@ func (ls *LocalStorage) GetDeadLetterQueue(ctx context.Context, limit, offset in
 	}
 	defer rows.Close()
 
+	return scanDeadLetterEntries(rows)
+}
+
+// GetDeadLetterQueueFiltered returns dead letter queue entries matching the filter with
+// pagination, along with the total number of matching entries.
+func (ls *LocalStorage) GetDeadLetterQueueFiltered(ctx context.Context, filter types.ObservabilityDeadLetterFilter, limit, offset int) ([]types.ObservabilityDeadLetterEntry, int64, error) {
+	db := ls.requireSQLDB()
+
+	if limit <= 0 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	var conditions []string
+	var args []interface{}
+	if filter.EventType != "" {
+		conditions = append(conditions, "event_type = ?")
+		args = append(args, filter.EventType)
+	}
+	if filter.EventSource != "" {
+		conditions = append(conditions, "event_source = ?")
+		args = append(args, filter.EventSource)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var count int64
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM observability_dead_letter_queue`+where, args...).Scan(&count); err != nil {
+		return nil, 0, fmt.Errorf("count filtered dead letter queue: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, event_type, event_source, event_timestamp, payload, error_message, retry_count, created_at
+		FROM observability_dead_letter_queue`+where+`
+		ORDER BY created_at ASC
+		LIMIT ? OFFSET ?`, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query filtered dead letter queue: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanDeadLetterEntries(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, count, nil
+}
+
+// scanDeadLetterEntries reads dead letter queue rows into entries.
+func scanDeadLetterEntries(rows *sql.Rows) ([]types.ObservabilityDeadLetterEntry, error) {
 	var entries []types.ObservabilityDeadLetterEntry
 	for rows.Next() {
 		var entry types.ObservabilityDeadLetterEntry
@@ -207,10 +288,11 @@ func (ls *LocalStorage) GetDeadLetterQueue(ctx context.Context, limit, offset in
 	return entries, nil
 }
 
-// DeleteFromDeadLetterQueue removes specific entries from the dead letter queue.
-func (ls *LocalStorage) DeleteFromDeadLetterQueue(ctx context.Context, ids []int64) error {
+// DeleteFromDeadLetterQueue removes specific entries from the dead letter queue and
+// returns how many were deleted.
+func (ls *LocalStorage) DeleteFromDeadLetterQueue(ctx context.Context, ids []int64) (int64, error) {
 	if len(ids) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	db := ls.requireSQLDB()
@@ -225,12 +307,16 @@ func (ls *LocalStorage) DeleteFromDeadLetterQueue(ctx context.Context, ids []int
 	}
 	query += ")"
 
-	_, err := db.ExecContext(ctx, query, args...)
+	result, err := db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("delete from dead letter queue: %w", err)
+		return 0, fmt.Errorf("delete from dead letter queue: %w", err)
 	}
 
-	return nil
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete from dead letter queue: %w", err)
+	}
+	return deleted, nil
 }
 
 // ClearDeadLetterQueue removes all entries from the dead letter queue.
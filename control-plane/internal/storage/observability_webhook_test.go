@@ -295,6 +295,48 @@ func TestDeadLetterQueue_Count(t *testing.T) {
 	require.Equal(t, int64(5), count)
 }
 
+func TestDeadLetterQueue_AgeBuckets(t *testing.T) {
+	ls, ctx := setupObservabilityTestStorage(t)
+
+	now := time.Now().UTC()
+	ages := []time.Duration{
+		10 * time.Minute,
+		50 * time.Minute,
+		2 * time.Hour,
+		23 * time.Hour,
+		25 * time.Hour,
+		72 * time.Hour,
+		30 * 24 * time.Hour,
+	}
+	for i := range ages {
+		event := &types.ObservabilityEvent{
+			EventType:   "test_event",
+			EventSource: "test",
+			Timestamp:   now.Format(time.RFC3339),
+			Data:        map[string]interface{}{"index": i},
+		}
+		require.NoError(t, ls.AddToDeadLetterQueue(ctx, event, "test error", 3))
+	}
+
+	entries, err := ls.GetDeadLetterQueue(ctx, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, len(ages))
+	for i, entry := range entries {
+		_, err := ls.requireSQLDB().ExecContext(ctx,
+			`UPDATE observability_dead_letter_queue SET created_at = ? WHERE id = ?`,
+			now.Add(-ages[i]), entry.ID)
+		require.NoError(t, err)
+	}
+
+	buckets, err := ls.GetDeadLetterQueueAgeBuckets(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, types.ObservabilityDeadLetterAgeBuckets{
+		UnderOneHour:    2,
+		OneHourToOneDay: 2,
+		OverOneDay:      3,
+	}, buckets)
+}
+
 func TestDeadLetterQueue_Pagination(t *testing.T) {
 	ls, ctx := setupObservabilityTestStorage(t)
 
@@ -387,8 +429,9 @@ func TestDeadLetterQueue_DeleteByIDs(t *testing.T) {
 
 	// Delete specific entries
 	idsToDelete := []int64{entries[0].ID, entries[2].ID, entries[4].ID}
-	err = ls.DeleteFromDeadLetterQueue(ctx, idsToDelete)
+	deleted, err := ls.DeleteFromDeadLetterQueue(ctx, idsToDelete)
 	require.NoError(t, err)
+	require.Equal(t, int64(3), deleted)
 
 	// Verify count
 	count, err := ls.GetDeadLetterQueueCount(ctx)
@@ -412,20 +455,23 @@ func TestDeadLetterQueue_DeleteEmpty(t *testing.T) {
 	ls, ctx := setupObservabilityTestStorage(t)
 
 	// Delete with empty slice should not error
-	err := ls.DeleteFromDeadLetterQueue(ctx, []int64{})
+	deleted, err := ls.DeleteFromDeadLetterQueue(ctx, []int64{})
 	require.NoError(t, err)
+	require.Zero(t, deleted)
 
 	// Delete with nil should not error
-	err = ls.DeleteFromDeadLetterQueue(ctx, nil)
+	deleted, err = ls.DeleteFromDeadLetterQueue(ctx, nil)
 	require.NoError(t, err)
+	require.Zero(t, deleted)
 }
 
 func TestDeadLetterQueue_DeleteNonExistent(t *testing.T) {
 	ls, ctx := setupObservabilityTestStorage(t)
 
 	// Delete non-existent IDs should not error
-	err := ls.DeleteFromDeadLetterQueue(ctx, []int64{999, 1000, 1001})
+	deleted, err := ls.DeleteFromDeadLetterQueue(ctx, []int64{999, 1000, 1001})
 	require.NoError(t, err)
+	require.Zero(t, deleted)
 }
 
 func TestDeadLetterQueue_Clear(t *testing.T) {
@@ -584,3 +630,52 @@ func TestDeadLetterQueue_MultipleEventTypes(t *testing.T) {
 		require.True(t, foundTypes[eventType], "expected event type %s to be present", eventType)
 	}
 }
+
+func TestDeadLetterQueue_Filtered(t *testing.T) {
+	ls, ctx := setupObservabilityTestStorage(t)
+
+	events := []struct{ eventType, eventSource string }{
+		{"execution_failed", "execution"},
+		{"execution_failed", "execution"},
+		{"execution_completed", "execution"},
+		{"node_offline", "node"},
+		{"execution_failed", "workflow"},
+	}
+	for _, e := range events {
+		event := &types.ObservabilityEvent{
+			EventType:   e.eventType,
+			EventSource: e.eventSource,
+			Timestamp:   time.Now().UTC().Format(time.RFC3339),
+			Data:        map[string]interface{}{"type": e.eventType},
+		}
+		require.NoError(t, ls.AddToDeadLetterQueue(ctx, event, "webhook unavailable", 3))
+	}
+
+	// No filter returns everything
+	entries, total, err := ls.GetDeadLetterQueueFiltered(ctx, types.ObservabilityDeadLetterFilter{}, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+	require.Equal(t, int64(5), total)
+
+	// Filter by event type
+	entries, total, err = ls.GetDeadLetterQueueFiltered(ctx, types.ObservabilityDeadLetterFilter{EventType: "execution_failed"}, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, int64(3), total)
+
+	// Filter by event type and source
+	entries, total, err = ls.GetDeadLetterQueueFiltered(ctx, types.ObservabilityDeadLetterFilter{EventType: "execution_failed", EventSource: "execution"}, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, int64(2), total)
+	for _, entry := range entries {
+		require.Equal(t, "execution_failed", entry.EventType)
+		require.Equal(t, "execution", entry.EventSource)
+	}
+
+	// Total reflects the filter, not the page
+	entries, total, err = ls.GetDeadLetterQueueFiltered(ctx, types.ObservabilityDeadLetterFilter{EventSource: "execution"}, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, int64(3), total)
+}
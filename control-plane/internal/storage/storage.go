@@ -46,6 +46,7 @@ type StorageProvider interface {
 	CreateExecutionRecord(ctx context.Context, execution *types.Execution) error
 	GetExecutionRecord(ctx context.Context, executionID string) (*types.Execution, error)
 	UpdateExecutionRecord(ctx context.Context, executionID string, update func(*types.Execution) (*types.Execution, error)) (*types.Execution, error)
+	DeleteExecutionRecord(ctx context.Context, executionID string) error
 	QueryExecutionRecords(ctx context.Context, filter types.ExecutionFilter) ([]*types.Execution, error)
 	QueryRunSummaries(ctx context.Context, filter types.ExecutionFilter) ([]*RunSummaryAggregation, int, error)
 	RegisterExecutionWebhook(ctx context.Context, webhook *types.ExecutionWebhook) error
@@ -184,8 +185,10 @@ type StorageProvider interface {
 	// Observability Dead Letter Queue
 	AddToDeadLetterQueue(ctx context.Context, event *types.ObservabilityEvent, errorMessage string, retryCount int) error
 	GetDeadLetterQueueCount(ctx context.Context) (int64, error)
+	GetDeadLetterQueueAgeBuckets(ctx context.Context, now time.Time) (types.ObservabilityDeadLetterAgeBuckets, error)
 	GetDeadLetterQueue(ctx context.Context, limit, offset int) ([]types.ObservabilityDeadLetterEntry, error)
-	DeleteFromDeadLetterQueue(ctx context.Context, ids []int64) error
+	GetDeadLetterQueueFiltered(ctx context.Context, filter types.ObservabilityDeadLetterFilter, limit, offset int) ([]types.ObservabilityDeadLetterEntry, int64, error)
+	DeleteFromDeadLetterQueue(ctx context.Context, ids []int64) (int64, error)
 	ClearDeadLetterQueue(ctx context.Context) error
 }
 
@@ -244,6 +247,15 @@ type PostgresStorageConfig struct {
 type LocalStorageConfig struct {
 	DatabasePath string `yaml:"database_path" mapstructure:"database_path"`
 	KVStorePath  string `yaml:"kv_store_path" mapstructure:"kv_store_path"`
+	// BusyTimeout is how long SQLite waits on a locked database before failing.
+	// Zero falls back to AGENTFIELD_SQLITE_BUSY_TIMEOUT_MS, then 60s.
+	BusyTimeout time.Duration `yaml:"busy_timeout" mapstructure:"busy_timeout"`
+	// MaxOpenConns caps the SQLite connection pool. Zero falls back to
+	// AGENTFIELD_SQLITE_MAX_OPEN_CONNS, then 1.
+	MaxOpenConns int `yaml:"max_open_conns" mapstructure:"max_open_conns"`
+	// JournalMode selects the SQLite journal mode (WAL, DELETE, TRUNCATE, PERSIST,
+	// MEMORY or OFF). Empty defaults to WAL.
+	JournalMode string `yaml:"journal_mode" mapstructure:"journal_mode"`
 }
 
 // VectorStoreConfig controls vector storage behavior.
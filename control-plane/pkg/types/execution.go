@@ -12,6 +12,8 @@ type Execution struct {
 	ExecutionID       string  `json:"execution_id" db:"execution_id"`
 	RunID             string  `json:"run_id" db:"run_id"`
 	ParentExecutionID *string `json:"parent_execution_id,omitempty" db:"parent_execution_id"`
+	// ReplayOf is the execution whose input this one replays.
+	ReplayOf *string `json:"replay_of,omitempty" db:"replay_of"`
 
 	// Agent metadata
 	AgentNodeID string `json:"agent_node_id" db:"agent_node_id"`
@@ -22,8 +24,12 @@ type Execution struct {
 	InputPayload  json.RawMessage `json:"input" db:"input_payload"`
 	ResultPayload json.RawMessage `json:"result,omitempty" db:"result_payload"`
 	ErrorMessage  *string         `json:"error,omitempty" db:"error_message"`
+	ErrorCategory *string         `json:"error_category,omitempty" db:"error_category"`
 	InputURI      *string         `json:"input_uri,omitempty" db:"input_uri"`
 	ResultURI     *string         `json:"result_uri,omitempty" db:"result_uri"`
+	// Original payload sizes in bytes; they survive payloads being offloaded to a URI.
+	InputSize  *int64 `json:"input_size,omitempty" db:"input_size"`
+	ResultSize *int64 `json:"result_size,omitempty" db:"result_size"`
 
 	// Lifecycle
 	Status      string     `json:"status" db:"status"`
@@ -38,6 +44,9 @@ type Execution struct {
 	// Notes for debugging and tracking
 	Notes []ExecutionNote `json:"notes,omitempty" db:"notes"`
 
+	// Tags are caller-supplied labels (e.g. env=staging) used to filter executions.
+	Tags map[string]string `json:"tags,omitempty" db:"tags"`
+
 	// Webhook state (computed, not stored in executions table)
 	WebhookRegistered bool                     `json:"webhook_registered,omitempty" db:"-"`
 	WebhookEvents     []*ExecutionWebhookEvent `json:"webhook_events,omitempty" db:"-"`
@@ -56,12 +65,23 @@ type ExecutionFilter struct {
 	Status            *string
 	SessionID         *string
 	ActorID           *string
-	Limit             int
-	Offset            int
-	StartTime         *time.Time
-	EndTime           *time.Time
-	SortBy            string
-	SortDescending    bool
+	// Tags keeps only executions carrying every listed key=value tag.
+	Tags           map[string]string
+	Limit          int
+	Offset         int
+	StartTime      *time.Time
+	EndTime        *time.Time
+	SortBy         string
+	SortDescending bool
+	// SortKeys orders results by several fields in turn. When set it takes
+	// precedence over SortBy/SortDescending.
+	SortKeys []ExecutionSortKey
+}
+
+// ExecutionSortKey is one field of an ordered, multi-key execution sort.
+type ExecutionSortKey struct {
+	Field      string
+	Descending bool
 }
 
 // ExecutionDAGEdge captures a parent→child relationship inside a run. The UI uses
@@ -31,30 +31,71 @@ type ObservabilityWebhookConfigResponse struct {
 
 // ObservabilityEvent is the normalized envelope for all events sent to the webhook.
 type ObservabilityEvent struct {
-	EventType   string      `json:"event_type"`   // e.g., "execution.completed", "node.online"
-	EventSource string      `json:"event_source"` // "execution", "node", "reasoner"
-	Timestamp   string      `json:"timestamp"`    // RFC3339
-	Data        interface{} `json:"data"`         // Event-specific payload
+	EventType      string      `json:"event_type"`                // e.g., "execution.completed", "node.online"
+	EventSource    string      `json:"event_source"`              // "execution", "node", "reasoner"
+	SourceInstance string      `json:"source_instance,omitempty"` // Control-plane replica that emitted the event
+	Timestamp      string      `json:"timestamp"`                 // RFC3339
+	Data           interface{} `json:"data"`                      // Event-specific payload
 }
 
 // ObservabilityEventBatch groups multiple events for batch delivery.
 type ObservabilityEventBatch struct {
-	BatchID    string               `json:"batch_id"`
-	EventCount int                  `json:"event_count"`
-	Events     []ObservabilityEvent `json:"events"`
-	Timestamp  string               `json:"timestamp"` // RFC3339
+	BatchID        string               `json:"batch_id"`
+	EventCount     int                  `json:"event_count"`
+	Events         []ObservabilityEvent `json:"events"`
+	SourceInstance string               `json:"source_instance,omitempty"`
+	Timestamp      string               `json:"timestamp"` // RFC3339
 }
 
 // ObservabilityForwarderStatus provides current forwarder state for the status endpoint.
 type ObservabilityForwarderStatus struct {
-	Enabled          bool       `json:"enabled"`
-	WebhookURL       string     `json:"webhook_url,omitempty"`
-	QueueDepth       int        `json:"queue_depth"`
-	EventsForwarded  int64      `json:"events_forwarded"`
-	EventsDropped    int64      `json:"events_dropped"`
-	DeadLetterCount  int64      `json:"dead_letter_count"`
-	LastForwardedAt  *time.Time `json:"last_forwarded_at,omitempty"`
-	LastError        *string    `json:"last_error,omitempty"`
+	Enabled             bool       `json:"enabled"`
+	WebhookURL          string     `json:"webhook_url,omitempty"`
+	QueueDepth          int        `json:"queue_depth"`
+	InFlightDeliveries  int        `json:"in_flight_deliveries"`
+	EventsForwarded     int64      `json:"events_forwarded"`
+	EventsDropped       int64      `json:"events_dropped"`
+	EventsSampled       int64      `json:"events_sampled"`
+	EventsExpired       int64      `json:"events_expired"`
+	DeadLetterCount     int64      `json:"dead_letter_count"`
+	LastForwardedAt     *time.Time `json:"last_forwarded_at,omitempty"`
+	LastError           *string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	NextRetryAt         *time.Time `json:"next_retry_at,omitempty"`
+
+	// DeadLetterAgeBuckets breaks DeadLetterCount down by how long entries have waited.
+	DeadLetterAgeBuckets *ObservabilityDeadLetterAgeBuckets `json:"dead_letter_age_buckets,omitempty"`
+
+	// Paused reports whether delivery was paused with Pause; events keep queuing meanwhile.
+	Paused bool `json:"paused"`
+
+	// Healthy reports whether every worker and event bus subscription is running.
+	Healthy bool `json:"healthy"`
+	// WorkerLastProcessedAt holds, per batch worker, when it last took an event or flushed.
+	WorkerLastProcessedAt []time.Time `json:"worker_last_processed_at,omitempty"`
+}
+
+// ObservabilityForwarderMetrics is a snapshot of the forwarder's runtime counters.
+type ObservabilityForwarderMetrics struct {
+	// EnqueuedBySource counts events accepted onto the queue, keyed by event source.
+	EnqueuedBySource map[string]int64 `json:"enqueued_by_source"`
+	// ForwardedBySource counts events delivered to the webhook, keyed by event source.
+	ForwardedBySource map[string]int64 `json:"forwarded_by_source"`
+	// BatchFillRatio is how full the batches being collected are, from 0 to 1, across
+	// all workers.
+	BatchFillRatio float64 `json:"batch_fill_ratio"`
+	// BatchesDelivered counts successful webhook deliveries.
+	BatchesDelivered int64 `json:"batches_delivered"`
+	// AverageDeliveryLatencyMs is the mean time a successful delivery took, retries
+	// included.
+	AverageDeliveryLatencyMs float64 `json:"average_delivery_latency_ms"`
+}
+
+// ObservabilityDeadLetterAgeBuckets counts dead letter queue entries by age.
+type ObservabilityDeadLetterAgeBuckets struct {
+	UnderOneHour    int64 `json:"under_1h"`
+	OneHourToOneDay int64 `json:"1h_to_24h"`
+	OverOneDay      int64 `json:"over_24h"`
 }
 
 // ObservabilityDeadLetterEntry represents an event that failed to deliver.
@@ -69,12 +110,23 @@ type ObservabilityDeadLetterEntry struct {
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 }
 
+// ObservabilityDeadLetterFilter narrows dead letter queue listings. Empty fields match all entries.
+type ObservabilityDeadLetterFilter struct {
+	EventType   string
+	EventSource string
+}
+
 // ObservabilityDeadLetterListResponse is the response for listing DLQ entries.
 type ObservabilityDeadLetterListResponse struct {
 	Entries    []ObservabilityDeadLetterEntry `json:"entries"`
 	TotalCount int64                          `json:"total_count"`
 }
 
+// ObservabilityDeadLetterIDsRequest selects dead letter queue entries by ID.
+type ObservabilityDeadLetterIDsRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
 // ObservabilityRedriveResponse is the response for the redrive operation.
 type ObservabilityRedriveResponse struct {
 	Success   bool   `json:"success"`
@@ -82,3 +134,12 @@ type ObservabilityRedriveResponse struct {
 	Processed int    `json:"processed"`
 	Failed    int    `json:"failed"`
 }
+
+// ObservabilityWebhookTestResponse is the response for a synchronous webhook test-fire.
+type ObservabilityWebhookTestResponse struct {
+	Success    bool   `json:"success"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code,omitempty"`
+	LatencyMS  int64  `json:"latency_ms"`
+	Error      string `json:"error,omitempty"`
+}
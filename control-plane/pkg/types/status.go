@@ -71,3 +71,35 @@ func IsTerminalExecutionStatus(status string) bool {
 		return false
 	}
 }
+
+// ExecutionErrorCategory classifies why an execution failed so failures can be grouped and alerted on.
+type ExecutionErrorCategory = string
+
+const (
+	// ExecutionErrorCategoryTimeout means the agent did not answer before the call deadline.
+	ExecutionErrorCategoryTimeout ExecutionErrorCategory = "timeout"
+	// ExecutionErrorCategoryNetwork means the agent could not be reached.
+	ExecutionErrorCategoryNetwork ExecutionErrorCategory = "network"
+	// ExecutionErrorCategoryAgent5xx means the agent answered with a server error.
+	ExecutionErrorCategoryAgent5xx ExecutionErrorCategory = "agent_5xx"
+	// ExecutionErrorCategoryAgent4xx means the agent rejected the call for a reason other than input validation.
+	ExecutionErrorCategoryAgent4xx ExecutionErrorCategory = "agent_4xx"
+	// ExecutionErrorCategoryValidation means the agent rejected the input as invalid.
+	ExecutionErrorCategoryValidation ExecutionErrorCategory = "validation"
+	// ExecutionErrorCategoryQueueFull means the control plane could not accept the execution.
+	ExecutionErrorCategoryQueueFull ExecutionErrorCategory = "queue_full"
+	// ExecutionErrorCategoryInternal covers every other failure.
+	ExecutionErrorCategoryInternal ExecutionErrorCategory = "internal"
+)
+
+// IsExecutionErrorCategory reports whether category is one of the known error categories.
+func IsExecutionErrorCategory(category string) bool {
+	switch category {
+	case ExecutionErrorCategoryTimeout, ExecutionErrorCategoryNetwork, ExecutionErrorCategoryAgent5xx,
+		ExecutionErrorCategoryAgent4xx, ExecutionErrorCategoryValidation, ExecutionErrorCategoryQueueFull,
+		ExecutionErrorCategoryInternal:
+		return true
+	default:
+		return false
+	}
+}
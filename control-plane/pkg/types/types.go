@@ -202,11 +202,18 @@ type CallbackTestResult struct {
 
 // ReasonerDefinition defines a reasoner provided by an agent node.
 type ReasonerDefinition struct {
-	ID           string          `json:"id"`
-	InputSchema  json.RawMessage `json:"input_schema"`
-	OutputSchema json.RawMessage `json:"output_schema"`
-	MemoryConfig MemoryConfig    `json:"memory_config"`
-	Tags         []string        `json:"tags,omitempty"`
+	ID           string               `json:"id"`
+	InputSchema  json.RawMessage      `json:"input_schema"`
+	OutputSchema json.RawMessage      `json:"output_schema"`
+	MemoryConfig MemoryConfig         `json:"memory_config"`
+	Tags         []string             `json:"tags,omitempty"`
+	Deprecation  *ReasonerDeprecation `json:"deprecation,omitempty"`
+}
+
+// ReasonerDeprecation marks a reasoner whose contract is being phased out by its agent.
+type ReasonerDeprecation struct {
+	Since   string `json:"since"`
+	Message string `json:"message,omitempty"`
 }
 
 // SkillDefinition defines a skill provided by an agent node.
@@ -564,9 +571,17 @@ type ExecutionFilters struct {
 
 // AgentFilters holds filters for querying agent nodes.
 type AgentFilters struct {
-	TeamID       *string       `json:"team_id,omitempty"`
-	HealthStatus *HealthStatus `json:"health_status,omitempty"`
-	Features     []string      `json:"features,omitempty"`
+	TeamID          *string               `json:"team_id,omitempty"`
+	HealthStatus    *HealthStatus         `json:"health_status,omitempty"`
+	LifecycleStatus *AgentLifecycleStatus `json:"lifecycle_status,omitempty"`
+	Features        []string              `json:"features,omitempty"`
+	// HeartbeatBefore keeps only agents whose last heartbeat is older than this time.
+	HeartbeatBefore *time.Time `json:"heartbeat_before,omitempty"`
+	// AfterID and Limit page through agents in ID order: only agents with an ID greater
+	// than AfterID are returned, at most Limit of them. Without either, every match is
+	// returned newest registration first.
+	AfterID string `json:"after_id,omitempty"`
+	Limit   int    `json:"limit,omitempty"`
 }
 
 // EventFilter holds filters for querying memory events.
@@ -715,15 +730,16 @@ type WorkflowRunEvent struct {
 
 // ExecutionWebhookEvent records outbound webhook delivery attempts.
 type ExecutionWebhookEvent struct {
-	ID           int64           `json:"id" db:"id"`
-	ExecutionID  string          `json:"execution_id" db:"execution_id"`
-	EventType    string          `json:"event_type" db:"event_type"`
-	Status       string          `json:"status" db:"status"`
-	HTTPStatus   *int            `json:"http_status,omitempty" db:"http_status"`
-	Payload      json.RawMessage `json:"payload,omitempty" db:"payload"`
-	ResponseBody *string         `json:"response_body,omitempty" db:"response_body"`
-	ErrorMessage *string         `json:"error_message,omitempty" db:"error_message"`
-	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	ID            int64           `json:"id" db:"id"`
+	ExecutionID   string          `json:"execution_id" db:"execution_id"`
+	EventType     string          `json:"event_type" db:"event_type"`
+	Status        string          `json:"status" db:"status"`
+	AttemptNumber int             `json:"attempt_number" db:"attempt_number"` // 1-based; 0 for events recorded before attempts were numbered
+	HTTPStatus    *int            `json:"http_status,omitempty" db:"http_status"`
+	Payload       json.RawMessage `json:"payload,omitempty" db:"payload"`
+	ResponseBody  *string         `json:"response_body,omitempty" db:"response_body"`
+	ErrorMessage  *string         `json:"error_message,omitempty" db:"error_message"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
 }
 
 // WorkflowRun tracks the lifecycle of an orchestrated workflow execution tree.
@@ -795,6 +811,7 @@ type WorkflowStepLeaseOptions struct {
 type ExecutionNote struct {
 	Message   string    `json:"message"`
 	Tags      []string  `json:"tags"`
+	Level     string    `json:"level,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
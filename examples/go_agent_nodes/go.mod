@@ -4,6 +4,10 @@ go 1.21
 
 require github.com/Agent-Field/agentfield/sdk/go v0.1.6
 
-require gopkg.in/yaml.v3 v3.0.1 // indirect
+require (
+	github.com/vmihailenco/msgpack/v5 v5.4.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
 
 replace github.com/Agent-Field/agentfield/sdk/go => ../../sdk/go
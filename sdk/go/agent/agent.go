@@ -9,10 +9,14 @@ import (
 	"io"
 	"log"
 	"math/rand"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"runtime/debug"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -21,6 +25,7 @@ import (
 	"github.com/Agent-Field/agentfield/sdk/go/ai"
 	"github.com/Agent-Field/agentfield/sdk/go/client"
 	"github.com/Agent-Field/agentfield/sdk/go/types"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 type executionContextKey struct{}
@@ -39,6 +44,27 @@ type ExecutionContext struct {
 	AgentNodeID       string
 	ReasonerName      string
 	StartedAt         time.Time
+
+	// TraceHeaders holds the W3C trace context (traceparent, tracestate, baggage) received
+	// with the invocation. Call forwards it so downstream executions join the same trace.
+	TraceHeaders map[string]string
+}
+
+// traceHeaderNames are the tracing headers carried from an invocation to its outbound calls.
+var traceHeaderNames = []string{"traceparent", "tracestate", "baggage"}
+
+// traceHeadersFrom returns the tracing headers present in h, or nil if there are none.
+func traceHeadersFrom(h http.Header) map[string]string {
+	var out map[string]string
+	for _, name := range traceHeaderNames {
+		if value := strings.TrimSpace(h.Get(name)); value != "" {
+			if out == nil {
+				out = make(map[string]string, len(traceHeaderNames))
+			}
+			out[name] = value
+		}
+	}
+	return out
 }
 
 func init() {
@@ -91,6 +117,31 @@ func WithCLIFormatter(formatter func(context.Context, any, error)) ReasonerOptio
 	}
 }
 
+// WithSchemaDefaults fills absent input fields from the "default" values declared
+// in the reasoner's input schema before the handler runs.
+func WithSchemaDefaults() ReasonerOption {
+	return func(r *Reasoner) {
+		r.SchemaDefaults = true
+	}
+}
+
+// WithInputTransform rewrites request input before the handler runs, after any schema
+// defaults are applied. An error from transform rejects the request: HTTP callers get a
+// 400 and Execute returns it without invoking the handler.
+func WithInputTransform(transform func(map[string]any) (map[string]any, error)) ReasonerOption {
+	return func(r *Reasoner) {
+		r.InputTransform = transform
+	}
+}
+
+// WithDeprecated marks the reasoner as deprecated since the given version. It keeps
+// working, but responses carry Deprecation and Warning headers and discovery flags it.
+func WithDeprecated(sinceVersion, message string) ReasonerOption {
+	return func(r *Reasoner) {
+		r.Deprecation = &types.Deprecation{Since: sinceVersion, Message: message}
+	}
+}
+
 // WithDescription adds a human-readable description for help/list commands.
 func WithDescription(desc string) ReasonerOption {
 	return func(r *Reasoner) {
@@ -98,6 +149,29 @@ func WithDescription(desc string) ReasonerOption {
 	}
 }
 
+// WithRequiredEnv declares environment variables the handler needs. Initialize refuses to
+// register the agent while any of them is unset or empty, and the names are advertised
+// to the control plane at registration.
+func WithRequiredEnv(keys ...string) ReasonerOption {
+	return func(r *Reasoner) {
+		for _, key := range keys {
+			if key = strings.TrimSpace(key); key != "" {
+				r.RequiredEnv = append(r.RequiredEnv, key)
+			}
+		}
+	}
+}
+
+// WithPreciseNumbers delivers numbers in HTTP request input as json.Number instead of
+// float64, so integers beyond 2^53, such as large IDs, reach the handler exactly. Handlers
+// call Int64, Float64, or String on the value as appropriate. Without this option every
+// number arrives as float64.
+func WithPreciseNumbers() ReasonerOption {
+	return func(r *Reasoner) {
+		r.PreciseNumbers = true
+	}
+}
+
 // Reasoner represents a single handler exposed by the agent.
 type Reasoner struct {
 	Name         string
@@ -109,6 +183,91 @@ type Reasoner struct {
 	DefaultCLI   bool
 	CLIFormatter func(context.Context, any, error)
 	Description  string
+
+	SchemaDefaults bool
+
+	// InputTransform rewrites input before the handler runs; see WithInputTransform.
+	InputTransform func(map[string]any) (map[string]any, error)
+
+	// Middleware wraps Handler for this reasoner only; see WithMiddleware.
+	Middleware []Middleware
+
+	// Deprecation is set by WithDeprecated and advertised to callers.
+	Deprecation *types.Deprecation
+
+	// ValidateOutput checks results against OutputSchema; StrictValidation turns a
+	// mismatch into an error instead of a logged warning. See WithValidation.
+	ValidateOutput   bool
+	StrictValidation bool
+
+	// RequiredEnv lists environment variables checked by Initialize; see WithRequiredEnv.
+	RequiredEnv []string
+
+	// PreciseNumbers decodes request numbers as json.Number; see WithPreciseNumbers.
+	PreciseNumbers bool
+
+	// ResponseEnvelope wraps synchronous HTTP responses; see WithResponseEnvelope.
+	ResponseEnvelope bool
+}
+
+// applySchemaDefaults returns input with schema defaults merged in for absent keys
+// when the reasoner opted in via WithSchemaDefaults. The caller's map is not modified.
+func (r *Reasoner) applySchemaDefaults(input map[string]any) map[string]any {
+	if !r.SchemaDefaults {
+		return input
+	}
+	defaults := schemaDefaultInput(r.InputSchema)
+	if len(defaults) == 0 {
+		return input
+	}
+	merged := make(map[string]any, len(input)+len(defaults))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range input {
+		merged[k] = v
+	}
+	return merged
+}
+
+// prepareInput applies schema defaults and then the input transform, if any.
+func (r *Reasoner) prepareInput(input map[string]any) (map[string]any, error) {
+	input = r.applySchemaDefaults(input)
+	if r.InputTransform == nil {
+		return input, nil
+	}
+	transformed, err := r.InputTransform(input)
+	if err != nil {
+		return nil, err
+	}
+	if transformed == nil {
+		transformed = make(map[string]any)
+	}
+	return transformed, nil
+}
+
+// schemaDefaultInput builds an input map from the "default" values of a JSON
+// schema's top-level properties. Schemas without defaults yield an empty map.
+func schemaDefaultInput(schema json.RawMessage) map[string]any {
+	input := make(map[string]any)
+	if len(schema) == 0 {
+		return input
+	}
+
+	var parsed struct {
+		Properties map[string]struct {
+			Default any `json:"default"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		return input
+	}
+	for name, prop := range parsed.Properties {
+		if prop.Default != nil {
+			input[name] = prop.Default
+		}
+	}
+	return input
 }
 
 // Config drives Agent behaviour.
@@ -122,10 +281,52 @@ type Config struct {
 	Token          string
 	DeploymentType string
 
+	// StrictTeam requires TeamID to be set explicitly. By default an empty TeamID
+	// falls back to "default", which can silently register an agent into the wrong team.
+	StrictTeam bool
+
 	LeaseRefreshInterval time.Duration
 	DisableLeaseLoop     bool
 	Logger               *log.Logger
 
+	// LeaseRenewalRetries bounds the attempts made for each lease renewal before
+	// waiting for the next refresh tick. Defaults to 3.
+	LeaseRenewalRetries int
+	// LeaseRenewalBackoff is the initial delay between renewal attempts, doubled on
+	// each retry. Defaults to 1 second.
+	LeaseRenewalBackoff time.Duration
+	// LeaseGracePeriod is how long renewals may keep failing, measured from the last
+	// successful renewal, before OnLeaseLost fires. Defaults to 3x LeaseRefreshInterval.
+	LeaseGracePeriod time.Duration
+
+	// Lifecycle hooks. Each is optional and invoked synchronously, so it should not block.
+	//
+	// OnRegistered fires after the node registers with the control plane in Initialize.
+	OnRegistered func()
+	// OnLeaseRenewed fires after each successful renewal by the lease loop.
+	OnLeaseRenewed func(lease types.LeaseResponse)
+	// OnLeaseLost, if set, is invoked once when the lease could not be renewed within
+	// LeaseGracePeriod. It fires again only after a later renewal succeeds.
+	OnLeaseLost func(err error)
+	// OnShutdown fires when the agent shuts down after notifying the control plane.
+	OnShutdown func()
+
+	// AsyncExecutionTimeout bounds reasoners dispatched on the async path, which run
+	// detached from the inbound request. Defaults to 30 minutes.
+	AsyncExecutionTimeout time.Duration
+
+	// CallBatchConcurrency bounds how many calls CallBatch dispatches at once.
+	// Defaults to 8.
+	CallBatchConcurrency int
+
+	// CallBreakerThreshold is the number of consecutive transient failures (connection
+	// errors, 5xx) of calls to one target after which Call fails fast with ErrCircuitOpen
+	// for CallBreakerCooldown. Zero disables the breaker.
+	CallBreakerThreshold int
+	// CallBreakerCooldown is how long an open breaker fails fast before a trial call is
+	// let through. Defaults to 30 seconds.
+	CallBreakerCooldown time.Duration
+
 	// AIConfig configures LLM/AI capabilities
 	// If nil, AI features will be disabled
 	AIConfig *ai.Config
@@ -136,6 +337,15 @@ type Config struct {
 	// MemoryBackend allows plugging in a custom memory storage backend.
 	// If nil, an in-memory backend is used (data lost on restart).
 	MemoryBackend MemoryBackend
+
+	// Middleware wraps every reasoner and skill registered on the agent. The first
+	// entry is the outermost; per-reasoner middleware from WithMiddleware runs inside.
+	Middleware []Middleware
+
+	// ResponseEnvelope wraps the synchronous HTTP responses of every reasoner and skill
+	// as {"data", "meta"} or {"error", "meta"}; see WithResponseEnvelope. Responses are
+	// bare results by default.
+	ResponseEnvelope bool
 }
 
 // CLIConfig controls CLI behaviour and presentation.
@@ -148,6 +358,14 @@ type CLIConfig struct {
 	HelpPreamble        string
 	HelpEpilog          string
 	EnvironmentVars     []string
+
+	// NoExitOnError makes Run return the underlying error rather than a
+	// *CLIError carrying a process exit code, for programmatic embedders.
+	NoExitOnError bool
+
+	// ConfigFilePath overrides the location of the CLI config file that supplies
+	// default flags. Defaults to ~/.config/<AppName>/config.yaml.
+	ConfigFilePath string
 }
 
 // Agent manages registration, lease renewal, and HTTP routing.
@@ -155,7 +373,9 @@ type Agent struct {
 	cfg        Config
 	client     *client.Client
 	httpClient *http.Client
+	breaker    *callBreaker
 	reasoners  map[string]*Reasoner
+	skills     map[string]*Reasoner
 	aiClient   *ai.Client // AI/LLM client
 	memory     *Memory    // Memory system for state management
 
@@ -170,8 +390,15 @@ type Agent struct {
 
 	initMu        sync.Mutex
 	initialized   bool
+	leaseStopped  bool
 	leaseLoopOnce sync.Once
 
+	leaseMu          sync.Mutex
+	registered       bool
+	lastLeaseRenewal time.Time
+	leaseSeconds     int
+	leaseLost        bool
+
 	defaultCLIReasoner string
 }
 
@@ -183,8 +410,15 @@ func New(cfg Config) (*Agent, error) {
 	if cfg.Version == "" {
 		return nil, errors.New("config.Version is required")
 	}
-	if cfg.TeamID == "" {
-		cfg.TeamID = "default"
+	if strings.TrimSpace(cfg.TeamID) == "" {
+		switch {
+		case cfg.StrictTeam:
+			return nil, errors.New("config.TeamID is required when StrictTeam is set")
+		case cfg.TeamID != "" && strings.TrimSpace(cfg.AgentFieldURL) != "":
+			return nil, errors.New("config.TeamID must not be blank")
+		case cfg.TeamID == "":
+			cfg.TeamID = "default"
+		}
 	}
 	if cfg.ListenAddress == "" {
 		cfg.ListenAddress = ":8001"
@@ -198,9 +432,24 @@ func New(cfg Config) (*Agent, error) {
 	if cfg.LeaseRefreshInterval <= 0 {
 		cfg.LeaseRefreshInterval = 2 * time.Minute
 	}
+	if cfg.LeaseRenewalRetries <= 0 {
+		cfg.LeaseRenewalRetries = 3
+	}
+	if cfg.LeaseRenewalBackoff <= 0 {
+		cfg.LeaseRenewalBackoff = time.Second
+	}
+	if cfg.LeaseGracePeriod <= 0 {
+		cfg.LeaseGracePeriod = 3 * cfg.LeaseRefreshInterval
+	}
 	if cfg.Logger == nil {
 		cfg.Logger = log.New(os.Stdout, "[agent] ", log.LstdFlags)
 	}
+	if cfg.AsyncExecutionTimeout <= 0 {
+		cfg.AsyncExecutionTimeout = 30 * time.Minute
+	}
+	if cfg.CallBatchConcurrency <= 0 {
+		cfg.CallBatchConcurrency = 8
+	}
 
 	httpClient := &http.Client{
 		Timeout: 15 * time.Second,
@@ -219,7 +468,9 @@ func New(cfg Config) (*Agent, error) {
 	a := &Agent{
 		cfg:        cfg,
 		httpClient: httpClient,
+		breaker:    newCallBreaker(cfg.CallBreakerThreshold, cfg.CallBreakerCooldown),
 		reasoners:  make(map[string]*Reasoner),
+		skills:     make(map[string]*Reasoner),
 		aiClient:   aiClient,
 		memory:     NewMemory(cfg.MemoryBackend),
 		stopLease:  make(chan struct{}),
@@ -342,6 +593,7 @@ func (a *Agent) RegisterReasoner(name string, handler HandlerFunc, opts ...Reaso
 	for _, opt := range opts {
 		opt(meta)
 	}
+	meta.Handler = chainMiddleware(name, a.withOutputValidation(meta, meta.Handler), a.cfg.Middleware, meta.Middleware)
 
 	if meta.DefaultCLI {
 		if a.defaultCLIReasoner != "" && a.defaultCLIReasoner != name {
@@ -355,6 +607,29 @@ func (a *Agent) RegisterReasoner(name string, handler HandlerFunc, opts ...Reaso
 	a.reasoners[name] = meta
 }
 
+// RegisterSkill makes a handler available at /skills/{name}. Skills accept the same
+// options as reasoners; CLI options are ignored.
+func (a *Agent) RegisterSkill(name string, handler HandlerFunc, opts ...ReasonerOption) {
+	if handler == nil {
+		panic("nil handler supplied")
+	}
+
+	meta := &Reasoner{
+		Name:         name,
+		Handler:      handler,
+		InputSchema:  json.RawMessage(`{"type":"object","additionalProperties":true}`),
+		OutputSchema: json.RawMessage(`{"type":"object","additionalProperties":true}`),
+	}
+	for _, opt := range opts {
+		opt(meta)
+	}
+	meta.Handler = chainMiddleware(name, a.withOutputValidation(meta, meta.Handler), a.cfg.Middleware, meta.Middleware)
+	meta.CLIEnabled = false
+	meta.DefaultCLI = false
+
+	a.skills[name] = meta
+}
+
 // Initialize registers the agent with the AgentField control plane without starting a listener.
 func (a *Agent) Initialize(ctx context.Context) error {
 	a.initMu.Lock()
@@ -368,15 +643,26 @@ func (a *Agent) Initialize(ctx context.Context) error {
 		return errors.New("AgentFieldURL is required when running in server mode")
 	}
 
-	if len(a.reasoners) == 0 {
+	if len(a.reasoners) == 0 && len(a.skills) == 0 {
 		return errors.New("no reasoners registered")
 	}
 
+	if err := a.checkRequiredEnv(); err != nil {
+		return err
+	}
+
 	if err := a.registerNode(ctx); err != nil {
 		return fmt.Errorf("register node: %w", err)
 	}
+	a.leaseMu.Lock()
+	a.registered = true
+	a.leaseMu.Unlock()
+	a.recordLeaseRenewal(0)
+	if a.cfg.OnRegistered != nil {
+		a.cfg.OnRegistered()
+	}
 
-	if err := a.markReady(ctx); err != nil {
+	if _, err := a.markReady(ctx); err != nil {
 		a.logger.Printf("warn: initial status update failed: %v", err)
 	}
 
@@ -385,6 +671,37 @@ func (a *Agent) Initialize(ctx context.Context) error {
 	return nil
 }
 
+// checkRequiredEnv reports every environment variable declared with WithRequiredEnv that
+// is unset or empty, together with the reasoners and skills that need it.
+func (a *Agent) checkRequiredEnv() error {
+	neededBy := map[string][]string{}
+	for _, handlers := range []map[string]*Reasoner{a.reasoners, a.skills} {
+		for name, meta := range handlers {
+			for _, key := range meta.RequiredEnv {
+				if os.Getenv(key) == "" {
+					neededBy[key] = append(neededBy[key], name)
+				}
+			}
+		}
+	}
+	if len(neededBy) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(neededBy))
+	for key := range neededBy {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	missing := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names := neededBy[key]
+		sort.Strings(names)
+		missing = append(missing, fmt.Sprintf("%s (needed by %s)", key, strings.Join(names, ", ")))
+	}
+	return fmt.Errorf("missing required environment variables: %s", strings.Join(missing, "; "))
+}
+
 // Run intelligently routes between CLI and server modes.
 func (a *Agent) Run(ctx context.Context) error {
 	args := os.Args[1:]
@@ -431,6 +748,17 @@ func (a *Agent) registerNode(ctx context.Context) error {
 			ID:           reasoner.Name,
 			InputSchema:  reasoner.InputSchema,
 			OutputSchema: reasoner.OutputSchema,
+			Deprecation:  reasoner.Deprecation,
+			RequiredEnv:  reasoner.RequiredEnv,
+		})
+	}
+
+	skills := make([]types.SkillDefinition, 0, len(a.skills))
+	for _, skill := range a.skills {
+		skills = append(skills, types.SkillDefinition{
+			ID:          skill.Name,
+			InputSchema: skill.InputSchema,
+			RequiredEnv: skill.RequiredEnv,
 		})
 	}
 
@@ -440,7 +768,7 @@ func (a *Agent) registerNode(ctx context.Context) error {
 		BaseURL:   strings.TrimSuffix(a.cfg.PublicURL, "/"),
 		Version:   a.cfg.Version,
 		Reasoners: reasoners,
-		Skills:    []types.SkillDefinition{},
+		Skills:    skills,
 		CommunicationConfig: types.CommunicationConfig{
 			Protocols:         []string{"http"},
 			HeartbeatInterval: "0s",
@@ -470,13 +798,35 @@ func (a *Agent) registerNode(ctx context.Context) error {
 	return nil
 }
 
-func (a *Agent) markReady(ctx context.Context) error {
+func (a *Agent) markReady(ctx context.Context) (*types.LeaseResponse, error) {
 	score := 100
-	_, err := a.client.UpdateStatus(ctx, a.cfg.NodeID, types.NodeStatusUpdate{
+	lease, err := a.client.UpdateStatus(ctx, a.cfg.NodeID, types.NodeStatusUpdate{
 		Phase:       "ready",
 		HealthScore: &score,
 	})
-	return err
+	if err != nil {
+		return nil, err
+	}
+	a.recordLeaseRenewal(lease.LeaseSeconds)
+	return lease, nil
+}
+
+// Status describes the agent's registration and lease state.
+type Status struct {
+	Registered       bool
+	LastLeaseRenewal time.Time
+	LeaseSeconds     int
+}
+
+// Status reports whether the agent is registered and when its lease was last renewed.
+func (a *Agent) Status() Status {
+	a.leaseMu.Lock()
+	defer a.leaseMu.Unlock()
+	return Status{
+		Registered:       a.registered,
+		LastLeaseRenewal: a.lastLeaseRenewal,
+		LeaseSeconds:     a.leaseSeconds,
+	}
 }
 
 func (a *Agent) startServer() error {
@@ -517,7 +867,11 @@ func (a *Agent) Execute(ctx context.Context, reasonerName string, input map[stri
 	if input == nil {
 		input = make(map[string]any)
 	}
-	return reasoner.Handler(ctx, input)
+	input, err := reasoner.prepareInput(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input for reasoner %q: %w", reasonerName, err)
+	}
+	return a.invokeReasoner(ctx, reasoner, input)
 }
 
 // HandleServerlessEvent allows custom serverless entrypoints to normalize arbitrary
@@ -553,11 +907,20 @@ func (a *Agent) HandleServerlessEvent(ctx context.Context, event map[string]any,
 		return map[string]any{"error": "reasoner not found"}, http.StatusNotFound, nil
 	}
 
-	result, err := handler.Handler(ctx, input)
+	start := time.Now()
+	input, err := handler.prepareInput(input)
+	if err != nil {
+		return a.errorPayload(handler, err, execCtx, start), http.StatusBadRequest, nil
+	}
+
+	result, err := a.invokeReasoner(ctx, handler, input)
 	if err != nil {
-		return map[string]any{"error": err.Error()}, http.StatusInternalServerError, nil
+		return a.errorPayload(handler, err, execCtx, start), http.StatusInternalServerError, nil
 	}
 
+	if a.responseEnvelope(handler) {
+		return successEnvelope(result, execCtx, start), http.StatusOK, nil
+	}
 	// Normalize to map for consistent JSON responses.
 	if payload, ok := result.(map[string]any); ok {
 		return payload, http.StatusOK, nil
@@ -569,10 +932,15 @@ func (a *Agent) handler() http.Handler {
 	a.handlerOnce.Do(func() {
 		mux := http.NewServeMux()
 		mux.HandleFunc("/health", a.healthHandler)
+		mux.HandleFunc("/livez", a.livezHandler)
+		mux.HandleFunc("/readyz", a.readyzHandler)
 		mux.HandleFunc("/discover", a.handleDiscover)
 		mux.HandleFunc("/execute", a.handleExecute)
 		mux.HandleFunc("/execute/", a.handleExecute)
+		mux.HandleFunc("/reasoners", a.handleListReasoners)
 		mux.HandleFunc("/reasoners/", a.handleReasoner)
+		mux.HandleFunc("/openapi.json", a.handleOpenAPI)
+		mux.HandleFunc("/skills/", a.handleSkill)
 		a.router = mux
 	})
 	return a.router
@@ -582,6 +950,27 @@ func (a *Agent) healthHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]any{"status": "ok"})
 }
 
+// livezHandler reports that the process is up and able to serve HTTP.
+func (a *Agent) livezHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ok"})
+}
+
+// readyzHandler reports ready only once Initialize has registered the node
+// with the control plane and the lease is active.
+func (a *Agent) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.isReady() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]any{"status": "not_ready"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ready"})
+}
+
+func (a *Agent) isReady() bool {
+	a.initMu.Lock()
+	defer a.initMu.Unlock()
+	return a.initialized && !a.leaseStopped
+}
+
 func (a *Agent) handleDiscover(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -593,11 +982,25 @@ func (a *Agent) handleDiscover(w http.ResponseWriter, r *http.Request) {
 func (a *Agent) discoveryPayload() map[string]any {
 	reasoners := make([]map[string]any, 0, len(a.reasoners))
 	for _, reasoner := range a.reasoners {
-		reasoners = append(reasoners, map[string]any{
+		entry := map[string]any{
 			"id":            reasoner.Name,
 			"input_schema":  rawToMap(reasoner.InputSchema),
 			"output_schema": rawToMap(reasoner.OutputSchema),
 			"tags":          []string{},
+			"deprecated":    reasoner.Deprecation != nil,
+		}
+		if reasoner.Deprecation != nil {
+			entry["deprecation"] = reasoner.Deprecation
+		}
+		reasoners = append(reasoners, entry)
+	}
+
+	skills := make([]map[string]any, 0, len(a.skills))
+	for _, skill := range a.skills {
+		skills = append(skills, map[string]any{
+			"id":           skill.Name,
+			"input_schema": rawToMap(skill.InputSchema),
+			"tags":         []string{},
 		})
 	}
 
@@ -611,8 +1014,79 @@ func (a *Agent) discoveryPayload() map[string]any {
 		"version":         a.cfg.Version,
 		"deployment_type": deployment,
 		"reasoners":       reasoners,
-		"skills":          []map[string]any{},
+		"skills":          skills,
+	}
+}
+
+// sortedReasoners returns the registered reasoners ordered by name.
+func (a *Agent) sortedReasoners() []*Reasoner {
+	reasoners := make([]*Reasoner, 0, len(a.reasoners))
+	for _, r := range a.reasoners {
+		reasoners = append(reasoners, r)
 	}
+	sort.Slice(reasoners, func(i, j int) bool { return reasoners[i].Name < reasoners[j].Name })
+	return reasoners
+}
+
+// handleListReasoners describes the registered reasoners and their schemas.
+func (a *Agent) handleListReasoners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	reasoners := make([]map[string]any, 0, len(a.reasoners))
+	for _, reasoner := range a.sortedReasoners() {
+		reasoners = append(reasoners, map[string]any{
+			"name":          reasoner.Name,
+			"description":   reasoner.Description,
+			"input_schema":  rawToMap(reasoner.InputSchema),
+			"output_schema": rawToMap(reasoner.OutputSchema),
+			"cli_enabled":   reasoner.CLIEnabled,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"reasoners": reasoners})
+}
+
+// handleOpenAPI serves a minimal OpenAPI 3 document covering the reasoner routes.
+func (a *Agent) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	paths := make(map[string]any, len(a.reasoners))
+	for _, reasoner := range a.sortedReasoners() {
+		paths["/reasoners/"+reasoner.Name] = map[string]any{
+			"post": map[string]any{
+				"operationId": reasoner.Name,
+				"summary":     reasoner.Description,
+				"requestBody": map[string]any{
+					"required": true,
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": rawToMap(reasoner.InputSchema)},
+					},
+				},
+				"responses": map[string]any{
+					"200": map[string]any{
+						"description": "Reasoner result",
+						"content": map[string]any{
+							"application/json": map[string]any{"schema": rawToMap(reasoner.OutputSchema)},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   a.cfg.NodeID,
+			"version": a.cfg.Version,
+		},
+		"paths": paths,
+	})
 }
 
 func (a *Agent) handleExecute(w http.ResponseWriter, r *http.Request) {
@@ -624,16 +1098,19 @@ func (a *Agent) handleExecute(w http.ResponseWriter, r *http.Request) {
 	targetName := strings.TrimPrefix(r.URL.Path, "/execute")
 	targetName = strings.TrimPrefix(targetName, "/")
 
-	var payload map[string]any
+	var body []byte
 	if r.Body != nil {
 		defer r.Body.Close()
-		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
-			http.Error(w, fmt.Sprintf("invalid JSON: %v", err), http.StatusBadRequest)
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			http.Error(w, fmt.Sprintf("read body: %v", err), http.StatusBadRequest)
 			return
 		}
 	}
-	if payload == nil {
-		payload = make(map[string]any)
+	payload, err := decodeServerlessPayload(body, false)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid JSON: %v", err), http.StatusBadRequest)
+		return
 	}
 
 	reasonerName := strings.TrimSpace(targetName)
@@ -651,21 +1128,176 @@ func (a *Agent) handleExecute(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
+	setDeprecationHeaders(w, reasoner)
+	if reasoner.PreciseNumbers {
+		// The reasoner is only known once the payload is decoded, so decode again
+		// keeping numbers exact.
+		if payload, err = decodeServerlessPayload(body, true); err != nil {
+			http.Error(w, fmt.Sprintf("invalid JSON: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
 
 	input := extractInputFromServerless(payload)
 	execCtx := a.buildExecutionContextFromServerless(r, payload, reasonerName)
 	ctx := contextWithExecution(r.Context(), execCtx)
 
-	result, err := reasoner.Handler(ctx, input)
+	start := time.Now()
+	input, err = reasoner.prepareInput(input)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, a.errorPayload(reasoner, err, execCtx, start))
+		return
+	}
+
+	result, err := a.invokeReasoner(ctx, reasoner, input)
 	if err != nil {
 		a.logger.Printf("reasoner %s failed: %v", reasonerName, err)
-		writeJSON(w, http.StatusInternalServerError, map[string]any{"error": err.Error()})
+		writeJSON(w, http.StatusInternalServerError, a.errorPayload(reasoner, err, execCtx, start))
 		return
 	}
 
+	if a.responseEnvelope(reasoner) {
+		writeJSON(w, http.StatusOK, successEnvelope(result, execCtx, start))
+		return
+	}
 	writeJSON(w, http.StatusOK, result)
 }
 
+// decodeServerlessPayload decodes a /execute request body. An empty body yields an
+// empty payload; with preciseNumbers, numbers are returned as json.Number.
+func decodeServerlessPayload(body []byte, preciseNumbers bool) (map[string]any, error) {
+	var payload map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if preciseNumbers {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if payload == nil {
+		payload = make(map[string]any)
+	}
+	return payload, nil
+}
+
+const contentTypeMsgpack = "application/msgpack"
+
+// decodeRequestInput reads the reasoner input from the request body, honouring a
+// YAML or msgpack Content-Type and defaulting to JSON. With preciseNumbers, numbers
+// are returned as json.Number rather than float64.
+func decodeRequestInput(r *http.Request, preciseNumbers bool) (map[string]any, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case contentTypeMsgpack, "application/x-msgpack":
+		var input map[string]any
+		if err := msgpack.NewDecoder(r.Body).Decode(&input); err != nil {
+			return nil, fmt.Errorf("invalid msgpack: %v", err)
+		}
+		if preciseNumbers {
+			return jsonNumbers(input).(map[string]any), nil
+		}
+		// Match the JSON path, where every number decodes as float64.
+		return normalizeNumbers(input).(map[string]any), nil
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read body: %w", err)
+		}
+		input, err := decodeYAMLInputNumbers(string(body), preciseNumbers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML: %v", err)
+		}
+		if input == nil {
+			input = map[string]any{}
+		}
+		return input, nil
+	}
+
+	var input map[string]any
+	decoder := json.NewDecoder(r.Body)
+	if preciseNumbers {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(&input); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	return input, nil
+}
+
+// jsonNumbers converts the numeric values msgpack produces into json.Number, matching
+// the JSON path under WithPreciseNumbers.
+func jsonNumbers(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		if val == nil {
+			return map[string]any{}
+		}
+		for k, item := range val {
+			val[k] = jsonNumbers(item)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = jsonNumbers(item)
+		}
+		return val
+	case int8, int16, int32, int64, int:
+		return json.Number(fmt.Sprintf("%d", val))
+	case uint8, uint16, uint32, uint64, uint:
+		return json.Number(fmt.Sprintf("%d", val))
+	case float32:
+		return json.Number(strconv.FormatFloat(float64(val), 'g', -1, 32))
+	case float64:
+		return json.Number(strconv.FormatFloat(val, 'g', -1, 64))
+	default:
+		return v
+	}
+}
+
+// normalizeNumbers converts the integer and float32 values msgpack produces into
+// float64 so handlers see the same types regardless of the request encoding.
+func normalizeNumbers(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		if val == nil {
+			return map[string]any{}
+		}
+		for k, item := range val {
+			val[k] = normalizeNumbers(item)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = normalizeNumbers(item)
+		}
+		return val
+	case int8:
+		return float64(val)
+	case int16:
+		return float64(val)
+	case int32:
+		return float64(val)
+	case int64:
+		return float64(val)
+	case int:
+		return float64(val)
+	case uint8:
+		return float64(val)
+	case uint16:
+		return float64(val)
+	case uint32:
+		return float64(val)
+	case uint64:
+		return float64(val)
+	case uint:
+		return float64(val)
+	case float32:
+		return float64(val)
+	default:
+		return v
+	}
+}
+
 func extractInputFromServerless(payload map[string]any) map[string]any {
 	if payload == nil {
 		return map[string]any{}
@@ -701,6 +1333,7 @@ func (a *Agent) buildExecutionContextFromServerless(r *http.Request, payload map
 		AgentNodeID:       a.cfg.NodeID,
 		ReasonerName:      reasonerName,
 		StartedAt:         time.Now(),
+		TraceHeaders:      traceHeadersFrom(r.Header),
 	}
 
 	if ctxMap, ok := payload["execution_context"].(map[string]any); ok {
@@ -744,27 +1377,60 @@ func (a *Agent) buildExecutionContextFromServerless(r *http.Request, payload map
 }
 
 func (a *Agent) handleReasoner(w http.ResponseWriter, r *http.Request) {
+	a.handleInvocation(w, r, "/reasoners/", a.reasoners)
+}
+
+func (a *Agent) handleSkill(w http.ResponseWriter, r *http.Request) {
+	a.handleInvocation(w, r, "/skills/", a.skills)
+}
+
+// setDeprecationHeaders advertises a deprecated reasoner through the Deprecation header
+// and a human-readable Warning (code 299, "miscellaneous persistent warning").
+func setDeprecationHeaders(w http.ResponseWriter, reasoner *Reasoner) {
+	if reasoner.Deprecation == nil {
+		return
+	}
+	text := fmt.Sprintf("reasoner %s is deprecated", reasoner.Name)
+	if reasoner.Deprecation.Since != "" {
+		text += " since " + reasoner.Deprecation.Since
+	}
+	if reasoner.Deprecation.Message != "" {
+		text += ": " + reasoner.Deprecation.Message
+	}
+	w.Header().Set("Deprecation", "true")
+	w.Header().Set("Warning", fmt.Sprintf(`299 - "%s"`, strings.ReplaceAll(text, `"`, `\"`)))
+}
+
+// handleInvocation serves a POST to {prefix}{name} against the handlers in registry,
+// dispatching asynchronously when the control plane supplied an execution ID.
+func (a *Agent) handleInvocation(w http.ResponseWriter, r *http.Request, prefix string, registry map[string]*Reasoner) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	name := strings.TrimPrefix(r.URL.Path, "/reasoners/")
+	name := strings.TrimPrefix(r.URL.Path, prefix)
 	if name == "" {
 		http.NotFound(w, r)
 		return
 	}
 
-	reasoner, ok := a.reasoners[name]
+	reasoner, ok := registry[name]
 	if !ok {
 		http.NotFound(w, r)
 		return
 	}
+	setDeprecationHeaders(w, reasoner)
 
 	defer r.Body.Close()
-	var input map[string]any
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, fmt.Sprintf("invalid JSON: %v", err), http.StatusBadRequest)
+	input, err := decodeRequestInput(r, reasoner.PreciseNumbers)
+	if err != nil {
+		a.writeInputError(w, r, reasoner, err)
+		return
+	}
+	input, err = reasoner.prepareInput(input)
+	if err != nil {
+		a.writeInputError(w, r, reasoner, err)
 		return
 	}
 
@@ -778,6 +1444,7 @@ func (a *Agent) handleReasoner(w http.ResponseWriter, r *http.Request) {
 		AgentNodeID:       a.cfg.NodeID,
 		ReasonerName:      name,
 		StartedAt:         time.Now(),
+		TraceHeaders:      traceHeadersFrom(r.Header),
 	}
 	if execCtx.WorkflowID == "" {
 		execCtx.WorkflowID = execCtx.RunID
@@ -792,7 +1459,7 @@ func (a *Agent) handleReasoner(w http.ResponseWriter, r *http.Request) {
 	// the result immediately; skip the async path even if an execution ID is present.
 	if a.cfg.DeploymentType != "serverless" && execCtx.ExecutionID != "" && strings.TrimSpace(a.cfg.AgentFieldURL) != "" {
 		go a.executeReasonerAsync(reasoner, cloneInputMap(input), execCtx)
-		writeJSON(w, http.StatusAccepted, map[string]any{
+		writeResponse(w, r, http.StatusAccepted, map[string]any{
 			"status":        "processing",
 			"execution_id":  execCtx.ExecutionID,
 			"run_id":        execCtx.RunID,
@@ -801,42 +1468,51 @@ func (a *Agent) handleReasoner(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := reasoner.Handler(ctx, input)
+	start := time.Now()
+	result, err := a.invokeReasoner(ctx, reasoner, input)
 	if err != nil {
 		a.logger.Printf("reasoner %s failed: %v", name, err)
+		if a.responseEnvelope(reasoner) {
+			writeResponse(w, r, http.StatusInternalServerError, errorEnvelope(err, execCtx, start))
+			return
+		}
 		response := map[string]any{
 			"error": err.Error(),
 		}
-		writeJSON(w, http.StatusInternalServerError, response)
+		writeResponse(w, r, http.StatusInternalServerError, response)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, result)
+	if a.responseEnvelope(reasoner) {
+		writeResponse(w, r, http.StatusOK, successEnvelope(result, execCtx, start))
+		return
+	}
+	writeResponse(w, r, http.StatusOK, result)
 }
 
-func (a *Agent) executeReasonerAsync(reasoner *Reasoner, input map[string]any, execCtx ExecutionContext) {
-	ctx := contextWithExecution(context.Background(), execCtx)
-	start := time.Now()
-
+// invokeReasoner runs the reasoner handler and converts a panic into an ordinary error so
+// a misbehaving reasoner cannot tear down the serving goroutine. The panic value and stack
+// trace are logged; callers only ever see a sanitized message.
+func (a *Agent) invokeReasoner(ctx context.Context, reasoner *Reasoner, input map[string]any) (result any, err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
-			errMsg := fmt.Sprintf("panic: %v", rec)
-			payload := map[string]any{
-				"status":        "failed",
-				"error":         errMsg,
-				"execution_id":  execCtx.ExecutionID,
-				"run_id":        execCtx.RunID,
-				"completed_at":  time.Now().UTC().Format(time.RFC3339),
-				"duration_ms":   time.Since(start).Milliseconds(),
-				"reasoner_name": reasoner.Name,
-			}
-			if err := a.sendExecutionStatus(execCtx.ExecutionID, payload); err != nil {
-				a.logger.Printf("failed to send panic status: %v", err)
-			}
+			a.logger.Printf("reasoner %s panicked: %v\n%s", reasoner.Name, rec, debug.Stack())
+			result = nil
+			err = fmt.Errorf("reasoner %s panicked", reasoner.Name)
 		}
 	}()
+	return reasoner.Handler(ctx, input)
+}
+
+func (a *Agent) executeReasonerAsync(reasoner *Reasoner, input map[string]any, execCtx ExecutionContext) {
+	// The inbound request has already been answered with 202, so the handler runs on a
+	// detached context bounded only by the async timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.AsyncExecutionTimeout)
+	defer cancel()
+	ctx = contextWithExecution(ctx, execCtx)
+	start := time.Now()
 
-	result, err := reasoner.Handler(ctx, input)
+	result, err := a.invokeReasoner(ctx, reasoner, input)
 	payload := map[string]any{
 		"execution_id":  execCtx.ExecutionID,
 		"run_id":        execCtx.RunID,
@@ -902,8 +1578,66 @@ func (a *Agent) postExecutionStatus(ctx context.Context, callbackURL string, pay
 	return lastErr
 }
 
+// CallOptions bounds the latency and retry behaviour of a single Call.
+type CallOptions struct {
+	// Timeout caps the total time spent across all attempts. Zero leaves ctx unchanged.
+	Timeout time.Duration
+	// MaxRetries is the number of extra attempts made after a transient failure.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry; it doubles on each subsequent retry.
+	RetryBackoff time.Duration
+}
+
+// transientCallError marks control-plane failures (5xx, connection errors) that are safe to retry.
+type transientCallError struct {
+	err error
+}
+
+func (e *transientCallError) Error() string { return e.err.Error() }
+func (e *transientCallError) Unwrap() error { return e.err }
+
 // Call invokes another reasoner via the AgentField control plane, preserving execution context.
 func (a *Agent) Call(ctx context.Context, target string, input map[string]any) (map[string]any, error) {
+	return a.callOnce(ctx, target, input)
+}
+
+// CallWithOptions behaves like Call but bounds the call with opts.Timeout and retries
+// transient control-plane errors up to opts.MaxRetries times. Reasoner-reported
+// failures are returned immediately without retrying.
+func (a *Agent) CallWithOptions(ctx context.Context, target string, input map[string]any, opts CallOptions) (map[string]any, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	backoff := opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		result, err := a.callOnce(ctx, target, input)
+		if err == nil {
+			return result, nil
+		}
+
+		var transient *transientCallError
+		if !errors.As(err, &transient) || attempt >= opts.MaxRetries || ctx.Err() != nil {
+			return nil, err
+		}
+
+		a.logger.Printf("call %s attempt %d failed, retrying: %v", target, attempt+1, err)
+		if backoff > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, fmt.Errorf("call %s: %w (last error: %v)", target, ctx.Err(), err)
+			case <-timer.C:
+			}
+			backoff *= 2
+		}
+	}
+}
+
+func (a *Agent) callOnce(ctx context.Context, target string, input map[string]any) (map[string]any, error) {
 	if strings.TrimSpace(a.cfg.AgentFieldURL) == "" {
 		return nil, errors.New("AgentFieldURL is required to call other reasoners")
 	}
@@ -912,6 +1646,17 @@ func (a *Agent) Call(ctx context.Context, target string, input map[string]any) (
 		target = fmt.Sprintf("%s.%s", a.cfg.NodeID, strings.TrimPrefix(target, "."))
 	}
 
+	if err := a.breaker.allow(target); err != nil {
+		return nil, fmt.Errorf("call %s: %w", target, err)
+	}
+	result, err := a.executeCall(ctx, target, input)
+	a.breaker.record(target, err)
+	return result, err
+}
+
+// executeCall performs a single execute request against the control plane for a
+// fully qualified target.
+func (a *Agent) executeCall(ctx context.Context, target string, input map[string]any) (map[string]any, error) {
 	execCtx := executionContextFrom(ctx)
 	runID := execCtx.RunID
 	if runID == "" {
@@ -944,21 +1689,30 @@ func (a *Agent) Call(ctx context.Context, target string, input map[string]any) (
 	if execCtx.ActorID != "" {
 		req.Header.Set("X-Actor-ID", execCtx.ActorID)
 	}
+	for name, value := range execCtx.TraceHeaders {
+		req.Header.Set(name, value)
+	}
 	if a.cfg.Token != "" {
 		req.Header.Set("Authorization", "Bearer "+a.cfg.Token)
 	}
 
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("perform execute call: %w", err)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("perform execute call: %w", err)
+		}
+		return nil, &transientCallError{err: fmt.Errorf("perform execute call: %w", err)}
 	}
 	defer resp.Body.Close()
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read execute response: %w", err)
+		return nil, &transientCallError{err: fmt.Errorf("read execute response: %w", err)}
 	}
 
+	if resp.StatusCode >= 500 {
+		return nil, &transientCallError{err: fmt.Errorf("execute failed: %s", strings.TrimSpace(string(bodyBytes)))}
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("execute failed: %s", strings.TrimSpace(string(bodyBytes)))
 	}
@@ -984,6 +1738,54 @@ func (a *Agent) Call(ctx context.Context, target string, input map[string]any) (
 	return execResp.Result, nil
 }
 
+// BatchCall describes one invocation submitted through CallBatch.
+type BatchCall struct {
+	Target string
+	Input  map[string]any
+}
+
+// BatchResult holds the outcome of the BatchCall at the same index.
+type BatchResult struct {
+	Target string
+	Result map[string]any
+	Err    error
+}
+
+// CallBatch invokes several reasoners concurrently via the control plane and returns
+// their results in the order the calls were supplied. A failing call records its error
+// in the matching BatchResult and does not abort the others. Parallelism is bounded by
+// Config.CallBatchConcurrency.
+func (a *Agent) CallBatch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	if strings.TrimSpace(a.cfg.AgentFieldURL) == "" {
+		return nil, errors.New("AgentFieldURL is required to call other reasoners")
+	}
+
+	results := make([]BatchResult, len(calls))
+	sem := make(chan struct{}, a.cfg.CallBatchConcurrency)
+	var wg sync.WaitGroup
+
+	for i, call := range calls {
+		results[i].Target = call.Target
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, call BatchCall) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Result, results[i].Err = a.Call(ctx, call.Target, call.Input)
+		}(i, call)
+	}
+
+	wg.Wait()
+	return results, nil
+}
+
 // emitWorkflowEvent sends a workflow event to the control plane asynchronously.
 // Failures are logged but do not impact the caller.
 func (a *Agent) emitWorkflowEvent(
@@ -1084,8 +1886,15 @@ func (a *Agent) CallLocal(ctx context.Context, reasonerName string, input map[st
 
 	a.emitWorkflowEvent(childCtx, "running", input, nil, nil, 0)
 
+	prepared, err := reasoner.prepareInput(input)
+	if err != nil {
+		err = fmt.Errorf("invalid input for reasoner %q: %w", reasonerName, err)
+		a.emitWorkflowEvent(childCtx, "failed", input, nil, err, 0)
+		return nil, err
+	}
+
 	start := time.Now()
-	result, err := reasoner.Handler(ctx, input)
+	result, err := a.invokeReasoner(ctx, reasoner, prepared)
 	durationMS := time.Since(start).Milliseconds()
 
 	if err != nil {
@@ -1129,6 +1938,36 @@ func writeJSON(w http.ResponseWriter, status int, payload any) {
 	}
 }
 
+// writeResponse encodes payload as msgpack when the request's Accept header asks
+// for it and falls back to JSON otherwise.
+func writeResponse(w http.ResponseWriter, r *http.Request, status int, payload any) {
+	if !acceptsMsgpack(r) {
+		writeJSON(w, status, payload)
+		return
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(payload); err != nil {
+		writeJSON(w, status, payload)
+		return
+	}
+	w.Header().Set("Content-Type", contentTypeMsgpack)
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func acceptsMsgpack(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && (mediaType == contentTypeMsgpack || mediaType == "application/x-msgpack") {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *Agent) startLeaseLoop() {
 	if a.cfg.DisableLeaseLoop || a.cfg.LeaseRefreshInterval <= 0 {
 		return
@@ -1137,14 +1976,17 @@ func (a *Agent) startLeaseLoop() {
 	a.leaseLoopOnce.Do(func() {
 		ticker := time.NewTicker(a.cfg.LeaseRefreshInterval)
 		go func() {
+			failures := 0
 			for {
 				select {
 				case <-ticker.C:
-					ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-					if err := a.markReady(ctx); err != nil {
-						a.logger.Printf("lease refresh failed: %v", err)
+					err := a.renewLease()
+					if err == nil {
+						failures = 0
+						continue
 					}
-					cancel()
+					failures++
+					a.handleLeaseRenewalFailure(failures, err)
 				case <-a.stopLease:
 					ticker.Stop()
 					return
@@ -1154,13 +1996,86 @@ func (a *Agent) startLeaseLoop() {
 	})
 }
 
+// renewLease refreshes the lease, retrying with exponential backoff up to
+// LeaseRenewalRetries times. It gives up early if the lease loop is stopped.
+func (a *Agent) renewLease() error {
+	backoff := a.cfg.LeaseRenewalBackoff
+	var err error
+	for attempt := 0; attempt < a.cfg.LeaseRenewalRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-a.stopLease:
+				return err
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		var lease *types.LeaseResponse
+		lease, err = a.markReady(ctx)
+		cancel()
+		if err == nil {
+			if a.cfg.OnLeaseRenewed != nil {
+				a.cfg.OnLeaseRenewed(*lease)
+			}
+			return nil
+		}
+	}
+	return err
+}
+
+// handleLeaseRenewalFailure logs a failed renewal and fires OnLeaseLost once the
+// grace period since the last successful renewal has elapsed.
+func (a *Agent) handleLeaseRenewalFailure(failures int, err error) {
+	a.leaseMu.Lock()
+	sinceRenewal := time.Since(a.lastLeaseRenewal)
+	lost := !a.leaseLost && sinceRenewal > a.cfg.LeaseGracePeriod
+	if lost {
+		a.leaseLost = true
+	}
+	a.leaseMu.Unlock()
+
+	a.logger.Printf("warn: lease renewal failed node_id=%s consecutive_failures=%d since_last_renewal=%s error=%v",
+		a.cfg.NodeID, failures, sinceRenewal.Round(time.Millisecond), err)
+
+	if lost {
+		a.logger.Printf("warn: lease lost node_id=%s grace_period=%s", a.cfg.NodeID, a.cfg.LeaseGracePeriod)
+		if a.cfg.OnLeaseLost != nil {
+			a.cfg.OnLeaseLost(err)
+		}
+	}
+}
+
+// recordLeaseRenewal marks the lease as freshly renewed. A zero leaseSeconds keeps
+// the previously reported lease duration.
+func (a *Agent) recordLeaseRenewal(leaseSeconds int) {
+	a.leaseMu.Lock()
+	a.lastLeaseRenewal = time.Now()
+	if leaseSeconds > 0 {
+		a.leaseSeconds = leaseSeconds
+	}
+	a.leaseLost = false
+	a.leaseMu.Unlock()
+}
+
 func (a *Agent) shutdown(ctx context.Context) error {
+	a.initMu.Lock()
+	a.leaseStopped = true
+	a.initMu.Unlock()
 	close(a.stopLease)
 
 	if _, err := a.client.Shutdown(ctx, a.cfg.NodeID, types.ShutdownRequest{Reason: "shutdown"}); err != nil {
 		a.logger.Printf("failed to notify shutdown: %v", err)
 	}
 
+	a.leaseMu.Lock()
+	a.registered = false
+	a.leaseMu.Unlock()
+	if a.cfg.OnShutdown != nil {
+		a.cfg.OnShutdown()
+	}
+
 	a.serverMu.RLock()
 	server := a.server
 	a.serverMu.RUnlock()
@@ -1184,14 +2099,31 @@ func (a *Agent) shutdown(ctx context.Context) error {
 //	    ai.WithSystem("You are a weather assistant"),
 //	    ai.WithTemperature(0.7))
 func (a *Agent) AI(ctx context.Context, prompt string, opts ...ai.Option) (*ai.Response, error) {
+	return a.AIMessages(ctx, []ai.Message{{Role: "user", Content: prompt}}, opts...)
+}
+
+// AIMessages makes an AI/LLM call with a full conversation, for reasoners that
+// thread system, user, and assistant turns. Messages are sent in the given order.
+// Returns an error if AI is not configured for this agent.
+//
+// Example usage:
+//
+//	response, err := agent.AIMessages(ctx, []ai.Message{
+//	    {Role: "system", Content: "You are a terse assistant"},
+//	    {Role: "user", Content: "Name a prime"},
+//	    {Role: "assistant", Content: "7"},
+//	    {Role: "user", Content: "Another"},
+//	}, ai.WithMaxTokens(16))
+func (a *Agent) AIMessages(ctx context.Context, messages []ai.Message, opts ...ai.Option) (*ai.Response, error) {
 	if a.aiClient == nil {
 		return nil, errors.New("AI not configured for this agent; set AIConfig in agent Config")
 	}
-	return a.aiClient.Complete(ctx, prompt, opts...)
+	return a.aiClient.CompleteWithMessages(ctx, messages, opts...)
 }
 
 // AIStream makes a streaming AI/LLM call.
-// Returns channels for streaming chunks and errors.
+// Returns channels for streaming chunks and errors. Cancelling ctx stops the
+// stream: the chunk channel is closed and ctx.Err() is sent on the error channel.
 //
 // Example usage:
 //
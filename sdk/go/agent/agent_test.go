@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/Agent-Field/agentfield/sdk/go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 func TestNew(t *testing.T) {
@@ -81,6 +84,40 @@ func TestNew(t *testing.T) {
 				assert.NotNil(t, a.cfg.Logger)
 			},
 		},
+		{
+			name: "blank TeamID with AgentFieldURL",
+			cfg: Config{
+				NodeID:        "node-1",
+				Version:       "1.0.0",
+				TeamID:        "  ",
+				AgentFieldURL: "https://api.example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "StrictTeam without TeamID",
+			cfg: Config{
+				NodeID:        "node-1",
+				Version:       "1.0.0",
+				AgentFieldURL: "https://api.example.com",
+				StrictTeam:    true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "StrictTeam with TeamID",
+			cfg: Config{
+				NodeID:        "node-1",
+				Version:       "1.0.0",
+				TeamID:        "payments",
+				AgentFieldURL: "https://api.example.com",
+				StrictTeam:    true,
+			},
+			wantErr: false,
+			check: func(t *testing.T, a *Agent) {
+				assert.Equal(t, "payments", a.cfg.TeamID)
+			},
+		},
 		{
 			name: "with AIConfig",
 			cfg: Config{
@@ -287,6 +324,109 @@ func TestHandler(t *testing.T) {
 	assert.Equal(t, "ok", response["status"])
 }
 
+func TestHealthProbes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/api/v1/nodes" {
+			json.NewEncoder(w).Encode(types.NodeRegistrationResponse{ID: "node-1", Success: true})
+			return
+		}
+		json.NewEncoder(w).Encode(types.LeaseResponse{LeaseSeconds: 120})
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		NodeID:           "node-1",
+		Version:          "1.0.0",
+		AgentFieldURL:    server.URL,
+		Logger:           log.New(io.Discard, "", 0),
+		DisableLeaseLoop: true,
+	}
+
+	agent, err := New(cfg)
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("test", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"ok": true}, nil
+	})
+
+	handler := agent.Handler()
+	probe := func(path string) int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, probe("/livez"))
+	assert.Equal(t, http.StatusServiceUnavailable, probe("/readyz"))
+
+	require.NoError(t, agent.Initialize(context.Background()))
+
+	assert.Equal(t, http.StatusOK, probe("/livez"))
+	assert.Equal(t, http.StatusOK, probe("/readyz"))
+}
+
+func TestReasonerDiscoveryEndpoints(t *testing.T) {
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	inputSchema := json.RawMessage(`{"type":"object","properties":{"name":{"type":"string"}}}`)
+	outputSchema := json.RawMessage(`{"type":"object","properties":{"greeting":{"type":"string"}}}`)
+	noop := func(ctx context.Context, input map[string]any) (any, error) { return nil, nil }
+
+	agent.RegisterReasoner("greet", noop,
+		WithInputSchema(inputSchema),
+		WithOutputSchema(outputSchema),
+		WithDescription("Greets a user"),
+		WithCLI(),
+	)
+	agent.RegisterReasoner("audit", noop)
+
+	handler := agent.Handler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reasoners", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var listing struct {
+		Reasoners []struct {
+			Name         string         `json:"name"`
+			Description  string         `json:"description"`
+			InputSchema  map[string]any `json:"input_schema"`
+			OutputSchema map[string]any `json:"output_schema"`
+			CLIEnabled   bool           `json:"cli_enabled"`
+		} `json:"reasoners"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&listing))
+	require.Len(t, listing.Reasoners, 2)
+
+	assert.Equal(t, "audit", listing.Reasoners[0].Name)
+	assert.False(t, listing.Reasoners[0].CLIEnabled)
+
+	greet := listing.Reasoners[1]
+	assert.Equal(t, "greet", greet.Name)
+	assert.Equal(t, "Greets a user", greet.Description)
+	assert.True(t, greet.CLIEnabled)
+	assert.Equal(t, rawToMap(inputSchema), greet.InputSchema)
+	assert.Equal(t, rawToMap(outputSchema), greet.OutputSchema)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc map[string]any
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	paths := doc["paths"].(map[string]any)
+	assert.Contains(t, paths, "/reasoners/greet")
+	assert.Contains(t, paths, "/reasoners/audit")
+}
+
 func TestHandleReasoner_Sync(t *testing.T) {
 	cfg := Config{
 		NodeID:        "node-1",
@@ -321,7 +461,7 @@ func TestHandleReasoner_Sync(t *testing.T) {
 	assert.Equal(t, float64(42), result["value"]) // JSON numbers are float64
 }
 
-func TestHandleReasoner_NotFound(t *testing.T) {
+func TestHandleReasoner_DeprecatedReturnsWarningHeader(t *testing.T) {
 	cfg := Config{
 		NodeID:        "node-1",
 		Version:       "1.0.0",
@@ -332,215 +472,1073 @@ func TestHandleReasoner_NotFound(t *testing.T) {
 	agent, err := New(cfg)
 	require.NoError(t, err)
 
+	agent.RegisterReasoner("legacy", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"value": input["value"]}, nil
+	}, WithDeprecated("1.2.0", "use summarize_v2"))
+	agent.RegisterReasoner("current", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{}, nil
+	})
+
 	server := httptest.NewServer(agent.handler())
 	defer server.Close()
 
-	req, err := http.NewRequest(http.MethodPost, server.URL+"/reasoners/nonexistent", bytes.NewReader([]byte("{}")))
+	resp, err := http.Post(server.URL+"/reasoners/legacy", "application/json", bytes.NewReader([]byte(`{"value":7}`)))
 	require.NoError(t, err)
+	defer resp.Body.Close()
 
-	resp, err := http.DefaultClient.Do(req)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get("Deprecation"))
+	assert.Equal(t, `299 - "reasoner legacy is deprecated since 1.2.0: use summarize_v2"`, resp.Header.Get("Warning"))
+	var result map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, float64(7), result["value"])
+
+	resp2, err := http.Post(server.URL+"/reasoners/current", "application/json", bytes.NewReader([]byte(`{}`)))
 	require.NoError(t, err)
-	defer resp.Body.Close()
+	defer resp2.Body.Close()
+	assert.Empty(t, resp2.Header.Get("Deprecation"))
+	assert.Empty(t, resp2.Header.Get("Warning"))
 
-	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp3, err := http.Post(server.URL+"/execute/legacy", "application/json", bytes.NewReader([]byte(`{"input":{"value":7}}`)))
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	assert.Equal(t, http.StatusOK, resp3.StatusCode)
+	assert.Equal(t, "true", resp3.Header.Get("Deprecation"))
+	assert.Equal(t, `299 - "reasoner legacy is deprecated since 1.2.0: use summarize_v2"`, resp3.Header.Get("Warning"))
+
+	discovery := agent.discoveryPayload()
+	for _, entry := range discovery["reasoners"].([]map[string]any) {
+		switch entry["id"] {
+		case "legacy":
+			assert.Equal(t, true, entry["deprecated"])
+			assert.Equal(t, &types.Deprecation{Since: "1.2.0", Message: "use summarize_v2"}, entry["deprecation"])
+		case "current":
+			assert.Equal(t, false, entry["deprecated"])
+		}
+	}
 }
 
-func TestHandleReasoner_WrongMethod(t *testing.T) {
-	cfg := Config{
+func TestHandleReasoner_SchemaDefaults(t *testing.T) {
+	agent, err := New(Config{
 		NodeID:        "node-1",
 		Version:       "1.0.0",
 		AgentFieldURL: "https://api.example.com",
 		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	schema := json.RawMessage(`{"type":"object","properties":{"tone":{"type":"string","default":"formal"},"limit":{"type":"integer","default":5},"name":{"type":"string"}}}`)
+	echo := func(ctx context.Context, input map[string]any) (any, error) {
+		return input, nil
 	}
+	agent.RegisterReasoner("greet", echo, WithInputSchema(schema), WithSchemaDefaults())
+	agent.RegisterReasoner("plain", echo, WithInputSchema(schema))
 
-	agent, err := New(cfg)
+	result, err := agent.Execute(context.Background(), "greet", map[string]any{"name": "Bob", "limit": float64(10)})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "Bob", "tone": "formal", "limit": float64(10)}, result)
+
+	result, err = agent.Execute(context.Background(), "plain", map[string]any{"name": "Bob"})
 	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "Bob"}, result)
 
 	server := httptest.NewServer(agent.handler())
 	defer server.Close()
 
-	req, err := http.NewRequest(http.MethodGet, server.URL+"/reasoners/test", nil)
-	require.NoError(t, err)
-
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := http.Post(server.URL+"/reasoners/greet", "application/json", strings.NewReader(`{"tone":"casual"}`))
 	require.NoError(t, err)
 	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
 
-	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, map[string]any{"tone": "casual", "limit": float64(5)}, body)
 }
 
-func TestHandleReasoner_Error(t *testing.T) {
-	cfg := Config{
+func TestHandleReasoner_InputTransform(t *testing.T) {
+	agent, err := New(Config{
 		NodeID:        "node-1",
 		Version:       "1.0.0",
 		AgentFieldURL: "https://api.example.com",
 		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	received := make(chan map[string]any, 2)
+	rename := func(input map[string]any) (map[string]any, error) {
+		if _, ok := input["user"]; !ok {
+			return nil, errors.New("user is required")
+		}
+		out := make(map[string]any, len(input))
+		for k, v := range input {
+			out[k] = v
+		}
+		out["name"] = out["user"]
+		delete(out, "user")
+		return out, nil
 	}
+	agent.RegisterReasoner("greet", func(ctx context.Context, input map[string]any) (any, error) {
+		received <- input
+		return input, nil
+	}, WithInputTransform(rename))
 
-	agent, err := New(cfg)
+	result, err := agent.Execute(context.Background(), "greet", map[string]any{"user": "Bob"})
 	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "Bob"}, result)
+	assert.Equal(t, map[string]any{"name": "Bob"}, <-received)
 
-	agent.RegisterReasoner("test", func(ctx context.Context, input map[string]any) (any, error) {
-		return nil, assert.AnError
-	})
+	_, err = agent.Execute(context.Background(), "greet", map[string]any{})
+	require.ErrorContains(t, err, "user is required")
 
 	server := httptest.NewServer(agent.handler())
 	defer server.Close()
 
-	req, err := http.NewRequest(http.MethodPost, server.URL+"/reasoners/test", bytes.NewReader([]byte("{}")))
+	resp, err := http.Post(server.URL+"/reasoners/greet", "application/json", strings.NewReader(`{"user":"Alice","tone":"casual"}`))
 	require.NoError(t, err)
-	req.Header.Set("Content-Type", "application/json")
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]any{"name": "Alice", "tone": "casual"}, <-received)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err = http.Post(server.URL+"/reasoners/greet", "application/json", strings.NewReader(`{"name":"Alice"}`))
 	require.NoError(t, err)
-	defer resp.Body.Close()
-
-	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-
-	var result map[string]any
-	json.NewDecoder(resp.Body).Decode(&result)
-	assert.Contains(t, result["error"], "assert.AnError")
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, string(body), "user is required")
+	assert.Empty(t, received)
 }
 
-func TestCall(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if strings.Contains(r.URL.Path, "/execute/") {
-			// Verify headers
-			assert.Equal(t, "run-1", r.Header.Get("X-Run-ID"))
-			assert.Equal(t, "parent-exec", r.Header.Get("X-Parent-Execution-ID"))
-			assert.Equal(t, "session-1", r.Header.Get("X-Session-ID"))
-			assert.Equal(t, "actor-1", r.Header.Get("X-Actor-ID"))
-
-			var reqBody map[string]any
-			json.NewDecoder(r.Body).Decode(&reqBody)
-			assert.Equal(t, map[string]any{"value": float64(42)}, reqBody["input"])
-
-			resp := map[string]any{
-				"execution_id": "exec-1",
-				"run_id":       "run-1",
-				"status":       "succeeded",
-				"result":       map[string]any{"output": "result"},
-			}
-			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(resp)
-		}
-	}))
-	defer server.Close()
-
-	cfg := Config{
+func TestHandleReasoner_YAMLBody(t *testing.T) {
+	agent, err := New(Config{
 		NodeID:        "node-1",
 		Version:       "1.0.0",
-		AgentFieldURL: server.URL,
+		AgentFieldURL: "https://api.example.com",
 		Logger:        log.New(io.Discard, "", 0),
-	}
-
-	agent, err := New(cfg)
+	})
 	require.NoError(t, err)
 
-	// Create context with execution context
-	ctx := contextWithExecution(context.Background(), ExecutionContext{
-		RunID:       "run-1",
-		ExecutionID: "parent-exec",
-		SessionID:   "session-1",
-		ActorID:     "actor-1",
+	received := make(chan map[string]any, 2)
+	agent.RegisterReasoner("echo", func(ctx context.Context, input map[string]any) (any, error) {
+		received <- input
+		return input, nil
 	})
 
-	result, err := agent.Call(ctx, "target.node", map[string]any{"value": 42})
-	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, "result", result["output"])
-}
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
 
-func TestCall_ErrorHandling(t *testing.T) {
-	tests := []struct {
-		name           string
-		serverResponse func(w http.ResponseWriter, r *http.Request)
-		wantErr        bool
-	}{
-		{
-			name: "API error",
-			serverResponse: func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusBadRequest)
-				w.Write([]byte("bad request"))
-			},
-			wantErr: true,
-		},
-		{
-			name: "execution failed status",
-			serverResponse: func(w http.ResponseWriter, r *http.Request) {
-				resp := map[string]any{
-					"status":        "failed",
-					"error_message": "execution failed",
-				}
-				w.WriteHeader(http.StatusOK)
-				json.NewEncoder(w).Encode(resp)
-			},
-			wantErr: true,
-		},
+	post := func(contentType, body string) {
+		resp, err := http.Post(server.URL+"/reasoners/echo", contentType, strings.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
-			defer server.Close()
-
-			cfg := Config{
-				NodeID:        "node-1",
-				Version:       "1.0.0",
-				AgentFieldURL: server.URL,
-				Logger:        log.New(io.Discard, "", 0),
-			}
+	post("application/json", `{"name":"Bob","count":3,"nested":{"ok":true}}`)
+	post("application/yaml", "name: Bob\ncount: 3\nnested:\n  ok: true\n")
 
-			agent, err := New(cfg)
-			require.NoError(t, err)
+	fromJSON := <-received
+	fromYAML := <-received
+	assert.Equal(t, fromJSON, fromYAML)
 
-			result, err := agent.Call(context.Background(), "target", map[string]any{})
-			if tt.wantErr {
-				assert.Error(t, err)
-				assert.Nil(t, result)
-			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, result)
-			}
-		})
-	}
+	resp, err := http.Post(server.URL+"/reasoners/echo", "application/yaml", strings.NewReader("- not\n- a map\n"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
 
-func TestAI(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := ai.Response{
-			Choices: []ai.Choice{
-				{
-					Message: ai.Message{
-						Content: "AI response",
-					},
-				},
-			},
-		}
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(resp)
-	}))
-	defer server.Close()
-
-	cfg := Config{
+func TestHandleReasoner_MsgpackRoundTrip(t *testing.T) {
+	agent, err := New(Config{
 		NodeID:        "node-1",
 		Version:       "1.0.0",
 		AgentFieldURL: "https://api.example.com",
 		Logger:        log.New(io.Discard, "", 0),
-		AIConfig: &ai.Config{
-			APIKey:  "test-key",
-			BaseURL: server.URL,
-			Model:   "gpt-4o",
-		},
+	})
+	require.NoError(t, err)
+
+	received := make(chan map[string]any, 2)
+	agent.RegisterReasoner("echo", func(ctx context.Context, input map[string]any) (any, error) {
+		received <- input
+		return map[string]any{"echo": input}, nil
+	})
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	payload := map[string]any{
+		"name":   "Bob",
+		"count":  3,
+		"ratio":  0.5,
+		"tags":   []any{"a", "b"},
+		"nested": map[string]any{"ok": true},
 	}
 
-	agent, err := New(cfg)
+	jsonBody, err := json.Marshal(payload)
+	require.NoError(t, err)
+	jsonResp, err := http.Post(server.URL+"/reasoners/echo", "application/json", bytes.NewReader(jsonBody))
 	require.NoError(t, err)
+	defer jsonResp.Body.Close()
+	require.Equal(t, http.StatusOK, jsonResp.StatusCode)
+	var fromJSON map[string]any
+	require.NoError(t, json.NewDecoder(jsonResp.Body).Decode(&fromJSON))
 
-	resp, err := agent.AI(context.Background(), "Hello")
-	assert.NoError(t, err)
-	assert.NotNil(t, resp)
-	assert.Equal(t, "AI response", resp.Text())
+	msgpackBody, err := msgpack.Marshal(payload)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/reasoners/echo", bytes.NewReader(msgpackBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/msgpack")
+	req.Header.Set("Accept", "application/msgpack")
+	msgpackResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer msgpackResp.Body.Close()
+	require.Equal(t, http.StatusOK, msgpackResp.StatusCode)
+	assert.Equal(t, "application/msgpack", msgpackResp.Header.Get("Content-Type"))
+	var fromMsgpack map[string]any
+	require.NoError(t, msgpack.NewDecoder(msgpackResp.Body).Decode(&fromMsgpack))
+
+	// Handlers see identical input regardless of encoding.
+	assert.Equal(t, <-received, <-received)
+	assert.Equal(t, fromJSON, normalizeNumbers(fromMsgpack))
+}
+
+func TestHandleReasoner_PreciseNumbers(t *testing.T) {
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	received := make(chan map[string]any, 1)
+	record := func(ctx context.Context, input map[string]any) (any, error) {
+		received <- input
+		return input, nil
+	}
+	agent.RegisterReasoner("precise", record, WithPreciseNumbers())
+	agent.RegisterReasoner("default", record)
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	const largeID = int64(9007199254740993) // 2^53 + 1, not representable as float64
+	post := func(reasoner, contentType string, body []byte) string {
+		resp, err := http.Post(server.URL+"/reasoners/"+reasoner, contentType, bytes.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		out, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	body := post("precise", "application/json", []byte(`{"id":9007199254740993,"ratio":0.5}`))
+	input := <-received
+	id, ok := input["id"].(json.Number)
+	require.True(t, ok, "expected json.Number, got %T", input["id"])
+	got, err := id.Int64()
+	require.NoError(t, err)
+	assert.Equal(t, largeID, got)
+	ratio, err := input["ratio"].(json.Number).Float64()
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, ratio)
+	assert.Contains(t, body, "9007199254740993")
+
+	msgpackBody, err := msgpack.Marshal(map[string]any{"id": largeID})
+	require.NoError(t, err)
+	post("precise", "application/msgpack", msgpackBody)
+	assert.Equal(t, json.Number("9007199254740993"), (<-received)["id"])
+
+	post("precise", "application/yaml", []byte("id: 9007199254740993\n"))
+	assert.Equal(t, json.Number("9007199254740993"), (<-received)["id"])
+
+	resp, err := http.Post(server.URL+"/execute/precise", "application/json", strings.NewReader(`{"input":{"id":9007199254740993}}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, json.Number("9007199254740993"), (<-received)["id"])
+
+	post("default", "application/json", []byte(`{"id":9007199254740993}`))
+	lossy, ok := (<-received)["id"].(float64)
+	require.True(t, ok)
+	assert.NotEqual(t, largeID, int64(lossy))
+}
+
+func TestHandleReasoner_PropagatesCancellation(t *testing.T) {
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	observed := make(chan error, 1)
+	agent.RegisterReasoner("slow", func(ctx context.Context, input map[string]any) (any, error) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			observed <- ctx.Err()
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			observed <- nil
+			return map[string]any{"done": true}, nil
+		}
+	})
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/reasoners/slow", strings.NewReader(`{}`))
+	require.NoError(t, err)
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err = http.DefaultClient.Do(req)
+	require.Error(t, err)
+
+	select {
+	case err := <-observed:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not observe request cancellation")
+	}
+}
+
+func TestExecuteReasonerAsync_HonorsTimeout(t *testing.T) {
+	callbackCh := make(chan map[string]any, 1)
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err == nil {
+			callbackCh <- payload
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer callbackServer.Close()
+
+	agent, err := New(Config{
+		NodeID:                "node-1",
+		Version:               "1.0.0",
+		AgentFieldURL:         callbackServer.URL,
+		AsyncExecutionTimeout: 50 * time.Millisecond,
+		Logger:                log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("slow", func(ctx context.Context, input map[string]any) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/reasoners/slow", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	req.Header.Set("X-Execution-ID", "exec-timeout")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	select {
+	case payload := <-callbackCh:
+		assert.Equal(t, "failed", payload["status"])
+		assert.Contains(t, payload["error"], "deadline exceeded")
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for callback payload")
+	}
+}
+
+func TestHandleReasoner_NotFound(t *testing.T) {
+	cfg := Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+	}
+
+	agent, err := New(cfg)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/reasoners/nonexistent", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandleReasoner_WrongMethod(t *testing.T) {
+	cfg := Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+	}
+
+	agent, err := New(cfg)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/reasoners/test", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestHandleReasoner_Error(t *testing.T) {
+	cfg := Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+	}
+
+	agent, err := New(cfg)
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("test", func(ctx context.Context, input map[string]any) (any, error) {
+		return nil, assert.AnError
+	})
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/reasoners/test", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	var result map[string]any
+	json.NewDecoder(resp.Body).Decode(&result)
+	assert.Contains(t, result["error"], "assert.AnError")
+}
+
+func TestHandleSkill_Sync(t *testing.T) {
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	agent.RegisterSkill("double", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"value": input["value"].(float64) * 2}, nil
+	})
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/skills/double", "application/json", strings.NewReader(`{"value":21}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var result map[string]any
+	json.NewDecoder(resp.Body).Decode(&result)
+	assert.Equal(t, float64(42), result["value"])
+
+	// Skills are not served on the reasoner route.
+	resp2, err := http.Post(server.URL+"/reasoners/double", "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp2.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp2.StatusCode)
+}
+
+func TestHandleSkill_NotFound(t *testing.T) {
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/skills/missing", "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestInitialize_RequiredEnv(t *testing.T) {
+	var registrations atomic.Int32
+	regCh := make(chan types.NodeRegistrationRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/nodes" {
+			registrations.Add(1)
+			var req types.NodeRegistrationRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			regCh <- req
+			json.NewEncoder(w).Encode(types.NodeRegistrationResponse{ID: "node-1", Success: true})
+			return
+		}
+		json.NewEncoder(w).Encode(types.LeaseResponse{LeaseSeconds: 120})
+	}))
+	defer server.Close()
+
+	agent, err := New(Config{
+		NodeID:           "node-1",
+		Version:          "1.0.0",
+		AgentFieldURL:    server.URL,
+		Logger:           log.New(io.Discard, "", 0),
+		DisableLeaseLoop: true,
+	})
+	require.NoError(t, err)
+
+	noop := func(ctx context.Context, input map[string]any) (any, error) { return nil, nil }
+	agent.RegisterReasoner("summarize", noop, WithRequiredEnv("AF_TEST_API_KEY", "AF_TEST_MODEL"))
+	agent.RegisterReasoner("translate", noop, WithRequiredEnv("AF_TEST_API_KEY"))
+	agent.RegisterSkill("lookup", noop, WithRequiredEnv("AF_TEST_DB_URL"))
+
+	t.Setenv("AF_TEST_API_KEY", "")
+	t.Setenv("AF_TEST_MODEL", "gpt")
+	t.Setenv("AF_TEST_DB_URL", "")
+
+	err = agent.Initialize(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "missing required environment variables: AF_TEST_API_KEY (needed by summarize, translate); AF_TEST_DB_URL (needed by lookup)", err.Error())
+	assert.Zero(t, registrations.Load(), "agent must not register with missing env")
+	assert.False(t, agent.initialized)
+
+	t.Setenv("AF_TEST_API_KEY", "secret")
+	t.Setenv("AF_TEST_DB_URL", "postgres://db")
+	require.NoError(t, agent.Initialize(context.Background()))
+
+	req := <-regCh
+	required := map[string][]string{}
+	for _, r := range req.Reasoners {
+		required[r.ID] = r.RequiredEnv
+	}
+	assert.Equal(t, []string{"AF_TEST_API_KEY", "AF_TEST_MODEL"}, required["summarize"])
+	assert.Equal(t, []string{"AF_TEST_API_KEY"}, required["translate"])
+	require.Len(t, req.Skills, 1)
+	assert.Equal(t, []string{"AF_TEST_DB_URL"}, req.Skills[0].RequiredEnv)
+}
+
+func TestInitialize_RegistersSkills(t *testing.T) {
+	regCh := make(chan types.NodeRegistrationRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/nodes" {
+			var req types.NodeRegistrationRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			regCh <- req
+			json.NewEncoder(w).Encode(types.NodeRegistrationResponse{ID: "node-1", Success: true})
+			return
+		}
+		json.NewEncoder(w).Encode(types.LeaseResponse{LeaseSeconds: 120})
+	}))
+	defer server.Close()
+
+	agent, err := New(Config{
+		NodeID:           "node-1",
+		Version:          "1.0.0",
+		AgentFieldURL:    server.URL,
+		Logger:           log.New(io.Discard, "", 0),
+		DisableLeaseLoop: true,
+	})
+	require.NoError(t, err)
+
+	inputSchema := json.RawMessage(`{"type":"object","properties":{"value":{"type":"number"}}}`)
+	agent.RegisterSkill("double", func(ctx context.Context, input map[string]any) (any, error) {
+		return nil, nil
+	}, WithInputSchema(inputSchema))
+
+	require.NoError(t, agent.Initialize(context.Background()))
+
+	req := <-regCh
+	require.Len(t, req.Skills, 1)
+	assert.Equal(t, "double", req.Skills[0].ID)
+	assert.JSONEq(t, string(inputSchema), string(req.Skills[0].InputSchema))
+	assert.Empty(t, req.Reasoners)
+}
+
+func TestLeaseLoop_OnLeaseLost(t *testing.T) {
+	var renewals atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/nodes" {
+			json.NewEncoder(w).Encode(types.NodeRegistrationResponse{ID: "node-1", Success: true})
+			return
+		}
+		renewals.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	lostCh := make(chan error, 1)
+	agent, err := New(Config{
+		NodeID:               "node-1",
+		Version:              "1.0.0",
+		AgentFieldURL:        server.URL,
+		Logger:               log.New(io.Discard, "", 0),
+		LeaseRefreshInterval: 20 * time.Millisecond,
+		LeaseRenewalRetries:  2,
+		LeaseRenewalBackoff:  5 * time.Millisecond,
+		LeaseGracePeriod:     150 * time.Millisecond,
+		OnLeaseLost: func(err error) {
+			lostCh <- err
+		},
+	})
+	require.NoError(t, err)
+	agent.RegisterReasoner("noop", func(ctx context.Context, input map[string]any) (any, error) {
+		return nil, nil
+	})
+
+	start := time.Now()
+	require.NoError(t, agent.Initialize(context.Background()))
+	defer agent.shutdown(context.Background())
+
+	select {
+	case err := <-lostCh:
+		assert.Error(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond, "callback must wait for the grace period")
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnLeaseLost was not invoked")
+	}
+
+	// Renewals were retried within each tick
+	assert.Greater(t, renewals.Load(), int32(2))
+
+	// The callback fires only once per lost lease
+	select {
+	case <-lostCh:
+		t.Fatal("OnLeaseLost invoked more than once")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestLifecycleHooksAndStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/nodes" {
+			json.NewEncoder(w).Encode(types.NodeRegistrationResponse{ID: "node-1", Success: true})
+			return
+		}
+		json.NewEncoder(w).Encode(types.LeaseResponse{LeaseSeconds: 90})
+	}))
+	defer server.Close()
+
+	var registered, shutdown atomic.Bool
+	renewedCh := make(chan types.LeaseResponse, 1)
+	agent, err := New(Config{
+		NodeID:               "node-1",
+		Version:              "1.0.0",
+		AgentFieldURL:        server.URL,
+		Logger:               log.New(io.Discard, "", 0),
+		LeaseRefreshInterval: 20 * time.Millisecond,
+		OnRegistered:         func() { registered.Store(true) },
+		OnLeaseRenewed: func(lease types.LeaseResponse) {
+			select {
+			case renewedCh <- lease:
+			default:
+			}
+		},
+		OnShutdown: func() { shutdown.Store(true) },
+	})
+	require.NoError(t, err)
+	agent.RegisterReasoner("noop", func(ctx context.Context, input map[string]any) (any, error) {
+		return nil, nil
+	})
+
+	assert.False(t, agent.Status().Registered)
+
+	require.NoError(t, agent.Initialize(context.Background()))
+	assert.True(t, registered.Load())
+
+	status := agent.Status()
+	assert.True(t, status.Registered)
+	assert.Equal(t, 90, status.LeaseSeconds)
+	assert.False(t, status.LastLeaseRenewal.IsZero())
+
+	select {
+	case lease := <-renewedCh:
+		assert.Equal(t, 90, lease.LeaseSeconds)
+	case <-time.After(time.Second):
+		t.Fatal("OnLeaseRenewed was not invoked")
+	}
+	assert.True(t, agent.Status().LastLeaseRenewal.After(status.LastLeaseRenewal))
+
+	require.NoError(t, agent.shutdown(context.Background()))
+	assert.True(t, shutdown.Load())
+	assert.False(t, agent.Status().Registered)
+}
+
+func TestCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/execute/") {
+			// Verify headers
+			assert.Equal(t, "run-1", r.Header.Get("X-Run-ID"))
+			assert.Equal(t, "parent-exec", r.Header.Get("X-Parent-Execution-ID"))
+			assert.Equal(t, "session-1", r.Header.Get("X-Session-ID"))
+			assert.Equal(t, "actor-1", r.Header.Get("X-Actor-ID"))
+
+			var reqBody map[string]any
+			json.NewDecoder(r.Body).Decode(&reqBody)
+			assert.Equal(t, map[string]any{"value": float64(42)}, reqBody["input"])
+
+			resp := map[string]any{
+				"execution_id": "exec-1",
+				"run_id":       "run-1",
+				"status":       "succeeded",
+				"result":       map[string]any{"output": "result"},
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(resp)
+		}
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: server.URL,
+		Logger:        log.New(io.Discard, "", 0),
+	}
+
+	agent, err := New(cfg)
+	require.NoError(t, err)
+
+	// Create context with execution context
+	ctx := contextWithExecution(context.Background(), ExecutionContext{
+		RunID:       "run-1",
+		ExecutionID: "parent-exec",
+		SessionID:   "session-1",
+		ActorID:     "actor-1",
+	})
+
+	result, err := agent.Call(ctx, "target.node", map[string]any{"value": 42})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "result", result["output"])
+}
+
+func TestCall_ForwardsTraceContext(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	var forwarded atomic.Value
+	controlPlane := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/execute/") {
+			forwarded.Store(r.Header.Get("traceparent"))
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]any{
+				"execution_id": "exec-child",
+				"run_id":       "run-1",
+				"status":       "succeeded",
+				"result":       map[string]any{"ok": true},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer controlPlane.Close()
+
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: controlPlane.URL,
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("orchestrate", func(ctx context.Context, input map[string]any) (any, error) {
+		return agent.Call(ctx, "other.reasoner", input)
+	})
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/reasoners/orchestrate", strings.NewReader(`{"value":1}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Run-ID", "run-1")
+	req.Header.Set("traceparent", traceparent)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, traceparent, forwarded.Load())
+}
+
+func TestCall_ErrorHandling(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		wantErr        bool
+	}{
+		{
+			name: "API error",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte("bad request"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "execution failed status",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				resp := map[string]any{
+					"status":        "failed",
+					"error_message": "execution failed",
+				}
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(resp)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			defer server.Close()
+
+			cfg := Config{
+				NodeID:        "node-1",
+				Version:       "1.0.0",
+				AgentFieldURL: server.URL,
+				Logger:        log.New(io.Discard, "", 0),
+			}
+
+			agent, err := New(cfg)
+			require.NoError(t, err)
+
+			result, err := agent.Call(context.Background(), "target", map[string]any{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+		})
+	}
+}
+
+func TestCallWithOptions_RetriesTransientErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "run-1", r.Header.Get("X-Run-ID"))
+		assert.Equal(t, "parent-exec", r.Header.Get("X-Parent-Execution-ID"))
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("unavailable"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]any{
+			"status": "succeeded",
+			"result": map[string]any{"output": "result"},
+		})
+	}))
+	defer server.Close()
+
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: server.URL,
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	ctx := contextWithExecution(context.Background(), ExecutionContext{
+		RunID:       "run-1",
+		ExecutionID: "parent-exec",
+	})
+
+	result, err := agent.CallWithOptions(ctx, "target.node", map[string]any{}, CallOptions{
+		MaxRetries:   3,
+		RetryBackoff: time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "result", result["output"])
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestCallWithOptions_DoesNotRetryFailedStatus(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]any{
+			"status":        "failed",
+			"error_message": "boom",
+		})
+	}))
+	defer server.Close()
+
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: server.URL,
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	_, err = agent.CallWithOptions(context.Background(), "target.node", map[string]any{}, CallOptions{MaxRetries: 3})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestCallWithOptions_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: server.URL,
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = agent.CallWithOptions(context.Background(), "target.node", map[string]any{}, CallOptions{
+		Timeout:    50 * time.Millisecond,
+		MaxRetries: 5,
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestCallBatch(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			prev := atomic.LoadInt32(&maxInFlight)
+			if current <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+
+		assert.Equal(t, "run-1", r.Header.Get("X-Run-ID"))
+		assert.Equal(t, "parent-exec", r.Header.Get("X-Parent-Execution-ID"))
+
+		if strings.HasSuffix(r.URL.Path, "/node.fail") {
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]any{"status": "failed", "error_message": "boom"})
+			return
+		}
+
+		var reqBody map[string]any
+		json.NewDecoder(r.Body).Decode(&reqBody)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]any{
+			"status": "succeeded",
+			"result": map[string]any{"echo": reqBody["input"].(map[string]any)["n"]},
+		})
+	}))
+	defer server.Close()
+
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: server.URL,
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	ctx := contextWithExecution(context.Background(), ExecutionContext{
+		RunID:       "run-1",
+		ExecutionID: "parent-exec",
+	})
+
+	calls := []BatchCall{
+		{Target: "node.a", Input: map[string]any{"n": 0}},
+		{Target: "node.fail", Input: map[string]any{"n": 1}},
+		{Target: "node.b", Input: map[string]any{"n": 2}},
+		{Target: "node.c", Input: map[string]any{"n": 3}},
+	}
+
+	results, err := agent.CallBatch(ctx, calls)
+	require.NoError(t, err)
+	require.Len(t, results, len(calls))
+
+	for i, res := range results {
+		assert.Equal(t, calls[i].Target, res.Target)
+		if calls[i].Target == "node.fail" {
+			assert.Error(t, res.Err)
+			continue
+		}
+		require.NoError(t, res.Err)
+		assert.Equal(t, float64(i), res.Result["echo"])
+	}
+	assert.Greater(t, atomic.LoadInt32(&maxInFlight), int32(1))
+}
+
+func TestAI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := ai.Response{
+			Choices: []ai.Choice{
+				{
+					Message: ai.Message{
+						Content: "AI response",
+					},
+				},
+			},
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	cfg := Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+		AIConfig: &ai.Config{
+			APIKey:  "test-key",
+			BaseURL: server.URL,
+			Model:   "gpt-4o",
+		},
+	}
+
+	agent, err := New(cfg)
+	require.NoError(t, err)
+
+	resp, err := agent.AI(context.Background(), "Hello")
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, "AI response", resp.Text())
 }
 
 func TestAI_NotConfigured(t *testing.T) {
@@ -561,6 +1559,118 @@ func TestAI_NotConfigured(t *testing.T) {
 	assert.Contains(t, err.Error(), "AI not configured")
 }
 
+func TestAIMessages(t *testing.T) {
+	var received ai.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		json.NewEncoder(w).Encode(ai.Response{
+			Choices: []ai.Choice{{Message: ai.Message{Role: "assistant", Content: "Bonjour"}}},
+		})
+	}))
+	defer server.Close()
+
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+		AIConfig: &ai.Config{
+			APIKey:  "test-key",
+			BaseURL: server.URL,
+			Model:   "gpt-4o",
+		},
+	})
+	require.NoError(t, err)
+
+	resp, err := agent.AIMessages(context.Background(), []ai.Message{
+		{Role: "system", Content: "Reply in French"},
+		{Role: "user", Content: "Hello"},
+	}, ai.WithTemperature(0.2), ai.WithMaxTokens(32))
+	require.NoError(t, err)
+	assert.Equal(t, "Bonjour", resp.Text())
+
+	assert.Equal(t, []ai.Message{
+		{Role: "system", Content: "Reply in French"},
+		{Role: "user", Content: "Hello"},
+	}, received.Messages)
+	require.NotNil(t, received.Temperature)
+	assert.Equal(t, 0.2, *received.Temperature)
+	require.NotNil(t, received.MaxTokens)
+	assert.Equal(t, 32, *received.MaxTokens)
+}
+
+func TestAI_RequestTimeoutBoundsCallsNotStreams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ai.Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/chat/completions") && req.Stream:
+			// A slow stream: each chunk arrives well within the idle timeout, but the
+			// whole stream takes longer than the request timeout.
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, chunk := range []string{
+				`data: {"choices":[{"delta":{"content":"slow"}}]}`,
+				`data: {"choices":[{"delta":{"content":" story"}}]}`,
+				`data: [DONE]`,
+			} {
+				time.Sleep(40 * time.Millisecond)
+				w.Write([]byte(chunk + "\n\n"))
+				w.(http.Flusher).Flush()
+			}
+		case strings.HasSuffix(r.URL.Path, "/chat/completions"):
+			// A hung provider never answers a non-streaming request.
+			<-r.Context().Done()
+		case strings.Contains(r.URL.Path, "/execute/"):
+			time.Sleep(100 * time.Millisecond)
+			json.NewEncoder(w).Encode(map[string]any{
+				"execution_id": "exec-1",
+				"run_id":       "run-1",
+				"status":       "succeeded",
+				"result":       map[string]any{"output": "ok"},
+			})
+		}
+	}))
+	defer server.Close()
+
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: server.URL,
+		Logger:        log.New(io.Discard, "", 0),
+		AIConfig: &ai.Config{
+			APIKey:            "test-key",
+			BaseURL:           server.URL,
+			Model:             "gpt-4o",
+			RequestTimeout:    80 * time.Millisecond,
+			StreamIdleTimeout: time.Second,
+		},
+	})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = agent.AI(context.Background(), "Hello")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	start = time.Now()
+	chunks, errs := agent.AIStream(context.Background(), "Tell me a story")
+	var text strings.Builder
+	for chunk := range chunks {
+		if len(chunk.Choices) > 0 {
+			text.WriteString(chunk.Choices[0].Delta.Content)
+		}
+	}
+	require.NoError(t, <-errs)
+	assert.Equal(t, "slow story", text.String())
+	assert.Greater(t, time.Since(start), 80*time.Millisecond, "the stream outlived the request timeout")
+
+	// Other agent HTTP work is not bound by the AI request timeout.
+	result, err := agent.Call(context.Background(), "target.node", map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result["output"])
+}
+
 func TestAIStream(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/event-stream")
@@ -625,6 +1735,65 @@ func TestAIStream(t *testing.T) {
 	assert.NotNil(t, errs)
 }
 
+func TestAIStream_CancelStopsStream(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("data: {\"id\":\"test\",\"choices\":[{\"delta\":{\"content\":\"Hello\"}}]}\n\n"))
+		w.(http.Flusher).Flush()
+
+		// Hold the stream open so only cancellation can end it.
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+		AIConfig: &ai.Config{
+			APIKey:  "test-key",
+			BaseURL: server.URL,
+			Model:   "gpt-4o",
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	chunks, errs := agent.AIStream(ctx, "Hello")
+
+	select {
+	case chunk := <-chunks:
+		require.NotEmpty(t, chunk.Choices)
+		assert.Equal(t, "Hello", chunk.Choices[0].Delta.Content)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for first chunk")
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-chunks:
+		assert.False(t, ok, "no chunks expected after cancellation")
+	case <-time.After(2 * time.Second):
+		t.Fatal("chunks channel not closed after cancellation")
+	}
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no error after cancellation")
+	}
+}
+
 func TestAIStream_NotConfigured(t *testing.T) {
 	cfg := Config{
 		NodeID:        "node-1",
@@ -674,6 +1843,82 @@ func TestExecutionContext_Empty(t *testing.T) {
 	assert.Equal(t, ExecutionContext{}, execCtx)
 }
 
+func TestHandleReasoner_PanicReturnsSanitizedError(t *testing.T) {
+	var logs bytes.Buffer
+	cfg := Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(&logs, "", 0),
+	}
+
+	agent, err := New(cfg)
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("boom", func(ctx context.Context, input map[string]any) (any, error) {
+		panic("secret internal state")
+	})
+	agent.RegisterReasoner("ok", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"ok": true}, nil
+	})
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/reasoners/boom", "application/json", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	var result map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, "reasoner boom panicked", result["error"])
+	assert.Contains(t, logs.String(), "secret internal state")
+	assert.Contains(t, logs.String(), "goroutine")
+
+	// The server keeps serving after a reasoner panic.
+	resp2, err := http.Post(server.URL+"/reasoners/ok", "application/json", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusOK, resp2.StatusCode)
+}
+
+func TestReasonerPanicRecoveredOnAllEntryPoints(t *testing.T) {
+	agent, err := New(Config{
+		NodeID:         "node-1",
+		Version:        "1.0.0",
+		DeploymentType: "serverless",
+		Logger:         log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("boom", func(ctx context.Context, input map[string]any) (any, error) {
+		panic("secret internal state")
+	})
+
+	t.Run("execute endpoint", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/execute/boom", strings.NewReader(`{"input":{}}`))
+		rec := httptest.NewRecorder()
+		agent.Handler().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "reasoner boom panicked")
+		assert.NotContains(t, rec.Body.String(), "secret internal state")
+	})
+
+	t.Run("Execute", func(t *testing.T) {
+		_, err := agent.Execute(context.Background(), "boom", nil)
+		require.EqualError(t, err, "reasoner boom panicked")
+	})
+
+	t.Run("HandleServerlessEvent", func(t *testing.T) {
+		payload, status, err := agent.HandleServerlessEvent(context.Background(), map[string]any{"reasoner": "boom"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.Equal(t, "reasoner boom panicked", payload["error"])
+	})
+}
+
 func TestHandleReasonerAsyncPostsStatus(t *testing.T) {
 	callbackCh := make(chan map[string]any, 1)
 	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -732,6 +1977,59 @@ func TestHandleReasonerAsyncPostsStatus(t *testing.T) {
 	}
 }
 
+func TestHandleReasonerAsyncPanicPostsFailedStatus(t *testing.T) {
+	callbackCh := make(chan map[string]any, 1)
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err == nil {
+			callbackCh <- payload
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer callbackServer.Close()
+
+	cfg := Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		TeamID:        "team",
+		AgentFieldURL: callbackServer.URL,
+		ListenAddress: ":0",
+		PublicURL:     "http://localhost:0",
+		Logger:        log.New(io.Discard, "[test] ", 0),
+	}
+
+	agent, err := New(cfg)
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("boom", func(ctx context.Context, input map[string]any) (any, error) {
+		panic("secret internal state")
+	})
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/reasoners/boom", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Execution-ID", "exec-panic")
+	req.Header.Set("X-Run-ID", "run-1")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resp.Body.Close()
+
+	select {
+	case payload := <-callbackCh:
+		assert.Equal(t, "exec-panic", payload["execution_id"])
+		assert.Equal(t, "failed", payload["status"])
+		assert.Equal(t, "reasoner boom panicked", payload["error"])
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for callback payload")
+	}
+}
+
 func TestChildContext(t *testing.T) {
 	parent := ExecutionContext{
 		RunID:          "run-1",
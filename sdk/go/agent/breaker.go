@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultCallBreakerCooldown is how long an open call breaker fails fast before letting
+// a trial call through.
+const defaultCallBreakerCooldown = 30 * time.Second
+
+// ErrCircuitOpen is returned by Call while the circuit breaker for the target is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// breakerState tracks one call target.
+type breakerState struct {
+	failures int
+	openedAt time.Time
+	open     bool
+	// probing is set while the single trial call of a half-open breaker is in flight.
+	probing bool
+}
+
+// callBreaker is a per-target circuit breaker for outbound calls. After threshold
+// consecutive transient failures the target's breaker opens and calls fail fast for
+// cooldown. The first call after that is let through as a trial: success closes the
+// breaker, failure opens it again. A nil callBreaker allows every call.
+type callBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu      sync.Mutex
+	targets map[string]*breakerState
+}
+
+func newCallBreaker(threshold int, cooldown time.Duration) *callBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCallBreakerCooldown
+	}
+	return &callBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		targets:   make(map[string]*breakerState),
+	}
+}
+
+// allow returns ErrCircuitOpen when a call to target must fail fast.
+func (b *callBreaker) allow(target string) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.targets[target]
+	if !ok || !state.open {
+		return nil
+	}
+	if state.probing || time.Since(state.openedAt) < b.cooldown {
+		return ErrCircuitOpen
+	}
+	state.probing = true
+	return nil
+}
+
+// record updates the breaker for target with the outcome of a call let through by allow.
+// Only transient failures count against the target; a reasoner-reported failure shows
+// the target is reachable. Cancelled calls leave the breaker unchanged.
+func (b *callBreaker) record(target string, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.targets[target]
+	if !ok {
+		state = &breakerState{}
+		b.targets[target] = state
+	}
+	wasProbing := state.probing
+	state.probing = false
+
+	var transient *transientCallError
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return
+	case errors.As(err, &transient):
+		state.failures++
+		if wasProbing || state.failures >= b.threshold {
+			state.open = true
+			state.openedAt = time.Now()
+		}
+	default:
+		delete(b.targets, target)
+	}
+}
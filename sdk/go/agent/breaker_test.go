@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCall_CircuitBreaker(t *testing.T) {
+	var hits int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("unavailable"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]any{
+			"status": "succeeded",
+			"result": map[string]any{"output": "result"},
+		})
+	}))
+	defer server.Close()
+
+	const cooldown = 100 * time.Millisecond
+	agent, err := New(Config{
+		NodeID:               "node-1",
+		Version:              "1.0.0",
+		AgentFieldURL:        server.URL,
+		Logger:               log.New(io.Discard, "", 0),
+		CallBreakerThreshold: 3,
+		CallBreakerCooldown:  cooldown,
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := agent.Call(ctx, "flaky.reasoner", map[string]any{})
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&hits))
+
+	// Open: calls fail fast without reaching the control plane.
+	for i := 0; i < 5; i++ {
+		_, err := agent.Call(ctx, "flaky.reasoner", map[string]any{})
+		require.ErrorIs(t, err, ErrCircuitOpen)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&hits))
+
+	// Other targets are unaffected.
+	_, err = agent.Call(ctx, "other.reasoner", map[string]any{})
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&hits))
+
+	// Half-open: a failed trial call opens the breaker again.
+	time.Sleep(cooldown + 20*time.Millisecond)
+	_, err = agent.Call(ctx, "flaky.reasoner", map[string]any{})
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&hits))
+	_, err = agent.Call(ctx, "flaky.reasoner", map[string]any{})
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&hits))
+
+	// Half-open: a successful trial call closes the breaker.
+	healthy.Store(true)
+	time.Sleep(cooldown + 20*time.Millisecond)
+	result, err := agent.Call(ctx, "flaky.reasoner", map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, "result", result["output"])
+	_, err = agent.Call(ctx, "flaky.reasoner", map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), atomic.LoadInt32(&hits))
+}
+
+func TestCall_CircuitBreakerIgnoresReasonerFailures(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]any{
+			"status":        "failed",
+			"error_message": "boom",
+		})
+	}))
+	defer server.Close()
+
+	agent, err := New(Config{
+		NodeID:               "node-1",
+		Version:              "1.0.0",
+		AgentFieldURL:        server.URL,
+		Logger:               log.New(io.Discard, "", 0),
+		CallBreakerThreshold: 2,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		_, err := agent.Call(context.Background(), "target.reasoner", map[string]any{})
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+	assert.Equal(t, int32(4), atomic.LoadInt32(&hits))
+}
@@ -0,0 +1,159 @@
+package agent
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// defaultReasonerCacheSize bounds how many results a single reasoner cache keeps.
+const defaultReasonerCacheSize = 256
+
+// WithCache memoizes successful reasoner results for ttl. keyFn derives the cache
+// key from the input; when nil the JSON encoding of the input is used. Returning an
+// empty key bypasses the cache for that call. Each reasoner keeps at most
+// defaultReasonerCacheSize entries, evicting the least recently used. Maps and
+// slices in a result are copied on the way in and out, so callers may modify what
+// they receive; values behind pointers are shared.
+func WithCache(ttl time.Duration, keyFn func(input map[string]any) string) ReasonerOption {
+	return func(r *Reasoner) {
+		if ttl <= 0 || r.Handler == nil {
+			return
+		}
+		if keyFn == nil {
+			keyFn = defaultCacheKey
+		}
+		cache := newResultCache(defaultReasonerCacheSize, ttl)
+		next := r.Handler
+		r.Handler = func(ctx context.Context, input map[string]any) (any, error) {
+			key := keyFn(input)
+			if key == "" {
+				return next(ctx, input)
+			}
+			if result, ok := cache.get(key); ok {
+				return cloneCachedValue(result), nil
+			}
+			result, err := next(ctx, input)
+			if err == nil {
+				cache.put(key, cloneCachedValue(result))
+			}
+			return result, err
+		}
+	}
+}
+
+func defaultCacheKey(input map[string]any) string {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// cloneCachedValue deep-copies the maps and slices in v so a cached result is never
+// shared with a caller.
+func cloneCachedValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		if val == nil {
+			return val
+		}
+		copied := make(map[string]any, len(val))
+		for k, item := range val {
+			copied[k] = cloneCachedValue(item)
+		}
+		return copied
+	case []any:
+		if val == nil {
+			return val
+		}
+		copied := make([]any, len(val))
+		for i, item := range val {
+			copied[i] = cloneCachedValue(item)
+		}
+		return copied
+	case []map[string]any:
+		if val == nil {
+			return val
+		}
+		copied := make([]map[string]any, len(val))
+		for i, item := range val {
+			copied[i] = cloneCachedValue(item).(map[string]any)
+		}
+		return copied
+	case []string:
+		if val == nil {
+			return val
+		}
+		return append([]string(nil), val...)
+	default:
+		return v
+	}
+}
+
+type cacheEntry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+// resultCache is a fixed-size LRU with per-entry expiry.
+type resultCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List // front is most recently used
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+func newResultCache(capacity int, ttl time.Duration) *resultCache {
+	return &resultCache{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+func (c *resultCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *resultCache) put(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCache_ReturnsCachedResult(t *testing.T) {
+	a := newTestAgent(t)
+
+	calls := 0
+	a.RegisterReasoner("embed", func(ctx context.Context, input map[string]any) (any, error) {
+		calls++
+		return map[string]any{"text": input["text"], "call": calls}, nil
+	}, WithCache(time.Minute, func(input map[string]any) string {
+		return fmt.Sprint(input["text"])
+	}))
+
+	first, err := a.Execute(context.Background(), "embed", map[string]any{"text": "hello"})
+	require.NoError(t, err)
+	second, err := a.Execute(context.Background(), "embed", map[string]any{"text": "hello", "ignored": true})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, first, second)
+
+	_, err = a.Execute(context.Background(), "embed", map[string]any{"text": "other"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestWithCache_ResultsAreNotShared(t *testing.T) {
+	a := newTestAgent(t)
+
+	var produced map[string]any
+	a.RegisterReasoner("lookup", func(ctx context.Context, input map[string]any) (any, error) {
+		produced = map[string]any{
+			"name": "alpha",
+			"tags": []any{"a", "b"},
+			"meta": map[string]any{"score": 1},
+		}
+		return produced, nil
+	}, WithCache(time.Minute, nil))
+
+	first, err := a.Execute(context.Background(), "lookup", map[string]any{"id": 1})
+	require.NoError(t, err)
+	got := first.(map[string]any)
+	got["name"] = "mutated"
+	got["tags"].([]any)[0] = "mutated"
+	got["meta"].(map[string]any)["score"] = 99
+	// Mutating what the handler returned must not leak into the cache either.
+	produced["name"] = "handler-mutated"
+
+	second, err := a.Execute(context.Background(), "lookup", map[string]any{"id": 1})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name": "alpha",
+		"tags": []any{"a", "b"},
+		"meta": map[string]any{"score": 1},
+	}, second)
+}
+
+func TestWithCache_SkipsErrors(t *testing.T) {
+	a := newTestAgent(t)
+
+	calls := 0
+	a.RegisterReasoner("flaky", func(ctx context.Context, input map[string]any) (any, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("transient")
+		}
+		return "ok", nil
+	}, WithCache(time.Minute, nil))
+
+	_, err := a.Execute(context.Background(), "flaky", map[string]any{"q": 1})
+	require.Error(t, err)
+	result, err := a.Execute(context.Background(), "flaky", map[string]any{"q": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	result, err = a.Execute(context.Background(), "flaky", map[string]any{"q": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, 2, calls)
+}
+
+func TestResultCache_ExpiryAndEviction(t *testing.T) {
+	now := time.Unix(0, 0)
+	cache := newResultCache(2, time.Second)
+	cache.now = func() time.Time { return now }
+
+	cache.put("a", 1)
+	cache.put("b", 2)
+	_, ok := cache.get("a") // a becomes most recently used
+	require.True(t, ok)
+	cache.put("c", 3) // evicts b
+
+	_, ok = cache.get("b")
+	assert.False(t, ok)
+	value, ok := cache.get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+
+	now = now.Add(time.Second)
+	_, ok = cache.get("a")
+	assert.False(t, ok)
+}
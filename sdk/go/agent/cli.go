@@ -1,16 +1,21 @@
 package agent
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -18,7 +23,13 @@ import (
 type cliContextKey struct{}
 
 // CLIError represents an error encountered while handling CLI input.
-// Code follows common CLI semantics: 0 success, 1 runtime error, 2 usage error.
+// Code follows common CLI semantics:
+//
+//	0  success
+//	1  runtime error: the reasoner (or a selftest run) returned an error
+//	2  usage error: bad flags or input, or no matching CLI reasoner
+//
+// Set CLIConfig.NoExitOnError to receive the underlying error instead.
 type CLIError struct {
 	Code int
 	Err  error
@@ -28,6 +39,10 @@ const (
 	ansiReset = "\033[0m"
 	ansiBold  = "\033[1m"
 	ansiCyan  = "\033[36m"
+	ansiGreen = "\033[32m"
+	ansiRed   = "\033[31m"
+
+	ansiClearScreen = "\033[H\033[2J"
 )
 
 func (e *CLIError) Error() string {
@@ -67,6 +82,18 @@ type cliInvocation struct {
 	helpTarget   string
 	version      bool
 	useColor     bool
+	quiet        bool
+	watch        time.Duration
+
+	defaultReasoner string
+}
+
+// cliFileConfig is the on-disk shape of the CLI config file. Every field is
+// optional; explicit flags always take precedence.
+type cliFileConfig struct {
+	Output          string `yaml:"output"`
+	Color           *bool  `yaml:"color"`
+	DefaultReasoner string `yaml:"default_reasoner"`
 }
 
 type cliContext struct {
@@ -97,14 +124,29 @@ func GetCLIArgs(ctx context.Context) map[string]string {
 }
 
 func (a *Agent) runCLI(ctx context.Context, args []string) error {
+	err := a.dispatchCLI(ctx, args)
+	if cfg := a.cfg.CLIConfig; cfg != nil && cfg.NoExitOnError {
+		var cliErr *CLIError
+		if errors.As(err, &cliErr) && cliErr.Err != nil {
+			return cliErr.Err
+		}
+	}
+	return err
+}
+
+func (a *Agent) dispatchCLI(ctx context.Context, args []string) error {
 	if !a.hasCLIReasoners() {
 		return &CLIError{Code: 2, Err: errors.New("no CLI reasoners registered; add agent.WithCLI() to a reasoner")}
 	}
 
 	inv, err := a.parseCLIArgs(args)
 	if err != nil {
-		a.printHelp("", inv.useColor)
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if inv.quiet {
+			printQuietError(err)
+		} else {
+			a.printHelp("", inv.useColor)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
 		return &CLIError{Code: 2, Err: err}
 	}
 
@@ -118,20 +160,34 @@ func (a *Agent) runCLI(ctx context.Context, args []string) error {
 	case inv.command == "help" || inv.help:
 		a.printHelp(inv.helpTarget, inv.useColor)
 		return nil
+	case inv.command == "selftest":
+		return a.runSelfTest(ctx, inv)
 	}
 
 	reasonerName := inv.command
+	if reasonerName == "" {
+		reasonerName = inv.defaultReasoner
+	}
 	if reasonerName == "" {
 		reasonerName = a.defaultCLIReasoner
 	}
 	if reasonerName == "" {
-		a.printHelp("", inv.useColor)
-		return &CLIError{Code: 2, Err: errors.New("no default CLI reasoner configured")}
+		err := errors.New("no default CLI reasoner configured")
+		if inv.quiet {
+			printQuietError(err)
+		} else {
+			a.printHelp("", inv.useColor)
+		}
+		return &CLIError{Code: 2, Err: err}
 	}
 
 	reasoner, ok := a.reasoners[reasonerName]
 	if !ok || !reasoner.CLIEnabled {
-		return &CLIError{Code: 2, Err: fmt.Errorf("reasoner %q is not available for CLI use", reasonerName)}
+		err := fmt.Errorf("reasoner %q is not available for CLI use", reasonerName)
+		if inv.quiet {
+			printQuietError(err)
+		}
+		return &CLIError{Code: 2, Err: err}
 	}
 
 	ctx = withCLIContext(ctx, cliContext{
@@ -141,13 +197,20 @@ func (a *Agent) runCLI(ctx context.Context, args []string) error {
 		useColor:     inv.useColor,
 	})
 
-	result, execErr := a.Execute(ctx, reasonerName, inv.input)
-
 	formatter := reasoner.CLIFormatter
 	if formatter == nil {
 		formatter = defaultFormatter(inv.outputFormat, inv.useColor)
+		if inv.quiet {
+			formatter = quietFormatter(formatter)
+		}
 	}
 
+	if inv.watch > 0 {
+		return a.watchReasoner(ctx, inv, reasonerName, formatter)
+	}
+
+	result, execErr := a.Execute(ctx, reasonerName, inv.input)
+
 	formatter(ctx, result, execErr)
 	if execErr != nil {
 		return &CLIError{Code: 1, Err: execErr}
@@ -166,6 +229,20 @@ func (a *Agent) parseCLIArgs(args []string) (cliInvocation, error) {
 		inv.outputFormat = strings.ToLower(strings.TrimSpace(cfg.DefaultOutputFormat))
 	}
 
+	fileCfg, err := a.loadCLIConfigFile()
+	if err != nil {
+		return inv, err
+	}
+	if fileCfg != nil {
+		if format := strings.ToLower(strings.TrimSpace(fileCfg.Output)); format != "" {
+			inv.outputFormat = format
+		}
+		if fileCfg.Color != nil {
+			inv.useColor = *fileCfg.Color
+		}
+		inv.defaultReasoner = strings.TrimSpace(fileCfg.DefaultReasoner)
+	}
+
 	var rawInput string
 	var inputFile string
 	for i := 0; i < len(args); i++ {
@@ -213,6 +290,25 @@ func (a *Agent) parseCLIArgs(args []string) (cliInvocation, error) {
 			inv.outputFormat = strings.ToLower(strings.TrimSpace(args[i]))
 		case arg == "--no-color":
 			inv.useColor = false
+		case strings.HasPrefix(arg, "--watch="):
+			interval, err := parseWatchInterval(strings.TrimPrefix(arg, "--watch="))
+			if err != nil {
+				return inv, err
+			}
+			inv.watch = interval
+		case arg == "--watch":
+			if i+1 >= len(args) {
+				return inv, errors.New("missing interval for --watch")
+			}
+			i++
+			interval, err := parseWatchInterval(args[i])
+			if err != nil {
+				return inv, err
+			}
+			inv.watch = interval
+		case arg == "-q" || arg == "--quiet":
+			inv.quiet = true
+			inv.useColor = false
 		default:
 			if strings.HasPrefix(arg, "-") {
 				return inv, fmt.Errorf("unknown flag %s", arg)
@@ -253,6 +349,47 @@ func (a *Agent) parseCLIArgs(args []string) (cliInvocation, error) {
 	return inv, nil
 }
 
+// cliConfigFilePath returns the CLI config file location, honouring
+// CLIConfig.ConfigFilePath before falling back to ~/.config/<app>/config.yaml.
+func (a *Agent) cliConfigFilePath() string {
+	if cfg := a.cfg.CLIConfig; cfg != nil && strings.TrimSpace(cfg.ConfigFilePath) != "" {
+		return strings.TrimSpace(cfg.ConfigFilePath)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".config", a.cliAppName(), "config.yaml")
+}
+
+// loadCLIConfigFile reads the CLI config file. A missing file is not an error.
+func (a *Agent) loadCLIConfigFile() (*cliFileConfig, error) {
+	path := a.cliConfigFilePath()
+	if path == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read CLI config file: %w", err)
+	}
+
+	var cfg cliFileConfig
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("parse CLI config file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+func (a *Agent) cliAppName() string {
+	if cfg := a.cfg.CLIConfig; cfg != nil && strings.TrimSpace(cfg.AppName) != "" {
+		return strings.TrimSpace(cfg.AppName)
+	}
+	return strings.TrimSpace(filepath.Base(os.Args[0]))
+}
+
 func buildCLIArgMap(inv cliInvocation) map[string]string {
 	args := make(map[string]string, len(inv.setValues)+3)
 	for k, v := range inv.setValues {
@@ -270,7 +407,7 @@ func buildCLIArgMap(inv cliInvocation) map[string]string {
 
 func isSupportedOutput(format string) bool {
 	switch strings.ToLower(format) {
-	case "json", "pretty", "yaml":
+	case "json", "jsonl", "pretty", "yaml":
 		return true
 	default:
 		return false
@@ -319,6 +456,10 @@ func parseJSONFromFile(path string) (map[string]any, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read input file: %w", err)
 	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return decodeYAMLInput(string(content))
+	}
 	return decodeJSONInput(string(content))
 }
 
@@ -330,11 +471,55 @@ func decodeJSONInput(raw string) (map[string]any, error) {
 
 	var parsed map[string]any
 	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		// Accept YAML documents that are not valid JSON, e.g. `name: Bob`.
+		if fromYAML, yamlErr := decodeYAMLInput(raw); yamlErr == nil && fromYAML != nil {
+			return fromYAML, nil
+		}
 		return nil, fmt.Errorf("parse JSON input: %w", err)
 	}
 	return parsed, nil
 }
 
+// decodeYAMLInput parses a YAML mapping into the same shape decodeJSONInput
+// produces, so numbers decode as float64 and nested maps as map[string]any.
+func decodeYAMLInput(raw string) (map[string]any, error) {
+	return decodeYAMLInputNumbers(raw, false)
+}
+
+// decodeYAMLInputNumbers is decodeYAMLInput with the option to return numbers as
+// json.Number, matching the JSON request path under WithPreciseNumbers.
+func decodeYAMLInputNumbers(raw string, preciseNumbers bool) (map[string]any, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	var doc any
+	if err := yaml.Unmarshal([]byte(raw), &doc); err != nil {
+		return nil, fmt.Errorf("parse YAML input: %w", err)
+	}
+	if doc == nil {
+		return nil, nil
+	}
+	if _, ok := doc.(map[string]any); !ok {
+		return nil, errors.New("parse YAML input: expected a mapping at the top level")
+	}
+
+	normalized, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("parse YAML input: %w", err)
+	}
+	var parsed map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	if preciseNumbers {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("parse YAML input: %w", err)
+	}
+	return parsed, nil
+}
+
 func mergeInput(stdin, file, flag map[string]any, setValues map[string]string) map[string]any {
 	merged := make(map[string]any)
 
@@ -383,6 +568,25 @@ func defaultFormatter(format string, useColor bool) func(context.Context, any, e
 				return
 			}
 			fmt.Println(string(data))
+		case "jsonl":
+			// Slices are emitted one element per line so the output can be piped
+			// into line-oriented tools; anything else becomes a single line.
+			items := []any{result}
+			if isListValue(result) {
+				value := reflect.ValueOf(result)
+				items = make([]any, value.Len())
+				for i := range items {
+					items[i] = value.Index(i).Interface()
+				}
+			}
+			for _, item := range items {
+				data, encErr := json.Marshal(item)
+				if encErr != nil {
+					fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", encErr)
+					return
+				}
+				fmt.Println(string(data))
+			}
 		case "pretty":
 			data, encErr := json.MarshalIndent(result, "", "  ")
 			if encErr != nil {
@@ -403,6 +607,37 @@ func defaultFormatter(format string, useColor bool) func(context.Context, any, e
 	}
 }
 
+func isListValue(v any) bool {
+	value := reflect.ValueOf(v)
+	kind := value.Kind()
+	if kind != reflect.Slice && kind != reflect.Array {
+		return false
+	}
+	// Byte slices such as json.RawMessage encode as a single value, not a list.
+	return value.Type().Elem().Kind() != reflect.Uint8
+}
+
+// quietFormatter wraps a formatter so that errors are reported as a single
+// JSON object on stderr instead of human-oriented text.
+func quietFormatter(next func(context.Context, any, error)) func(context.Context, any, error) {
+	return func(ctx context.Context, result any, err error) {
+		if err != nil {
+			printQuietError(err)
+			return
+		}
+		next(ctx, result, nil)
+	}
+}
+
+func printQuietError(err error) {
+	data, encErr := json.Marshal(map[string]string{"error": err.Error()})
+	if encErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, string(data))
+}
+
 func (a *Agent) printList(useColor bool) {
 	reasoners := make([]*Reasoner, 0, len(a.reasoners))
 	for _, r := range a.reasoners {
@@ -432,12 +667,103 @@ func (a *Agent) printList(useColor bool) {
 	}
 }
 
+// watchReasoner re-runs a reasoner every interval, clearing the screen between
+// iterations, until ctx is cancelled or the process is interrupted.
+func (a *Agent) watchReasoner(ctx context.Context, inv cliInvocation, reasonerName string, formatter func(context.Context, any, error)) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(inv.watch)
+	defer ticker.Stop()
+
+	for iteration := 0; ; iteration++ {
+		if iteration > 0 && !inv.quiet {
+			fmt.Print(ansiClearScreen)
+		}
+		result, execErr := a.Execute(ctx, reasonerName, inv.input)
+		if execErr != nil && ctx.Err() != nil {
+			// Interrupted mid-run; the error is an artefact of cancellation.
+			return nil
+		}
+		formatter(ctx, result, execErr)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseWatchInterval accepts Go durations ("5s", "1m") or a bare number of seconds.
+func parseWatchInterval(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	interval, err := time.ParseDuration(raw)
+	if err != nil {
+		seconds, convErr := strconv.ParseFloat(raw, 64)
+		if convErr != nil {
+			return 0, fmt.Errorf("invalid --watch interval %q", raw)
+		}
+		interval = time.Duration(seconds * float64(time.Second))
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("--watch interval must be positive, got %q", raw)
+	}
+	return interval, nil
+}
+
+// runSelfTest executes every CLI-enabled reasoner once with input derived from
+// the defaults declared in its input schema, reporting pass/fail and duration.
+func (a *Agent) runSelfTest(ctx context.Context, inv cliInvocation) error {
+	reasoners := make([]*Reasoner, 0, len(a.reasoners))
+	for _, r := range a.reasoners {
+		if r.CLIEnabled {
+			reasoners = append(reasoners, r)
+		}
+	}
+	sort.Slice(reasoners, func(i, j int) bool { return reasoners[i].Name < reasoners[j].Name })
+
+	failed := 0
+	for _, r := range reasoners {
+		runCtx := withCLIContext(ctx, cliContext{
+			args:         buildCLIArgMap(inv),
+			command:      "selftest",
+			outputFormat: inv.outputFormat,
+			useColor:     inv.useColor,
+		})
+
+		start := time.Now()
+		err := a.selfTestReasoner(runCtx, r)
+		elapsed := time.Since(start).Round(time.Millisecond)
+
+		if err != nil {
+			failed++
+			fmt.Printf("%s %s (%s): %v\n", colorText(inv.useColor, ansiRed, "FAIL"), r.Name, elapsed, err)
+			continue
+		}
+		fmt.Printf("%s %s (%s)\n", colorText(inv.useColor, ansiGreen, "PASS"), r.Name, elapsed)
+	}
+
+	fmt.Printf("\n%d passed, %d failed\n", len(reasoners)-failed, failed)
+	if failed > 0 {
+		return &CLIError{Code: 1, Err: fmt.Errorf("selftest: %d of %d reasoners failed", failed, len(reasoners))}
+	}
+	return nil
+}
+
+func (a *Agent) selfTestReasoner(ctx context.Context, r *Reasoner) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+	_, err = a.Execute(ctx, r.Name, schemaDefaultInput(r.InputSchema))
+	return err
+}
+
 func (a *Agent) printHelp(reasonerName string, useColor bool) {
 	cfg := a.cfg.CLIConfig
-	appName := strings.TrimSpace(filepath.Base(os.Args[0]))
-	if cfg != nil && strings.TrimSpace(cfg.AppName) != "" {
-		appName = strings.TrimSpace(cfg.AppName)
-	}
+	appName := a.cliAppName()
 	appDesc := ""
 	if cfg != nil {
 		appDesc = strings.TrimSpace(cfg.AppDescription)
@@ -462,6 +788,7 @@ func (a *Agent) printHelp(reasonerName string, useColor bool) {
 		fmt.Println(colorText(useColor, ansiBold, "Available Commands:"))
 		fmt.Println("  serve          Start agent server")
 		fmt.Println("  list           List available reasoners")
+		fmt.Println("  selftest       Run every reasoner once with default input")
 		fmt.Println("  help [command] Show help information")
 		fmt.Println("  version        Display version information")
 
@@ -503,9 +830,11 @@ func (a *Agent) printHelp(reasonerName string, useColor bool) {
 	fmt.Println(colorText(useColor, ansiBold, "Flags:"))
 	fmt.Println("  --set key=value   Set individual input parameters (repeatable)")
 	fmt.Println("  --input <json>    Provide input as JSON string")
-	fmt.Println("  --input-file <p>  Load input from JSON file")
-	fmt.Println("  --output <fmt>    Output format: json, pretty, yaml")
+	fmt.Println("  --input-file <p>  Load input from JSON or YAML (.yaml/.yml) file")
+	fmt.Println("  --output <fmt>    Output format: json, jsonl, pretty, yaml")
 	fmt.Println("  --no-color        Disable colorized output")
+	fmt.Println("  --watch <dur>     Re-run the reasoner every interval until interrupted")
+	fmt.Println("  -q, --quiet       Print only the result; errors are emitted as JSON on stderr")
 	fmt.Println("  --help            Show help information")
 
 	if cfg != nil && len(cfg.EnvironmentVars) > 0 {
@@ -2,12 +2,16 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -93,3 +97,269 @@ func TestRunCLI_ExecutesDefaultReasoner(t *testing.T) {
 	assert.Contains(t, stdout, "Hello, Bob")
 	assert.Equal(t, "", strings.TrimSpace(stderr))
 }
+
+func TestParseCLIArgs_YAMLInput(t *testing.T) {
+	a := newTestAgent(t)
+
+	jsonInput, err := decodeJSONInput(`{"name":"Bob","count":3,"tags":["a","b"],"nested":{"ok":true}}`)
+	require.NoError(t, err)
+
+	yamlFile := filepath.Join(t.TempDir(), "input.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte("name: Bob\ncount: 3\ntags: [a, b]\nnested:\n  ok: true\n"), 0o600))
+
+	inv, err := a.parseCLIArgs([]string{"--input-file", yamlFile})
+	require.NoError(t, err)
+	assert.Equal(t, jsonInput, inv.input)
+
+	inv, err = a.parseCLIArgs([]string{"--input", "name: Bob\ncount: 3\ntags: [a, b]\nnested: {ok: true}"})
+	require.NoError(t, err)
+	assert.Equal(t, jsonInput, inv.input)
+}
+
+func TestParseCLIArgs_InvalidInput(t *testing.T) {
+	a := newTestAgent(t)
+
+	_, err := a.parseCLIArgs([]string{"--input", `{"name":`})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parse JSON input")
+}
+
+func TestRunCLI_SelfTest(t *testing.T) {
+	a := newTestAgent(t)
+
+	a.RegisterReasoner("greet", func(ctx context.Context, input map[string]any) (any, error) {
+		assert.True(t, IsCLIMode(ctx))
+		assert.Equal(t, "World", input["name"])
+		return "ok", nil
+	}, WithCLI(), WithInputSchema(json.RawMessage(`{"type":"object","properties":{"name":{"type":"string","default":"World"}}}`)))
+	a.RegisterReasoner("broken", func(ctx context.Context, input map[string]any) (any, error) {
+		return nil, errors.New("boom")
+	}, WithCLI())
+	a.RegisterReasoner("hidden", func(ctx context.Context, input map[string]any) (any, error) {
+		t.Fatal("non-CLI reasoner should not run")
+		return nil, nil
+	})
+
+	stdout, _, err := captureOutput(t, func() error {
+		return a.runCLI(context.Background(), []string{"selftest", "--no-color"})
+	})
+
+	require.Error(t, err)
+	var cliErr *CLIError
+	require.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, 1, cliErr.ExitCode())
+	assert.Contains(t, stdout, "PASS greet")
+	assert.Contains(t, stdout, "FAIL broken")
+	assert.Contains(t, stdout, "boom")
+	assert.Contains(t, stdout, "1 passed, 1 failed")
+	assert.NotContains(t, stdout, "hidden")
+}
+
+func TestDefaultFormatter_JSONLines(t *testing.T) {
+	format := defaultFormatter("jsonl", false)
+
+	stdout, _, _ := captureOutput(t, func() error {
+		format(context.Background(), []map[string]any{{"id": 1}, {"id": 2}}, nil)
+		return nil
+	})
+	assert.Equal(t, "{\"id\":1}\n{\"id\":2}\n", stdout)
+
+	stdout, _, _ = captureOutput(t, func() error {
+		format(context.Background(), map[string]any{"ok": true, "count": 3}, nil)
+		return nil
+	})
+	assert.Equal(t, "{\"count\":3,\"ok\":true}\n", stdout)
+
+	stdout, _, _ = captureOutput(t, func() error {
+		format(context.Background(), "done", nil)
+		return nil
+	})
+	assert.Equal(t, "\"done\"\n", stdout)
+
+	stdout, _, _ = captureOutput(t, func() error {
+		format(context.Background(), json.RawMessage(`{"raw":true}`), nil)
+		return nil
+	})
+	assert.Equal(t, "{\"raw\":true}\n", stdout)
+
+	type blob []byte
+	stdout, _, _ = captureOutput(t, func() error {
+		format(context.Background(), blob("hi"), nil)
+		return nil
+	})
+	assert.Equal(t, "\"aGk=\"\n", stdout)
+}
+
+func TestParseCLIArgs_AcceptsJSONLines(t *testing.T) {
+	a := newTestAgent(t)
+
+	inv, err := a.parseCLIArgs([]string{"--output", "jsonl"})
+	require.NoError(t, err)
+	assert.Equal(t, "jsonl", inv.outputFormat)
+}
+
+func TestRunCLI_QuietMode(t *testing.T) {
+	a := newTestAgent(t)
+
+	a.RegisterReasoner("greet", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"greeting": fmt.Sprintf("Hello, %s", input["name"])}, nil
+	}, WithCLI(), WithDefaultCLI())
+	a.RegisterReasoner("fail", func(ctx context.Context, input map[string]any) (any, error) {
+		return nil, errors.New("boom")
+	}, WithCLI())
+
+	stdout, stderr, err := captureOutput(t, func() error {
+		return a.runCLI(context.Background(), []string{"--quiet", "--set", "name=Bob", "--output", "json"})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "{\"greeting\":\"Hello, Bob\"}\n", stdout)
+	assert.Empty(t, stderr)
+
+	stdout, stderr, err = captureOutput(t, func() error {
+		return a.runCLI(context.Background(), []string{"fail", "-q", "--output", "json"})
+	})
+	require.Error(t, err)
+	assert.Empty(t, stdout)
+	assert.JSONEq(t, `{"error":"boom"}`, stderr)
+
+	stdout, stderr, err = captureOutput(t, func() error {
+		return a.runCLI(context.Background(), []string{"--quiet", "--bogus"})
+	})
+	require.Error(t, err)
+	assert.Empty(t, stdout)
+	assert.JSONEq(t, `{"error":"unknown flag --bogus"}`, stderr)
+}
+
+func TestParseCLIArgs_ConfigFileDefaults(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("output: yaml\ncolor: false\ndefault_reasoner: greet\n"), 0o600))
+
+	a, err := New(Config{
+		NodeID:    "node-1",
+		Version:   "1.0.0",
+		Logger:    log.New(io.Discard, "", 0),
+		CLIConfig: &CLIConfig{DefaultOutputFormat: "json", ConfigFilePath: configPath},
+	})
+	require.NoError(t, err)
+
+	inv, err := a.parseCLIArgs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "yaml", inv.outputFormat)
+	assert.False(t, inv.useColor)
+	assert.Equal(t, "greet", inv.defaultReasoner)
+
+	inv, err = a.parseCLIArgs([]string{"--output", "pretty"})
+	require.NoError(t, err)
+	assert.Equal(t, "pretty", inv.outputFormat)
+
+	require.NoError(t, os.WriteFile(configPath, []byte("color: true\n"), 0o600))
+	inv, err = a.parseCLIArgs([]string{"--no-color"})
+	require.NoError(t, err)
+	assert.False(t, inv.useColor)
+	assert.Equal(t, "json", inv.outputFormat)
+}
+
+func TestRunCLI_ConfigFileDefaultReasoner(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("output: json\ndefault_reasoner: farewell\n"), 0o600))
+
+	a, err := New(Config{
+		NodeID:    "node-1",
+		Version:   "1.0.0",
+		Logger:    log.New(io.Discard, "", 0),
+		CLIConfig: &CLIConfig{ConfigFilePath: configPath},
+	})
+	require.NoError(t, err)
+
+	a.RegisterReasoner("greet", func(ctx context.Context, input map[string]any) (any, error) {
+		return "hello", nil
+	}, WithCLI(), WithDefaultCLI())
+	a.RegisterReasoner("farewell", func(ctx context.Context, input map[string]any) (any, error) {
+		return "goodbye", nil
+	}, WithCLI())
+
+	stdout, _, err := captureOutput(t, func() error {
+		return a.runCLI(context.Background(), nil)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "\"goodbye\"\n", stdout)
+
+	stdout, _, err = captureOutput(t, func() error {
+		return a.runCLI(context.Background(), []string{"greet"})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "\"hello\"\n", stdout)
+}
+
+func TestRunCLI_WatchRerunsUntilCancelled(t *testing.T) {
+	a := newTestAgent(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	a.RegisterReasoner("tick", func(ctx context.Context, input map[string]any) (any, error) {
+		calls++
+		if calls == 2 {
+			defer cancel()
+		}
+		return map[string]any{"tick": calls}, nil
+	}, WithCLI(), WithDefaultCLI())
+
+	stdout, _, err := captureOutput(t, func() error {
+		return a.runCLI(ctx, []string{"--watch", "10ms", "--output", "json"})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, strings.Count(stdout, ansiClearScreen))
+	assert.Contains(t, stdout, `{"tick":1}`)
+	assert.Contains(t, stdout, `{"tick":2}`)
+}
+
+func TestParseWatchInterval(t *testing.T) {
+	interval, err := parseWatchInterval("2s")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, interval)
+
+	interval, err = parseWatchInterval("0.5")
+	require.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, interval)
+
+	_, err = parseWatchInterval("soon")
+	require.Error(t, err)
+	_, err = parseWatchInterval("0")
+	require.Error(t, err)
+}
+
+func TestRunCLI_NoExitOnError(t *testing.T) {
+	errBoom := errors.New("boom")
+	register := func(a *Agent) {
+		a.RegisterReasoner("fail", func(ctx context.Context, input map[string]any) (any, error) {
+			return nil, errBoom
+		}, WithCLI(), WithDefaultCLI())
+	}
+
+	a := newTestAgent(t)
+	register(a)
+	_, _, err := captureOutput(t, func() error {
+		return a.runCLI(context.Background(), nil)
+	})
+	var cliErr *CLIError
+	require.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, 1, cliErr.Code)
+	assert.ErrorIs(t, err, errBoom)
+
+	a, err = New(Config{
+		NodeID:    "node-1",
+		Version:   "1.0.0",
+		Logger:    log.New(io.Discard, "", 0),
+		CLIConfig: &CLIConfig{NoExitOnError: true},
+	})
+	require.NoError(t, err)
+	register(a)
+	_, _, err = captureOutput(t, func() error {
+		return a.runCLI(context.Background(), nil)
+	})
+	assert.Same(t, errBoom, err)
+	assert.False(t, errors.As(err, &cliErr))
+}
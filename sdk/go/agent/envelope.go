@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"net/http"
+	"time"
+)
+
+// WithResponseEnvelope wraps the reasoner's synchronous HTTP responses in a consistent
+// envelope: {"data": result, "meta": {...}} on success and {"error": {"message": ...},
+// "meta": {...}} on failure. Config.ResponseEnvelope enables it for every reasoner.
+func WithResponseEnvelope() ReasonerOption {
+	return func(r *Reasoner) {
+		r.ResponseEnvelope = true
+	}
+}
+
+// responseEnvelope reports whether responses from reasoner are enveloped.
+func (a *Agent) responseEnvelope(reasoner *Reasoner) bool {
+	return a.cfg.ResponseEnvelope || reasoner.ResponseEnvelope
+}
+
+// envelopeMeta describes the invocation that produced an enveloped response.
+func envelopeMeta(execCtx ExecutionContext, start time.Time) map[string]any {
+	return map[string]any{
+		"execution_id": execCtx.ExecutionID,
+		"duration_ms":  time.Since(start).Milliseconds(),
+	}
+}
+
+// successEnvelope wraps a reasoner result.
+func successEnvelope(result any, execCtx ExecutionContext, start time.Time) map[string]any {
+	return map[string]any{
+		"data": result,
+		"meta": envelopeMeta(execCtx, start),
+	}
+}
+
+// errorEnvelope wraps a reasoner error.
+func errorEnvelope(err error, execCtx ExecutionContext, start time.Time) map[string]any {
+	return map[string]any{
+		"error": map[string]any{"message": err.Error()},
+		"meta":  envelopeMeta(execCtx, start),
+	}
+}
+
+// errorPayload is the body reporting err for reasoner: an error envelope when enabled,
+// otherwise {"error": message}.
+func (a *Agent) errorPayload(reasoner *Reasoner, err error, execCtx ExecutionContext, start time.Time) map[string]any {
+	if a.responseEnvelope(reasoner) {
+		return errorEnvelope(err, execCtx, start)
+	}
+	return map[string]any{"error": err.Error()}
+}
+
+// writeInputError rejects a request whose input could not be decoded or validated,
+// using the reasoner's error envelope when enabled so callers see a single error shape.
+func (a *Agent) writeInputError(w http.ResponseWriter, r *http.Request, reasoner *Reasoner, err error) {
+	if !a.responseEnvelope(reasoner) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	execCtx := ExecutionContext{ExecutionID: r.Header.Get("X-Execution-ID")}
+	writeResponse(w, r, http.StatusBadRequest, errorEnvelope(err, execCtx, time.Now()))
+}
@@ -0,0 +1,208 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postReasoner(t *testing.T, url string) (int, map[string]any) {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestResponseEnvelope(t *testing.T) {
+	agent, err := New(Config{
+		NodeID:  "node-1",
+		Version: "1.0.0",
+		Logger:  log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	ok := func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"greeting": "hi"}, nil
+	}
+	agent.RegisterReasoner("wrapped", ok, WithResponseEnvelope())
+	agent.RegisterReasoner("failing", func(ctx context.Context, input map[string]any) (any, error) {
+		return nil, errors.New("boom")
+	}, WithResponseEnvelope())
+	agent.RegisterReasoner("bare", ok)
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	status, body := postReasoner(t, server.URL+"/reasoners/wrapped")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]any{"greeting": "hi"}, body["data"])
+	require.Contains(t, body, "meta")
+	meta := body["meta"].(map[string]any)
+	assert.Contains(t, meta, "execution_id")
+	assert.Contains(t, meta, "duration_ms")
+	assert.NotContains(t, body, "error")
+
+	status, body = postReasoner(t, server.URL+"/reasoners/failing")
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, map[string]any{"message": "boom"}, body["error"])
+	assert.Contains(t, body, "meta")
+	assert.NotContains(t, body, "data")
+
+	status, body = postReasoner(t, server.URL+"/reasoners/bare")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]any{"greeting": "hi"}, body)
+}
+
+func TestResponseEnvelope_Config(t *testing.T) {
+	agent, err := New(Config{
+		NodeID:           "node-1",
+		Version:          "1.0.0",
+		Logger:           log.New(io.Discard, "", 0),
+		ResponseEnvelope: true,
+	})
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("echo", func(ctx context.Context, input map[string]any) (any, error) {
+		return "done", nil
+	})
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	status, body := postReasoner(t, server.URL+"/reasoners/echo")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "done", body["data"])
+	assert.Contains(t, body, "meta")
+}
+
+func TestResponseEnvelope_InputErrors(t *testing.T) {
+	agent, err := New(Config{
+		NodeID:  "node-1",
+		Version: "1.0.0",
+		Logger:  log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	ok := func(ctx context.Context, input map[string]any) (any, error) {
+		return "done", nil
+	}
+	agent.RegisterReasoner("wrapped", ok, WithResponseEnvelope())
+	agent.RegisterReasoner("strict", ok, WithResponseEnvelope(), WithInputTransform(func(input map[string]any) (map[string]any, error) {
+		return nil, errors.New("name is required")
+	}))
+	agent.RegisterReasoner("bare", ok)
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	post := func(path, body string) (int, string) {
+		req, err := http.NewRequest(http.MethodPost, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Execution-ID", "exec-1")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		raw, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(raw)
+	}
+
+	status, raw := post("/reasoners/wrapped", `{not json`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(raw), &body))
+	require.Contains(t, body, "error")
+	assert.Contains(t, body["error"].(map[string]any), "message")
+	assert.Equal(t, "exec-1", body["meta"].(map[string]any)["execution_id"])
+	assert.NotContains(t, body, "data")
+
+	status, raw = post("/reasoners/strict", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	body = nil
+	require.NoError(t, json.Unmarshal([]byte(raw), &body))
+	assert.Equal(t, map[string]any{"message": "name is required"}, body["error"])
+	assert.Contains(t, body, "meta")
+
+	// Reasoners without the envelope keep the plain-text error.
+	status, raw = post("/reasoners/bare", `{not json`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.False(t, json.Valid([]byte(raw)))
+}
+
+func TestResponseEnvelope_ExecuteAndServerless(t *testing.T) {
+	agent, err := New(Config{
+		NodeID:  "node-1",
+		Version: "1.0.0",
+		Logger:  log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("wrapped", func(ctx context.Context, input map[string]any) (any, error) {
+		return "done", nil
+	}, WithResponseEnvelope())
+	agent.RegisterReasoner("failing", func(ctx context.Context, input map[string]any) (any, error) {
+		return nil, errors.New("boom")
+	}, WithResponseEnvelope())
+	agent.RegisterReasoner("strict", func(ctx context.Context, input map[string]any) (any, error) {
+		return "done", nil
+	}, WithResponseEnvelope(), WithInputTransform(func(input map[string]any) (map[string]any, error) {
+		return nil, errors.New("name is required")
+	}))
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	status, body := postReasoner(t, server.URL+"/execute/wrapped")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "done", body["data"])
+	assert.Contains(t, body, "meta")
+
+	status, body = postReasoner(t, server.URL+"/execute/failing")
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, map[string]any{"message": "boom"}, body["error"])
+	assert.Contains(t, body, "meta")
+
+	status, body = postReasoner(t, server.URL+"/execute/strict")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, map[string]any{"message": "name is required"}, body["error"])
+	assert.Contains(t, body, "meta")
+
+	event := func(reasoner string) map[string]any {
+		return map[string]any{
+			"reasoner":          reasoner,
+			"input":             map[string]any{},
+			"execution_context": map[string]any{"execution_id": "exec-1"},
+		}
+	}
+
+	result, status, err := agent.HandleServerlessEvent(context.Background(), event("wrapped"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "done", result["data"])
+	assert.Equal(t, "exec-1", result["meta"].(map[string]any)["execution_id"])
+
+	result, status, err = agent.HandleServerlessEvent(context.Background(), event("failing"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, map[string]any{"message": "boom"}, result["error"])
+
+	result, status, err = agent.HandleServerlessEvent(context.Background(), event("strict"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, map[string]any{"message": "name is required"}, result["error"])
+	assert.Contains(t, result, "meta")
+}
@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// eventPayload represents the JSON payload of a custom event sent to the AgentField server.
+type eventPayload struct {
+	EventType string         `json:"event_type"`
+	Data      map[string]any `json:"data,omitempty"`
+}
+
+// EmitEvent publishes a custom domain event, such as "approval_requested", to the
+// AgentField server, which forwards it through the observability pipeline alongside
+// execution and node events. The execution context carried by ctx is attached to the
+// event.
+//
+// Unlike Note, EmitEvent waits for the server to accept the event and returns an error
+// if it does not.
+//
+// Example usage:
+//
+//	err := agent.EmitEvent(ctx, "approval_requested", map[string]any{"amount": 1200})
+func (a *Agent) EmitEvent(ctx context.Context, eventType string, data map[string]any) error {
+	baseURL := strings.TrimSpace(a.cfg.AgentFieldURL)
+	if baseURL == "" {
+		return errors.New("AgentFieldURL is required to emit events")
+	}
+	if strings.TrimSpace(eventType) == "" {
+		return errors.New("event type is required")
+	}
+
+	body, err := json.Marshal(eventPayload{EventType: eventType, Data: data})
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	url := strings.TrimSuffix(baseURL, "/") + "/api/v1/events"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if a.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.cfg.Token)
+	}
+
+	execCtx := ExecutionContextFrom(ctx)
+	if execCtx.RunID != "" {
+		req.Header.Set("X-Run-ID", execCtx.RunID)
+	}
+	if execCtx.ExecutionID != "" {
+		req.Header.Set("X-Execution-ID", execCtx.ExecutionID)
+	}
+	if execCtx.WorkflowID != "" {
+		req.Header.Set("X-Workflow-ID", execCtx.WorkflowID)
+	}
+	req.Header.Set("X-Agent-Node-ID", a.cfg.NodeID)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("server returned %d: %s", resp.StatusCode, strings.TrimSpace(string(bodyBytes)))
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitEvent(t *testing.T) {
+	var (
+		receivedPath    string
+		receivedPayload eventPayload
+		receivedHeaders http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedPath = r.URL.Path
+		receivedHeaders = r.Header.Clone()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&receivedPayload))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	a, err := New(Config{
+		NodeID:        "billing-agent",
+		Version:       "1.0.0",
+		AgentFieldURL: server.URL,
+		Token:         "secret-token",
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	ctx := contextWithExecution(context.Background(), ExecutionContext{
+		RunID:       "run-1",
+		ExecutionID: "exec-1",
+		WorkflowID:  "wf-1",
+	})
+	err = a.EmitEvent(ctx, "approval_requested", map[string]any{"amount": 1200.0})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api/v1/events", receivedPath)
+	assert.Equal(t, "approval_requested", receivedPayload.EventType)
+	assert.Equal(t, map[string]any{"amount": 1200.0}, receivedPayload.Data)
+	assert.Equal(t, "Bearer secret-token", receivedHeaders.Get("Authorization"))
+	assert.Equal(t, "billing-agent", receivedHeaders.Get("X-Agent-Node-ID"))
+	assert.Equal(t, "exec-1", receivedHeaders.Get("X-Execution-ID"))
+	assert.Equal(t, "run-1", receivedHeaders.Get("X-Run-ID"))
+	assert.Equal(t, "wf-1", receivedHeaders.Get("X-Workflow-ID"))
+}
+
+func TestEmitEvent_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid event_type"}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	a, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: server.URL,
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	require.NoError(t, err)
+
+	err = a.EmitEvent(context.Background(), "bad type", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+
+	err = a.EmitEvent(context.Background(), " ", nil)
+	require.Error(t, err)
+
+	offline, err := New(Config{NodeID: "node-1", Version: "1.0.0", Logger: log.New(io.Discard, "", 0)})
+	require.NoError(t, err)
+	require.Error(t, offline.EmitEvent(context.Background(), "approval_requested", nil))
+}
@@ -0,0 +1,98 @@
+package agent
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Middleware wraps the handler of the named reasoner or skill. It can inspect or alter the
+// input, the result, and the error, and is applied once at registration time.
+type Middleware func(name string, next HandlerFunc) HandlerFunc
+
+// WithMiddleware wraps a single reasoner or skill in the given middleware. They run inside
+// any middleware configured on Config.Middleware.
+func WithMiddleware(mw ...Middleware) ReasonerOption {
+	return func(r *Reasoner) {
+		r.Middleware = append(r.Middleware, mw...)
+	}
+}
+
+// chainMiddleware wraps handler so that the first middleware is the outermost.
+func chainMiddleware(name string, handler HandlerFunc, middleware ...[]Middleware) HandlerFunc {
+	var all []Middleware
+	for _, mw := range middleware {
+		all = append(all, mw...)
+	}
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i] != nil {
+			handler = all[i](name, handler)
+		}
+	}
+	return handler
+}
+
+// LoggingConfig controls LoggingMiddleware.
+type LoggingConfig struct {
+	// Logger receives the log lines. Defaults to the standard library logger.
+	Logger *log.Logger
+	// SampleRate is the fraction of invocations that are logged, between 0 and 1.
+	// Values outside (0, 1] log every invocation.
+	SampleRate float64
+	// RedactKeys lists top-level input keys, matched case-insensitively, that are left
+	// out of the logged key list.
+	RedactKeys []string
+}
+
+// LoggingMiddleware logs the reasoner name, input keys, outcome, and duration of a
+// sampled share of invocations. Input values are never logged.
+func LoggingMiddleware(cfg LoggingConfig) Middleware {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+	rate := cfg.SampleRate
+	if rate <= 0 || rate > 1 {
+		rate = 1
+	}
+	redact := make(map[string]struct{}, len(cfg.RedactKeys))
+	for _, key := range cfg.RedactKeys {
+		redact[strings.ToLower(key)] = struct{}{}
+	}
+
+	return func(name string, next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, input map[string]any) (any, error) {
+			if rate < 1 && rand.Float64() >= rate {
+				return next(ctx, input)
+			}
+
+			keys := inputKeys(input, redact)
+			start := time.Now()
+			result, err := next(ctx, input)
+			duration := time.Since(start)
+
+			if err != nil {
+				logger.Printf("reasoner %s failed in %s input_keys=%v error=%v", name, duration, keys, err)
+			} else {
+				logger.Printf("reasoner %s succeeded in %s input_keys=%v", name, duration, keys)
+			}
+			return result, err
+		}
+	}
+}
+
+// inputKeys returns the sorted top-level keys of input, without the redacted ones.
+func inputKeys(input map[string]any, redact map[string]struct{}) []string {
+	keys := make([]string, 0, len(input))
+	for key := range input {
+		if _, ok := redact[strings.ToLower(key)]; ok {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggingMiddleware_LogsSampledRequestWithRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+		Middleware: []Middleware{LoggingMiddleware(LoggingConfig{
+			Logger:     log.New(&buf, "", 0),
+			SampleRate: 1,
+			RedactKeys: []string{"api_key", "Password"},
+		})},
+	})
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("greet", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"message": "hi"}, nil
+	})
+	agent.RegisterReasoner("fail", func(ctx context.Context, input map[string]any) (any, error) {
+		return nil, errors.New("boom")
+	})
+
+	input := map[string]any{
+		"name":    "Ada",
+		"api_key": "sk-secret",
+		"auth":    map[string]any{"password": "hunter2", "user": "ada"},
+	}
+	result, err := agent.Execute(context.Background(), "greet", input)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"message": "hi"}, result)
+
+	logged := buf.String()
+	assert.Contains(t, logged, "reasoner greet succeeded")
+	assert.Contains(t, logged, "input_keys=[auth name]")
+	assert.NotContains(t, logged, "api_key")
+	assert.NotContains(t, logged, "Ada")
+	assert.NotContains(t, logged, "sk-secret")
+	assert.NotContains(t, logged, "hunter2")
+	assert.Equal(t, "sk-secret", input["api_key"], "caller input must not be modified")
+
+	buf.Reset()
+	_, err = agent.Execute(context.Background(), "fail", map[string]any{"query": "private"})
+	require.Error(t, err)
+	assert.Contains(t, buf.String(), "reasoner fail failed")
+	assert.Contains(t, buf.String(), "input_keys=[query]")
+	assert.Contains(t, buf.String(), "error=boom")
+	assert.NotContains(t, buf.String(), "private")
+}
+
+func TestLoggingMiddleware_SkipsUnsampledRequests(t *testing.T) {
+	var buf bytes.Buffer
+	mw := LoggingMiddleware(LoggingConfig{Logger: log.New(&buf, "", 0), SampleRate: 1e-12})
+	handler := mw("quiet", func(ctx context.Context, input map[string]any) (any, error) {
+		return "ok", nil
+	})
+
+	for i := 0; i < 10; i++ {
+		result, err := handler(context.Background(), map[string]any{})
+		require.NoError(t, err)
+		assert.Equal(t, "ok", result)
+	}
+	assert.Empty(t, buf.String())
+}
+
+func TestChainMiddleware_Order(t *testing.T) {
+	var calls []string
+	trace := func(label string) Middleware {
+		return func(name string, next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, input map[string]any) (any, error) {
+				calls = append(calls, label+":"+name)
+				return next(ctx, input)
+			}
+		}
+	}
+
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(io.Discard, "", 0),
+		Middleware:    []Middleware{trace("global")},
+	})
+	require.NoError(t, err)
+
+	agent.RegisterReasoner("work", func(ctx context.Context, input map[string]any) (any, error) {
+		calls = append(calls, "handler")
+		return nil, nil
+	}, WithMiddleware(trace("local")))
+
+	_, err = agent.Execute(context.Background(), "work", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"global:work", "local:work", "handler"}, calls)
+}
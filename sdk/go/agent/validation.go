@@ -0,0 +1,201 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithValidation checks each result of the reasoner against its OutputSchema. A mismatch
+// is logged and the result is still returned; use WithStrictValidation to fail instead.
+func WithValidation() ReasonerOption {
+	return func(r *Reasoner) {
+		r.ValidateOutput = true
+	}
+}
+
+// WithStrictValidation checks each result against OutputSchema like WithValidation, but a
+// mismatch turns the invocation into an error.
+func WithStrictValidation() ReasonerOption {
+	return func(r *Reasoner) {
+		r.ValidateOutput = true
+		r.StrictValidation = true
+	}
+}
+
+// withOutputValidation wraps the reasoner's handler so its results are checked against
+// OutputSchema. It is a no-op unless validation was requested.
+func (a *Agent) withOutputValidation(reasoner *Reasoner, handler HandlerFunc) HandlerFunc {
+	if !reasoner.ValidateOutput {
+		return handler
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(reasoner.OutputSchema, &schema); err != nil {
+		a.logger.Printf("warn: output schema of %s is not valid JSON, skipping validation: %v", reasoner.Name, err)
+		return handler
+	}
+
+	return func(ctx context.Context, input map[string]any) (any, error) {
+		result, err := handler(ctx, input)
+		if err != nil {
+			return result, err
+		}
+		if verr := validateResult(schema, result); verr != nil {
+			if reasoner.StrictValidation {
+				return nil, fmt.Errorf("output of %s does not match its schema: %w", reasoner.Name, verr)
+			}
+			a.logger.Printf("warn: output of %s does not match its schema: %v", reasoner.Name, verr)
+		}
+		return result, nil
+	}
+}
+
+// validateResult normalizes result through JSON, as it would be sent over the wire, and
+// validates it against schema.
+func validateResult(schema map[string]any, result any) error {
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("encode result: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return fmt.Errorf("decode result: %w", err)
+	}
+	return validateAgainstSchema(schema, value, "$")
+}
+
+// validateAgainstSchema checks value against the subset of JSON Schema used for reasoner
+// contracts: type, enum, properties, required, additionalProperties (as a boolean), and
+// items. Other keywords are ignored.
+func validateAgainstSchema(schema map[string]any, value any, path string) error {
+	if wantTypes := schemaTypes(schema["type"]); len(wantTypes) > 0 {
+		matched := false
+		for _, t := range wantTypes {
+			if matchesSchemaType(t, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(wantTypes, " or "), jsonTypeName(value))
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		allowed := false
+		for _, candidate := range enum {
+			if reflect.DeepEqual(candidate, value) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%s: value is not one of the allowed values", path)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		if required, ok := schema["required"].([]any); ok {
+			for _, name := range required {
+				key, _ := name.(string)
+				if _, present := v[key]; key != "" && !present {
+					return fmt.Errorf("%s: missing required property %q", path, key)
+				}
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			propSchema, declared := properties[key].(map[string]any)
+			if !declared {
+				if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+					return fmt.Errorf("%s: unexpected property %q", path, key)
+				}
+				continue
+			}
+			if err := validateAgainstSchema(propSchema, v[key], path+"."+key); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				if err := validateAgainstSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// schemaTypes returns the declared type(s); "type" may be a string or a list of strings.
+func schemaTypes(raw any) []string {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		names := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				names = append(names, s)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+func matchesSchemaType(schemaType string, value any) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	// Unknown types are not enforced.
+	return true
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,145 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var summaryOutputSchema = json.RawMessage(`{
+	"type": "object",
+	"properties": {
+		"summary": {"type": "string"},
+		"score": {"type": "number"},
+		"tags": {"type": "array", "items": {"type": "string"}}
+	},
+	"required": ["summary"]
+}`)
+
+func newValidationTestAgent(t *testing.T, logs io.Writer) *Agent {
+	t.Helper()
+	agent, err := New(Config{
+		NodeID:        "node-1",
+		Version:       "1.0.0",
+		AgentFieldURL: "https://api.example.com",
+		Logger:        log.New(logs, "", 0),
+	})
+	require.NoError(t, err)
+	return agent
+}
+
+func TestOutputValidation_WarnMode(t *testing.T) {
+	var logs bytes.Buffer
+	agent := newValidationTestAgent(t, &logs)
+
+	agent.RegisterReasoner("good", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"summary": "ok", "score": 0.9, "tags": []string{"a"}}, nil
+	}, WithOutputSchema(summaryOutputSchema), WithValidation())
+	agent.RegisterReasoner("bad", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"score": "high"}, nil
+	}, WithOutputSchema(summaryOutputSchema), WithValidation())
+
+	result, err := agent.Execute(context.Background(), "good", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result.(map[string]any)["summary"])
+	assert.Empty(t, logs.String())
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/reasoners/bad", "application/json", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "high", body["score"])
+	assert.Contains(t, logs.String(), "output of bad does not match its schema")
+}
+
+func TestOutputValidation_StrictMode(t *testing.T) {
+	var logs bytes.Buffer
+	agent := newValidationTestAgent(t, &logs)
+
+	agent.RegisterReasoner("good", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"summary": "ok"}, nil
+	}, WithOutputSchema(summaryOutputSchema), WithStrictValidation())
+	agent.RegisterReasoner("bad", func(ctx context.Context, input map[string]any) (any, error) {
+		return map[string]any{"summary": "ok", "tags": []any{"a", 2}}, nil
+	}, WithOutputSchema(summaryOutputSchema), WithStrictValidation())
+
+	_, err := agent.Execute(context.Background(), "good", nil)
+	require.NoError(t, err)
+
+	_, err = agent.Execute(context.Background(), "bad", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "$.tags[1]: expected string, got number")
+
+	server := httptest.NewServer(agent.handler())
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/reasoners/bad", "application/json", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestOutputValidation_DisabledByDefault(t *testing.T) {
+	var logs bytes.Buffer
+	agent := newValidationTestAgent(t, &logs)
+
+	agent.RegisterReasoner("bad", func(ctx context.Context, input map[string]any) (any, error) {
+		return "not an object", nil
+	}, WithOutputSchema(summaryOutputSchema))
+
+	result, err := agent.Execute(context.Background(), "bad", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "not an object", result)
+	assert.Empty(t, logs.String())
+}
+
+func TestValidateAgainstSchema(t *testing.T) {
+	schema := map[string]any{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"count": map[string]any{"type": "integer"},
+			"mode":  map[string]any{"enum": []any{"fast", "slow"}},
+			"note":  map[string]any{"type": []any{"string", "null"}},
+		},
+		"required": []any{"count"},
+	}
+
+	tests := []struct {
+		name    string
+		value   any
+		wantErr string
+	}{
+		{name: "valid", value: map[string]any{"count": float64(3), "mode": "fast", "note": nil}},
+		{name: "missing required", value: map[string]any{}, wantErr: `missing required property "count"`},
+		{name: "non-integer", value: map[string]any{"count": 1.5}, wantErr: "$.count: expected integer"},
+		{name: "enum", value: map[string]any{"count": float64(1), "mode": "medium"}, wantErr: "$.mode: value is not one of the allowed values"},
+		{name: "additional property", value: map[string]any{"count": float64(1), "extra": true}, wantErr: `unexpected property "extra"`},
+		{name: "wrong root type", value: []any{}, wantErr: "$: expected object, got array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAgainstSchema(schema, tt.value, "$")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
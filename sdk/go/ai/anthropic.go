@@ -0,0 +1,194 @@
+package ai
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// anthropicVersion is the Anthropic API version the request and response types follow.
+const anthropicVersion = "2023-06-01"
+
+// anthropicRequest is the body of a POST /messages call.
+type anthropicRequest struct {
+	Model       string    `json:"model"`
+	System      string    `json:"system,omitempty"`
+	Messages    []Message `json:"messages"`
+	MaxTokens   int       `json:"max_tokens"`
+	Temperature *float64  `json:"temperature,omitempty"`
+	Stream      bool      `json:"stream,omitempty"`
+}
+
+// newAnthropicRequest converts req to the messages format. System messages move to the
+// top-level system field, since the messages list only accepts user and assistant turns.
+func newAnthropicRequest(req *Request) (*anthropicRequest, error) {
+	if req.ResponseFormat != nil {
+		return nil, fmt.Errorf("response format is not supported by the %s provider", ProviderAnthropic)
+	}
+
+	out := &anthropicRequest{
+		Model:       req.Model,
+		Temperature: req.Temperature,
+		Stream:      req.Stream,
+	}
+	if req.MaxTokens != nil {
+		out.MaxTokens = *req.MaxTokens
+	}
+
+	var system []string
+	for _, msg := range req.Messages {
+		if msg.Role == "system" {
+			system = append(system, msg.Content)
+			continue
+		}
+		out.Messages = append(out.Messages, msg)
+	}
+	out.System = strings.Join(system, "\n\n")
+	return out, nil
+}
+
+// anthropicContentBlock is one entry of a message's content list.
+type anthropicContentBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+// anthropicUsage reports token counts for a message.
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// anthropicResponse is the body returned by a non-streaming /messages call.
+type anthropicResponse struct {
+	ID         string                  `json:"id"`
+	Type       string                  `json:"type"`
+	Role       string                  `json:"role"`
+	Model      string                  `json:"model"`
+	Content    []anthropicContentBlock `json:"content"`
+	StopReason string                  `json:"stop_reason"`
+	Usage      *anthropicUsage         `json:"usage,omitempty"`
+}
+
+// toResponse maps the message onto the OpenAI-shaped Response, joining its text blocks
+// into a single choice.
+func (r *anthropicResponse) toResponse() *Response {
+	var text strings.Builder
+	for _, block := range r.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+
+	resp := &Response{
+		ID:     r.ID,
+		Object: r.Type,
+		Model:  r.Model,
+		Choices: []Choice{{
+			Message:      Message{Role: "assistant", Content: text.String()},
+			FinishReason: r.StopReason,
+		}},
+	}
+	if r.Usage != nil {
+		resp.Usage = &Usage{
+			PromptTokens:     r.Usage.InputTokens,
+			CompletionTokens: r.Usage.OutputTokens,
+			TotalTokens:      r.Usage.InputTokens + r.Usage.OutputTokens,
+		}
+	}
+	return resp
+}
+
+// anthropicStreamEvent is the data payload of a streaming /messages event.
+type anthropicStreamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		ID    string `json:"id"`
+		Model string `json:"model"`
+	} `json:"message"`
+	Delta struct {
+		Type       string `json:"type"`
+		Text       string `json:"text"`
+		StopReason string `json:"stop_reason"`
+	} `json:"delta"`
+	Error *ErrorDetail `json:"error,omitempty"`
+}
+
+// anthropicStreamDecoder turns a streaming /messages response into StreamChunks:
+// text deltas become content chunks and the final stop reason becomes a finish chunk.
+type anthropicStreamDecoder struct {
+	reader *bufio.Reader
+	id     string
+	model  string
+}
+
+func newAnthropicStreamDecoder(r io.Reader) *anthropicStreamDecoder {
+	return &anthropicStreamDecoder{reader: bufio.NewReader(r)}
+}
+
+// Decode reads events until the next one that carries content, returning io.EOF once
+// the message is complete.
+func (d *anthropicStreamDecoder) Decode() (StreamChunk, error) {
+	for {
+		data, err := d.nextEventData()
+		if err != nil {
+			return StreamChunk{}, err
+		}
+
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			continue // Skip malformed events
+		}
+
+		switch event.Type {
+		case "message_start":
+			d.id, d.model = event.Message.ID, event.Message.Model
+		case "content_block_delta":
+			if event.Delta.Type == "text_delta" {
+				return d.chunk(MessageDelta{Content: event.Delta.Text}, nil), nil
+			}
+		case "message_delta":
+			if event.Delta.StopReason != "" {
+				reason := event.Delta.StopReason
+				return d.chunk(MessageDelta{}, &reason), nil
+			}
+		case "message_stop":
+			return StreamChunk{}, io.EOF
+		case "error":
+			if event.Error != nil {
+				return StreamChunk{}, fmt.Errorf("API error: %s", event.Error.Message)
+			}
+			return StreamChunk{}, fmt.Errorf("API error: %s", data)
+		}
+	}
+}
+
+func (d *anthropicStreamDecoder) chunk(delta MessageDelta, finishReason *string) StreamChunk {
+	return StreamChunk{
+		ID:      d.id,
+		Model:   d.model,
+		Choices: []StreamDelta{{Delta: delta, FinishReason: finishReason}},
+	}
+}
+
+// nextEventData returns the data of the next SSE event, joining multi-line data fields.
+func (d *anthropicStreamDecoder) nextEventData() (string, error) {
+	var data []string
+	for {
+		line, err := d.reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if strings.HasPrefix(line, "data:") {
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		} else if line == "" && len(data) > 0 {
+			return strings.Join(data, "\n"), nil
+		}
+		if err != nil {
+			if len(data) > 0 {
+				return strings.Join(data, "\n"), nil
+			}
+			return "", err
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // Client provides AI/LLM capabilities using OpenAI or OpenRouter API.
@@ -26,11 +27,11 @@ func NewClient(config *Config) (*Client, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	// Deadlines are applied per request from the config rather than on the transport,
+	// which would otherwise also cut off long-running streams.
 	return &Client{
-		config: config,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
+		config:     config,
+		httpClient: &http.Client{},
 	}, nil
 }
 
@@ -77,37 +78,15 @@ func (c *Client) CompleteWithMessages(ctx context.Context, messages []Message, o
 }
 
 func (c *Client) doRequest(ctx context.Context, req *Request) (*Response, error) {
-	// Marshal request
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("marshal request: %w", err)
+	if timeout := c.config.requestTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	// Build URL
-	url := strings.TrimSuffix(c.config.BaseURL, "/") + "/chat/completions"
-
-	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	httpReq, err := c.newHTTPRequest(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	// Set headers
-	httpReq.Header.Set("Content-Type", "application/json")
-	apiKey := c.config.APIKey
-	if strings.TrimSpace(req.APIKeyOverride) != "" {
-		apiKey = req.APIKeyOverride
-	}
-	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
-
-	// Add OpenRouter-specific headers if applicable
-	if c.config.IsOpenRouter() {
-		if c.config.SiteURL != "" {
-			httpReq.Header.Set("HTTP-Referer", c.config.SiteURL)
-		}
-		if c.config.SiteName != "" {
-			httpReq.Header.Set("X-Title", c.config.SiteName)
-		}
+		return nil, err
 	}
 
 	// Execute request
@@ -133,6 +112,14 @@ func (c *Client) doRequest(ctx context.Context, req *Request) (*Response, error)
 	}
 
 	// Parse response
+	if c.config.provider() == ProviderAnthropic {
+		var message anthropicResponse
+		if err := json.Unmarshal(respBody, &message); err != nil {
+			return nil, fmt.Errorf("unmarshal response: %w", err)
+		}
+		return message.toResponse(), nil
+	}
+
 	var response Response
 	if err := json.Unmarshal(respBody, &response); err != nil {
 		return nil, fmt.Errorf("unmarshal response: %w", err)
@@ -141,8 +128,63 @@ func (c *Client) doRequest(ctx context.Context, req *Request) (*Response, error)
 	return &response, nil
 }
 
+// newHTTPRequest encodes req in the configured provider's format and sets its
+// endpoint and authentication headers.
+func (c *Client) newHTTPRequest(ctx context.Context, req *Request) (*http.Request, error) {
+	// Marshal request
+	var payload any = req
+	endpoint := "/chat/completions"
+	if c.config.provider() == ProviderAnthropic {
+		message, err := newAnthropicRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		payload = message
+		endpoint = "/messages"
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	// Build URL
+	url := strings.TrimSuffix(c.config.BaseURL, "/") + endpoint
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("Content-Type", "application/json")
+	apiKey := c.config.APIKey
+	if strings.TrimSpace(req.APIKeyOverride) != "" {
+		apiKey = req.APIKeyOverride
+	}
+	if c.config.provider() == ProviderAnthropic {
+		httpReq.Header.Set("x-api-key", apiKey)
+		httpReq.Header.Set("anthropic-version", anthropicVersion)
+	} else {
+		httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	// Add OpenRouter-specific headers if applicable
+	if c.config.IsOpenRouter() {
+		if c.config.SiteURL != "" {
+			httpReq.Header.Set("HTTP-Referer", c.config.SiteURL)
+		}
+		if c.config.SiteName != "" {
+			httpReq.Header.Set("X-Title", c.config.SiteName)
+		}
+	}
+
+	return httpReq, nil
+}
+
 // StreamComplete makes a streaming chat completion request.
-// Returns a channel of response chunks.
+// Returns a channel of response chunks. Cancelling ctx closes the response body,
+// closes the chunk channel, and sends ctx.Err() on the error channel.
 func (c *Client) StreamComplete(ctx context.Context, prompt string, opts ...Option) (<-chan StreamChunk, <-chan error) {
 	chunkCh := make(chan StreamChunk)
 	errCh := make(chan error, 1)
@@ -171,50 +213,52 @@ func (c *Client) StreamComplete(ctx context.Context, prompt string, opts ...Opti
 			}
 		}
 
-		// Marshal request
-		body, err := json.Marshal(req)
-		if err != nil {
-			errCh <- fmt.Errorf("marshal request: %w", err)
-			return
-		}
-
-		// Build URL
-		url := strings.TrimSuffix(c.config.BaseURL, "/") + "/chat/completions"
-
 		// Create HTTP request
-		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		streamCtx, cancelStream := context.WithCancel(ctx)
+		defer cancelStream()
+		httpReq, err := c.newHTTPRequest(streamCtx, req)
 		if err != nil {
-			errCh <- fmt.Errorf("create request: %w", err)
+			errCh <- err
 			return
 		}
-
-		// Set headers
-		httpReq.Header.Set("Content-Type", "application/json")
-		apiKey := c.config.APIKey
-		if strings.TrimSpace(req.APIKeyOverride) != "" {
-			apiKey = req.APIKeyOverride
-		}
-		httpReq.Header.Set("Authorization", "Bearer "+apiKey)
 		httpReq.Header.Set("Accept", "text/event-stream")
 
-		// Add OpenRouter-specific headers if applicable
-		if c.config.IsOpenRouter() {
-			if c.config.SiteURL != "" {
-				httpReq.Header.Set("HTTP-Referer", c.config.SiteURL)
-			}
-			if c.config.SiteName != "" {
-				httpReq.Header.Set("X-Title", c.config.SiteName)
-			}
+		// Execute request. The request timeout covers waiting for the response to
+		// start; once chunks are flowing only the caller's context can end the stream.
+		var startTimer *time.Timer
+		if timeout := c.config.requestTimeout(); timeout > 0 {
+			startTimer = time.AfterFunc(timeout, cancelStream)
 		}
-
-		// Execute request
 		httpResp, err := c.httpClient.Do(httpReq)
+		if startTimer != nil && !startTimer.Stop() {
+			if err == nil {
+				httpResp.Body.Close()
+			}
+			errCh <- fmt.Errorf("execute request: %w", context.DeadlineExceeded)
+			return
+		}
 		if err != nil {
 			errCh <- fmt.Errorf("execute request: %w", err)
 			return
 		}
 		defer httpResp.Body.Close()
 
+		// Closing the body unblocks a Decode that is waiting on the provider, so
+		// cancellation takes effect mid-stream rather than at the next chunk.
+		stop := context.AfterFunc(ctx, func() {
+			httpResp.Body.Close()
+		})
+		defer stop()
+
+		// The idle timeout bounds each wait for the next chunk, so a stalled provider
+		// cannot hold the stream open while one that keeps sending is never cut off.
+		idleTimeout := c.config.streamIdleTimeout()
+		var idleTimer *time.Timer
+		if idleTimeout > 0 {
+			idleTimer = time.AfterFunc(idleTimeout, cancelStream)
+			defer idleTimer.Stop()
+		}
+
 		// Check for errors
 		if httpResp.StatusCode >= 400 {
 			respBody, _ := io.ReadAll(httpResp.Body)
@@ -223,9 +267,20 @@ func (c *Client) StreamComplete(ctx context.Context, prompt string, opts ...Opti
 		}
 
 		// Parse SSE stream
-		decoder := NewSSEDecoder(httpResp.Body)
+		var decoder interface{ Decode() (StreamChunk, error) } = NewSSEDecoder(httpResp.Body)
+		if c.config.provider() == ProviderAnthropic {
+			decoder = newAnthropicStreamDecoder(httpResp.Body)
+		}
 		for {
 			chunk, err := decoder.Decode()
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				errCh <- ctxErr
+				return
+			}
+			if idleTimer != nil && !idleTimer.Stop() {
+				errCh <- fmt.Errorf("decode stream: %w", context.DeadlineExceeded)
+				return
+			}
 			if err != nil {
 				if err != io.EOF {
 					errCh <- fmt.Errorf("decode stream: %w", err)
@@ -239,6 +294,9 @@ func (c *Client) StreamComplete(ctx context.Context, prompt string, opts ...Opti
 				return
 			case chunkCh <- chunk:
 			}
+			if idleTimer != nil {
+				idleTimer.Reset(idleTimeout)
+			}
 		}
 	}()
 
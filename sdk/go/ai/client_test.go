@@ -146,6 +146,96 @@ func TestComplete_WithAPIKeyOverride(t *testing.T) {
 	assert.NotNil(t, resp)
 }
 
+func TestComplete_Providers(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		handler  func(t *testing.T, w http.ResponseWriter, r *http.Request)
+	}{
+		{
+			name:     "openai",
+			provider: ProviderOpenAI,
+			handler: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/chat/completions", r.URL.Path)
+				assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+				var req Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				require.Len(t, req.Messages, 2)
+				assert.Equal(t, "system", req.Messages[0].Role)
+
+				json.NewEncoder(w).Encode(Response{
+					ID:      "chatcmpl-1",
+					Choices: []Choice{{Message: Message{Role: "assistant", Content: "Hi there"}, FinishReason: "stop"}},
+				})
+			},
+		},
+		{
+			name:     "anthropic",
+			provider: ProviderAnthropic,
+			handler: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/messages", r.URL.Path)
+				assert.Equal(t, "test-key", r.Header.Get("x-api-key"))
+				assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+				assert.Empty(t, r.Header.Get("Authorization"))
+
+				var req map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "Be brief", req["system"])
+				assert.Equal(t, float64(256), req["max_tokens"])
+				assert.Equal(t, []any{map[string]any{"role": "user", "content": "Hello"}}, req["messages"])
+
+				w.Write([]byte(`{
+					"id": "msg_1",
+					"type": "message",
+					"role": "assistant",
+					"model": "claude-sonnet-4",
+					"content": [{"type": "text", "text": "Hi "}, {"type": "text", "text": "there"}],
+					"stop_reason": "end_turn",
+					"usage": {"input_tokens": 4, "output_tokens": 2}
+				}`))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tt.handler(t, w, r)
+			}))
+			defer server.Close()
+
+			client, err := NewClient(&Config{
+				Provider:  tt.provider,
+				APIKey:    "test-key",
+				BaseURL:   server.URL,
+				Model:     "test-model",
+				MaxTokens: 256,
+			})
+			require.NoError(t, err)
+
+			resp, err := client.Complete(context.Background(), "Hello", WithSystem("Be brief"))
+			require.NoError(t, err)
+			assert.Equal(t, "Hi there", resp.Text())
+		})
+	}
+}
+
+func TestComplete_AnthropicRejectsResponseFormat(t *testing.T) {
+	client, err := NewClient(&Config{
+		Provider:  ProviderAnthropic,
+		APIKey:    "test-key",
+		BaseURL:   "http://127.0.0.1:0",
+		Model:     "claude-sonnet-4",
+		MaxTokens: 256,
+	})
+	require.NoError(t, err)
+
+	_, err = client.Complete(context.Background(), "Hello", WithJSONMode())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported by the anthropic provider")
+}
+
 func TestComplete_WithOptions(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var req Request
@@ -420,6 +510,141 @@ func TestStreamComplete(t *testing.T) {
 	}
 }
 
+func TestStreamComplete_Anthropic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/messages", r.URL.Path)
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+		var req anthropicRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, req.Stream)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(`event: message_start
+data: {"type":"message_start","message":{"id":"msg_1","model":"claude-sonnet-4"}}
+
+event: content_block_start
+data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}
+
+event: ping
+data: {"type":"ping"}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":" world"}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":0}
+
+event: message_delta
+data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":2}}
+
+event: message_stop
+data: {"type":"message_stop"}
+
+`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		Provider:  ProviderAnthropic,
+		APIKey:    "test-key",
+		BaseURL:   server.URL,
+		Model:     "claude-sonnet-4",
+		MaxTokens: 256,
+	})
+	require.NoError(t, err)
+
+	chunks, errs := client.StreamComplete(context.Background(), "Hello")
+
+	var text strings.Builder
+	var finish string
+	for chunk := range chunks {
+		require.Len(t, chunk.Choices, 1)
+		assert.Equal(t, "msg_1", chunk.ID)
+		text.WriteString(chunk.Choices[0].Delta.Content)
+		if chunk.Choices[0].FinishReason != nil {
+			finish = *chunk.Choices[0].FinishReason
+		}
+	}
+	assert.NoError(t, <-errs)
+	assert.Equal(t, "Hello world", text.String())
+	assert.Equal(t, "end_turn", finish)
+}
+
+func TestStreamComplete_RequestTimeoutCoversStartOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		for _, chunk := range []string{
+			`data: {"id":"chatcmpl-123","choices":[{"delta":{"content":"Hello"}}]}`,
+			`data: {"id":"chatcmpl-123","choices":[{"delta":{"content":" world"}}]}`,
+			`data: [DONE]`,
+		} {
+			w.Write([]byte(chunk + "\n\n"))
+			w.(http.Flusher).Flush()
+			// Chunks arrive slower than the request timeout.
+			time.Sleep(100 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		APIKey:            "test-key",
+		BaseURL:           server.URL,
+		Model:             "gpt-4o",
+		RequestTimeout:    50 * time.Millisecond,
+		StreamIdleTimeout: time.Second,
+	})
+	require.NoError(t, err)
+
+	chunks, errs := client.StreamComplete(context.Background(), "Hello")
+
+	var received []StreamChunk
+	for chunk := range chunks {
+		received = append(received, chunk)
+	}
+	assert.NoError(t, <-errs)
+	assert.Len(t, received, 2)
+}
+
+func TestStreamComplete_IdleTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`data: {"id":"chatcmpl-123","choices":[{"delta":{"content":"Hello"}}]}` + "\n\n"))
+		w.(http.Flusher).Flush()
+		// The provider stalls after the first chunk.
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		APIKey:            "test-key",
+		BaseURL:           server.URL,
+		Model:             "gpt-4o",
+		RequestTimeout:    time.Second,
+		StreamIdleTimeout: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	chunks, errs := client.StreamComplete(context.Background(), "Hello")
+
+	var received []StreamChunk
+	for chunk := range chunks {
+		received = append(received, chunk)
+	}
+	assert.ErrorIs(t, <-errs, context.DeadlineExceeded)
+	assert.Len(t, received, 1)
+}
+
 func TestStreamComplete_ErrorHandling(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
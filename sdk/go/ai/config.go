@@ -2,18 +2,32 @@ package ai
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"time"
 )
 
+// Supported values for Config.Provider.
+const (
+	// ProviderOpenAI speaks the OpenAI /chat/completions format, also used by OpenRouter.
+	ProviderOpenAI = "openai"
+	// ProviderAnthropic speaks Anthropic's /messages format.
+	ProviderAnthropic = "anthropic"
+)
+
 // Config holds AI/LLM configuration for making API calls.
 type Config struct {
-	// API Key for OpenAI or OpenRouter
+	// Provider selects the request and response format: ProviderOpenAI or
+	// ProviderAnthropic. Empty means ProviderOpenAI.
+	Provider string
+
+	// API Key for OpenAI, OpenRouter, or Anthropic
 	APIKey string
 
 	// BaseURL can be either OpenAI or OpenRouter endpoint
 	// Default: https://api.openai.com/v1
 	// OpenRouter: https://openrouter.ai/api/v1
+	// Anthropic: https://api.anthropic.com/v1
 	BaseURL string
 
 	// Default model to use (e.g., "gpt-4o", "openai/gpt-4o" for OpenRouter)
@@ -25,9 +39,20 @@ type Config struct {
 	// Default max tokens for responses
 	MaxTokens int
 
-	// HTTP timeout for requests
+	// HTTP timeout for requests. Used as the request timeout when RequestTimeout is unset.
 	Timeout time.Duration
 
+	// RequestTimeout bounds each completion call through its request context, so slow
+	// reasoning models can be given more time without affecting other HTTP clients.
+	// Streaming calls apply it only until the response starts; after that the stream
+	// is bounded by StreamIdleTimeout and the caller's context. Zero falls back to Timeout.
+	RequestTimeout time.Duration
+
+	// StreamIdleTimeout bounds the wait for each chunk of a streaming call, so a
+	// stalled stream fails while a long one that keeps producing output runs to the
+	// end. Zero falls back to the request timeout.
+	StreamIdleTimeout time.Duration
+
 	// Optional: Site URL for OpenRouter rankings
 	SiteURL string
 
@@ -81,9 +106,43 @@ func (c *Config) Validate() error {
 	if c.Model == "" {
 		return errors.New("model is required")
 	}
+	switch c.provider() {
+	case ProviderOpenAI:
+	case ProviderAnthropic:
+		// The messages API rejects requests without max_tokens.
+		if c.MaxTokens <= 0 {
+			return errors.New("max tokens is required for the anthropic provider")
+		}
+	default:
+		return fmt.Errorf("unsupported provider %q", c.Provider)
+	}
 	return nil
 }
 
+// provider returns the configured provider, defaulting to ProviderOpenAI.
+func (c *Config) provider() string {
+	if c.Provider == "" {
+		return ProviderOpenAI
+	}
+	return c.Provider
+}
+
+// requestTimeout returns the deadline applied to each completion call, or zero for none.
+func (c *Config) requestTimeout() time.Duration {
+	if c.RequestTimeout > 0 {
+		return c.RequestTimeout
+	}
+	return c.Timeout
+}
+
+// streamIdleTimeout returns the longest gap allowed between stream chunks, or zero for none.
+func (c *Config) streamIdleTimeout() time.Duration {
+	if c.StreamIdleTimeout > 0 {
+		return c.StreamIdleTimeout
+	}
+	return c.requestTimeout()
+}
+
 // IsOpenRouter returns true if the base URL is for OpenRouter.
 func (c *Config) IsOpenRouter() bool {
 	return c.BaseURL == "https://openrouter.ai/api/v1" ||
@@ -168,6 +168,37 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "anthropic with max tokens",
+			config: &Config{
+				Provider:  ProviderAnthropic,
+				APIKey:    "test-key",
+				BaseURL:   "https://api.anthropic.com/v1",
+				Model:     "claude-sonnet-4",
+				MaxTokens: 1024,
+			},
+			wantErr: false,
+		},
+		{
+			name: "anthropic without max tokens",
+			config: &Config{
+				Provider: ProviderAnthropic,
+				APIKey:   "test-key",
+				BaseURL:  "https://api.anthropic.com/v1",
+				Model:    "claude-sonnet-4",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown provider",
+			config: &Config{
+				Provider: "mystery",
+				APIKey:   "test-key",
+				BaseURL:  "https://api.example.com/v1",
+				Model:    "gpt-4o",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -2,13 +2,16 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
-	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -21,6 +24,9 @@ type Client struct {
 	httpClient *http.Client
 	token      string
 	apiKey     string
+	logger     *log.Logger
+	compress   bool
+	teamID     string
 }
 
 // Option mutates Client configuration.
@@ -49,6 +55,70 @@ func WithAPIKey(key string) Option {
 	}
 }
 
+// WithLogger enables debug logging of each request's method, URL, status, and duration.
+// Authorization and X-API-Key header values are redacted.
+func WithLogger(logger *log.Logger) Option {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// teamHeader carries the client's team scope on every request.
+const teamHeader = "X-Team-ID"
+
+// WithTeam scopes the client to a team. ListNodes only returns the team's nodes, and
+// every request, including status updates, carries the team in the X-Team-ID header.
+func WithTeam(teamID string) Option {
+	return func(c *Client) {
+		c.teamID = strings.TrimSpace(teamID)
+	}
+}
+
+// compressionThreshold is the minimum encoded body size, in bytes, that
+// WithRequestCompression will gzip.
+const compressionThreshold = 1024
+
+// WithRequestCompression gzip-compresses request bodies larger than 1 KiB and sets
+// Content-Encoding accordingly.
+func WithRequestCompression() Option {
+	return func(c *Client) {
+		c.compress = true
+	}
+}
+
+// RequestOption tunes a single API call.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	timeout time.Duration
+	query   url.Values
+}
+
+// WithRequestTimeout bounds a single call, overriding the client's default timeout.
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = d
+	}
+}
+
+// withQuery adds query parameters to a single call.
+func withQuery(query url.Values) RequestOption {
+	return func(o *requestOptions) {
+		o.query = query
+	}
+}
+
+// ListNodesOptions filters ListNodes.
+type ListNodesOptions struct {
+	// HealthStatus restricts the result to nodes in this health state. The control
+	// plane returns only active nodes when it is empty and ShowAll is false.
+	HealthStatus string
+	// ShowAll includes nodes in every health state.
+	ShowAll bool
+}
+
 // New creates a new Client instance.
 func New(baseURL string, opts ...Option) (*Client, error) {
 	if baseURL == "" {
@@ -65,6 +135,7 @@ func New(baseURL string, opts ...Option) (*Client, error) {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		logger: log.New(io.Discard, "", 0),
 	}
 
 	for _, opt := range opts {
@@ -75,15 +146,15 @@ func New(baseURL string, opts ...Option) (*Client, error) {
 }
 
 // RegisterNode registers or updates the agent node with the control plane.
-func (c *Client) RegisterNode(ctx context.Context, payload types.NodeRegistrationRequest) (*types.NodeRegistrationResponse, error) {
+func (c *Client) RegisterNode(ctx context.Context, payload types.NodeRegistrationRequest, opts ...RequestOption) (*types.NodeRegistrationResponse, error) {
 	payload.LastHeartbeat = payload.LastHeartbeat.UTC()
 	payload.RegisteredAt = payload.RegisteredAt.UTC()
 
 	var resp types.NodeRegistrationResponse
-	if err := c.do(ctx, http.MethodPost, "/api/v1/nodes", payload, &resp); err != nil {
-		if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusNotFound {
+	if err := c.do(ctx, http.MethodPost, "/api/v1/nodes", payload, &resp, opts...); err != nil {
+		if errors.Is(err, ErrNotFound) {
 			// Fallback to legacy registration endpoint for older servers.
-			if fallbackErr := c.do(ctx, http.MethodPost, "/api/v1/nodes/register", payload, &resp); fallbackErr != nil {
+			if fallbackErr := c.do(ctx, http.MethodPost, "/api/v1/nodes/register", payload, &resp, opts...); fallbackErr != nil {
 				return nil, fallbackErr
 			}
 			return &resp, nil
@@ -93,13 +164,34 @@ func (c *Client) RegisterNode(ctx context.Context, payload types.NodeRegistratio
 	return &resp, nil
 }
 
+// ListNodes lists the nodes registered with the control plane, limited to the client's
+// team when it was created WithTeam.
+func (c *Client) ListNodes(ctx context.Context, filter ListNodesOptions, opts ...RequestOption) (*types.NodeListResponse, error) {
+	query := url.Values{}
+	if c.teamID != "" {
+		query.Set("team_id", c.teamID)
+	}
+	if filter.HealthStatus != "" {
+		query.Set("health_status", filter.HealthStatus)
+	}
+	if filter.ShowAll {
+		query.Set("show_all", "true")
+	}
+
+	var resp types.NodeListResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/nodes", nil, &resp, append(opts, withQuery(query))...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // UpdateStatus renews the node lease and optionally reports lifecycle changes.
-func (c *Client) UpdateStatus(ctx context.Context, nodeID string, payload types.NodeStatusUpdate) (*types.LeaseResponse, error) {
+func (c *Client) UpdateStatus(ctx context.Context, nodeID string, payload types.NodeStatusUpdate, opts ...RequestOption) (*types.LeaseResponse, error) {
 	var resp types.LeaseResponse
 	route := fmt.Sprintf("/api/v1/nodes/%s/status", url.PathEscape(nodeID))
-	if err := c.do(ctx, http.MethodPatch, route, payload, &resp); err != nil {
-		if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusNotFound {
-			return c.legacyHeartbeat(ctx, nodeID, payload)
+	if err := c.do(ctx, http.MethodPatch, route, payload, &resp, opts...); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return c.legacyHeartbeat(ctx, nodeID, payload, opts...)
 		}
 		return nil, err
 	}
@@ -107,43 +199,64 @@ func (c *Client) UpdateStatus(ctx context.Context, nodeID string, payload types.
 }
 
 // AcknowledgeAction notifies the control plane that a pushed action completed.
-func (c *Client) AcknowledgeAction(ctx context.Context, nodeID string, payload types.ActionAckRequest) (*types.LeaseResponse, error) {
+func (c *Client) AcknowledgeAction(ctx context.Context, nodeID string, payload types.ActionAckRequest, opts ...RequestOption) (*types.LeaseResponse, error) {
 	var resp types.LeaseResponse
 	route := fmt.Sprintf("/api/v1/nodes/%s/actions/ack", url.PathEscape(nodeID))
-	if err := c.do(ctx, http.MethodPost, route, payload, &resp); err != nil {
+	if err := c.do(ctx, http.MethodPost, route, payload, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
 // Shutdown informs the control plane that the node is shutting down gracefully.
-func (c *Client) Shutdown(ctx context.Context, nodeID string, payload types.ShutdownRequest) (*types.LeaseResponse, error) {
+func (c *Client) Shutdown(ctx context.Context, nodeID string, payload types.ShutdownRequest, opts ...RequestOption) (*types.LeaseResponse, error) {
 	var resp types.LeaseResponse
 	route := fmt.Sprintf("/api/v1/nodes/%s/shutdown", url.PathEscape(nodeID))
-	if err := c.do(ctx, http.MethodPost, route, payload, &resp); err != nil {
+	if err := c.do(ctx, http.MethodPost, route, payload, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
-func (c *Client) do(ctx context.Context, method string, endpoint string, body any, out any) error {
+func (c *Client) do(ctx context.Context, method string, endpoint string, body any, out any, opts ...RequestOption) error {
+	var ro requestOptions
+	for _, opt := range opts {
+		opt(&ro)
+	}
+
+	httpClient := c.httpClient
+	if ro.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ro.timeout)
+		defer cancel()
+
+		// The call's deadline replaces the client-wide timeout for this request only.
+		override := *c.httpClient
+		override.Timeout = 0
+		httpClient = &override
+	}
+
 	u := *c.baseURL
-	rel := strings.TrimPrefix(endpoint, "/")
-	basePath := strings.TrimSuffix(c.baseURL.Path, "/")
-	if basePath == "" {
-		u.Path = "/" + rel
-	} else {
-		u.Path = path.Join(basePath, rel)
-		if !strings.HasPrefix(u.Path, "/") {
-			u.Path = "/" + u.Path
-		}
+	u.Path = joinURLPath(c.baseURL.Path, endpoint)
+	u.RawPath = ""
+	if len(ro.query) > 0 {
+		u.RawQuery = ro.query.Encode()
 	}
 
-	var buf io.ReadWriter = &bytes.Buffer{}
+	buf := &bytes.Buffer{}
+	compressed := false
 	if body != nil {
 		if err := json.NewEncoder(buf).Encode(body); err != nil {
 			return fmt.Errorf("encode request: %w", err)
 		}
+		if c.compress && buf.Len() > compressionThreshold {
+			gzipped, err := gzipBody(buf.Bytes())
+			if err != nil {
+				return fmt.Errorf("compress request: %w", err)
+			}
+			buf = gzipped
+			compressed = true
+		}
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
@@ -154,6 +267,9 @@ func (c *Client) do(ctx context.Context, method string, endpoint string, body an
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 	req.Header.Set("Accept", "application/json")
 
 	if c.token != "" {
@@ -162,12 +278,18 @@ func (c *Client) do(ctx context.Context, method string, endpoint string, body an
 	if c.apiKey != "" {
 		req.Header.Set("X-API-Key", c.apiKey)
 	}
+	if c.teamID != "" {
+		req.Header.Set(teamHeader, c.teamID)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	start := time.Now()
+	resp, err := httpClient.Do(req)
 	if err != nil {
+		c.logRequest(req, 0, time.Since(start), err)
 		return fmt.Errorf("perform request: %w", err)
 	}
 	defer resp.Body.Close()
+	c.logRequest(req, resp.StatusCode, time.Since(start), nil)
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -192,9 +314,62 @@ func (c *Client) do(ctx context.Context, method string, endpoint string, body an
 	return nil
 }
 
-func (c *Client) legacyHeartbeat(ctx context.Context, nodeID string, payload types.NodeStatusUpdate) (*types.LeaseResponse, error) {
+func gzipBody(data []byte) (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	zw := gzip.NewWriter(buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// joinURLPath appends endpoint to the base URL's path, normalising the slashes
+// between them. Unlike path.Join it does not clean the result, so a trailing slash
+// on the endpoint is preserved.
+func joinURLPath(basePath, endpoint string) string {
+	base := strings.Trim(basePath, "/")
+	rel := strings.TrimPrefix(endpoint, "/")
+	if base == "" {
+		return "/" + rel
+	}
+	return "/" + base + "/" + rel
+}
+
+// redactedHeaders lists request headers whose values must never be logged.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"X-Api-Key":     true,
+}
+
+func (c *Client) logRequest(req *http.Request, status int, duration time.Duration, err error) {
+	keys := make([]string, 0, len(req.Header))
+	for key := range req.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	headers := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := strings.Join(req.Header[key], ",")
+		if redactedHeaders[http.CanonicalHeaderKey(key)] {
+			value = "[REDACTED]"
+		}
+		headers = append(headers, key+"="+value)
+	}
+
+	if err != nil {
+		c.logger.Printf("debug: %s %s error=%v duration=%s headers=%s", req.Method, req.URL.Redacted(), err, duration, strings.Join(headers, " "))
+		return
+	}
+	c.logger.Printf("debug: %s %s status=%d duration=%s headers=%s", req.Method, req.URL.Redacted(), status, duration, strings.Join(headers, " "))
+}
+
+func (c *Client) legacyHeartbeat(ctx context.Context, nodeID string, payload types.NodeStatusUpdate, opts ...RequestOption) (*types.LeaseResponse, error) {
 	route := fmt.Sprintf("/api/v1/nodes/%s/heartbeat", url.PathEscape(nodeID))
-	if err := c.do(ctx, http.MethodPost, route, payload, nil); err != nil {
+	if err := c.do(ctx, http.MethodPost, route, payload, nil, opts...); err != nil {
 		return nil, err
 	}
 	lease := 120 * time.Second
@@ -204,7 +379,16 @@ func (c *Client) legacyHeartbeat(ctx context.Context, nodeID string, payload typ
 	}, nil
 }
 
+// Sentinel errors matched by APIError via errors.Is for common status codes.
+var (
+	ErrNotFound     = errors.New("agentfield: not found")
+	ErrUnauthorized = errors.New("agentfield: unauthorized")
+	ErrConflict     = errors.New("agentfield: conflict")
+)
+
 // APIError captures non-success responses from the AgentField API.
+// Use errors.Is with ErrNotFound, ErrUnauthorized or ErrConflict to check the
+// common cases, or errors.As to access the status code and body.
 type APIError struct {
 	StatusCode int
 	Body       []byte
@@ -213,3 +397,16 @@ type APIError struct {
 func (e *APIError) Error() string {
 	return fmt.Sprintf("agentfield api error (%d): %s", e.StatusCode, strings.TrimSpace(string(e.Body)))
 }
+
+// Is reports whether the error's status code corresponds to target.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
@@ -10,6 +10,14 @@ type ReasonerDefinition struct {
 	ID           string          `json:"id"`
 	InputSchema  json.RawMessage `json:"input_schema"`
 	OutputSchema json.RawMessage `json:"output_schema"`
+	Deprecation  *Deprecation    `json:"deprecation,omitempty"`
+	RequiredEnv  []string        `json:"required_env,omitempty"`
+}
+
+// Deprecation marks a reasoner whose contract is being phased out.
+type Deprecation struct {
+	Since   string `json:"since"`
+	Message string `json:"message,omitempty"`
 }
 
 // SkillDefinition is included for completeness.
@@ -17,6 +25,7 @@ type SkillDefinition struct {
 	ID          string          `json:"id"`
 	InputSchema json.RawMessage `json:"input_schema"`
 	Tags        []string        `json:"tags,omitempty"`
+	RequiredEnv []string        `json:"required_env,omitempty"`
 }
 
 // CommunicationConfig declares supported protocols for the agent.
@@ -56,6 +65,27 @@ type NodeRegistrationResponse struct {
 	RegisteredAt      time.Time `json:"-"`
 }
 
+// NodeInfo is the subset of a registered node returned by the node listing API.
+type NodeInfo struct {
+	ID              string               `json:"id"`
+	TeamID          string               `json:"team_id"`
+	BaseURL         string               `json:"base_url"`
+	Version         string               `json:"version"`
+	DeploymentType  string               `json:"deployment_type,omitempty"`
+	Reasoners       []ReasonerDefinition `json:"reasoners"`
+	Skills          []SkillDefinition    `json:"skills"`
+	HealthStatus    string               `json:"health_status"`
+	LifecycleStatus string               `json:"lifecycle_status"`
+	LastHeartbeat   time.Time            `json:"last_heartbeat"`
+	RegisteredAt    time.Time            `json:"registered_at"`
+}
+
+// NodeListResponse wraps the nodes returned by ListNodes.
+type NodeListResponse struct {
+	Nodes []NodeInfo `json:"nodes"`
+	Count int        `json:"count"`
+}
+
 // NodeStatusUpdate is used for lease renewals.
 type NodeStatusUpdate struct {
 	Phase       string `json:"phase"`
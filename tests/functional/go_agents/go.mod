@@ -6,4 +6,8 @@ replace github.com/Agent-Field/agentfield/sdk/go => ../../../sdk/go
 
 require github.com/Agent-Field/agentfield/sdk/go v0.0.0-00010101000000-000000000000
 
-require gopkg.in/yaml.v3 v3.0.1 // indirect
+require (
+	github.com/vmihailenco/msgpack/v5 v5.4.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)